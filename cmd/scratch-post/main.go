@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -18,14 +18,30 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/curious-kitten/scratch-post/internal/commands/executions"
 	"github.com/curious-kitten/scratch-post/internal/commands/generate"
+	"github.com/curious-kitten/scratch-post/internal/commands/importer"
+	"github.com/curious-kitten/scratch-post/internal/commands/login"
+	"github.com/curious-kitten/scratch-post/internal/commands/logout"
+	"github.com/curious-kitten/scratch-post/internal/commands/plans"
+	"github.com/curious-kitten/scratch-post/internal/commands/projects"
+	"github.com/curious-kitten/scratch-post/internal/commands/report"
+	"github.com/curious-kitten/scratch-post/internal/commands/scenarios"
 	"github.com/curious-kitten/scratch-post/internal/commands/start"
 )
 
 func init() {
 	Root.AddCommand(
 		generate.Command,
+		importer.Command,
+		report.Command,
 		start.Command,
+		login.Command,
+		logout.Command,
+		projects.Command,
+		scenarios.Command,
+		executions.Command,
+		plans.Command,
 	)
 }
 
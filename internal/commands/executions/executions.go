@@ -0,0 +1,19 @@
+package executions
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/executions/executionupdate"
+)
+
+func init() {
+	Command.AddCommand(
+		executionupdate.Command,
+	)
+}
+
+// Command is used to colocate all the execution commands
+var Command = &cobra.Command{
+	Use:   "executions",
+	Short: "executions is used to manage the executions of the server the user is logged in to",
+}
@@ -0,0 +1,92 @@
+package executionupdate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/output"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/testreport"
+)
+
+var config remote.Config
+var format output.Flags
+var status string
+var actualResult string
+var duration float64
+
+func init() {
+	config.Register(Command)
+	format.Register(Command)
+	Command.Flags().StringVar(&status, "status", "", "status of the execution, Pending, Fail, Pass or Skipped")
+	Command.Flags().StringVar(&actualResult, "actualResult", "", "what happened when the scenario was executed")
+	Command.Flags().Float64Var(&duration, "duration", 0, "duration of the execution in seconds")
+}
+
+var Command = &cobra.Command{
+	Use:   "update ID",
+	Short: "update changes the result of an execution and prints it",
+	Long: `update changes the result of an execution and prints it.
+	Only the fields of the flags that are set are changed.`,
+	Example: `  scratch-post executions update 4c65a2a6800b9c5 --status Fail --actualResult "the cart is empty"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := format.Validate(); err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		execution, err := c.Executions.Get(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("status") {
+			value, err := parseStatus(status)
+			if err != nil {
+				return err
+			}
+			execution.Status = value
+		}
+		if cmd.Flags().Changed("actualResult") {
+			execution.ActualResult = actualResult
+		}
+		if cmd.Flags().Changed("duration") {
+			execution.Duration = duration
+		}
+		updated, err := c.Executions.Update(ctx, args[0], execution)
+		if err != nil {
+			return err
+		}
+		return format.Print(cmd.OutOrStdout(), updated, Columns(updated))
+	},
+}
+
+// parseStatus returns the status with the name, ignoring the case
+func parseStatus(name string) (executionv1.Status, error) {
+	for statusName, value := range executionv1.Status_value {
+		if strings.EqualFold(statusName, name) {
+			return executionv1.Status(value), nil
+		}
+	}
+	return executionv1.Status_Pending, fmt.Errorf("status has to be Pending, Fail, Pass or Skipped")
+}
+
+// Columns describes the table of executions
+func Columns(executions ...*executionv1.Execution) output.Columns {
+	return output.Columns{
+		Headers: []string{"ID", "SCENARIO", "NAME", "CONFIGURATION", "STATUS", "DURATION"},
+		Rows:    len(executions),
+		Row: func(i int) []string {
+			e := executions[i]
+			return []string{e.Identity.GetId(), e.ScenarioId, e.Name, testreport.Configuration(e.Configuration), e.Status.String(), strconv.FormatFloat(e.Duration, 'f', -1, 64)}
+		},
+	}
+}
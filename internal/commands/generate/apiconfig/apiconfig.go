@@ -11,11 +11,20 @@ import (
 
 var rootPrefix string
 var port string
+var grpcPort string
 var probes string
 var projects string
 var scenarios string
 var testplans string
 var executions string
+var stepblocks string
+var folders string
+var requirements string
+var issues string
+var releases string
+var openapi string
+var graphql string
+var validateRequests bool
 var adminPrefix string
 var users string
 var file string
@@ -23,11 +32,20 @@ var file string
 func init() {
 	Command.Flags().StringVar(&rootPrefix, "rootPrefix", "/api/v1", "prefix for all api endpoints")
 	Command.Flags().StringVar(&port, "port", "9090", "port for the server")
+	Command.Flags().StringVar(&grpcPort, "grpcPort", "9091", "port for the gRPC API. The gRPC API is not served when empty")
 	Command.Flags().StringVar(&probes, "probes", "/probes", "probes endpoints")
 	Command.Flags().StringVar(&projects, "projects", "/projects", "projects endpoint")
 	Command.Flags().StringVar(&testplans, "testplans", "/testplans", "testplans endpoint")
 	Command.Flags().StringVar(&scenarios, "scenarios", "/scenarios", "scenarios endpoint")
 	Command.Flags().StringVar(&executions, "executions", "/executions", "executions endpoint")
+	Command.Flags().StringVar(&stepblocks, "stepblocks", "/stepblocks", "step blocks endpoint")
+	Command.Flags().StringVar(&folders, "folders", "/folders", "folders endpoint")
+	Command.Flags().StringVar(&requirements, "requirements", "/requirements", "requirements endpoint")
+	Command.Flags().StringVar(&issues, "issues", "/issues", "issues endpoint")
+	Command.Flags().StringVar(&releases, "releases", "/releases", "releases endpoint")
+	Command.Flags().StringVar(&openapi, "openapi", "/openapi.json", "endpoint of the OpenAPI document. The document is not served when empty")
+	Command.Flags().StringVar(&graphql, "graphql", "/graphql", "endpoint of the GraphQL API. The GraphQL API is not served when empty")
+	Command.Flags().BoolVar(&validateRequests, "validateRequests", false, "reject the requests that do not match the OpenAPI document")
 	Command.Flags().StringVar(&adminPrefix, "adminPrefix", "/admin", "prefix for all admin endpoints")
 	Command.Flags().StringVar(&users, "users", "/users", "users endpoint. Is part of the admin endpoints")
 
@@ -40,14 +58,23 @@ var Command = &cobra.Command{
 	Short: "api-config generates JSON file for configuring the REST API",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		storeConfig := endpoints.Config{
-			RootPrefix: rootPrefix,
-			Port:       port,
+			RootPrefix:       rootPrefix,
+			Port:             port,
+			GRPCPort:         grpcPort,
+			ValidateRequests: validateRequests,
 			Endpoints: endpoints.Endpoints{
-				Probes:     probes,
-				Projects:   projects,
-				Scenarios:  scenarios,
-				TestPlans:  testplans,
-				Executions: executions,
+				Probes:       probes,
+				Projects:     projects,
+				Scenarios:    scenarios,
+				TestPlans:    testplans,
+				Executions:   executions,
+				StepBlocks:   stepblocks,
+				Folders:      folders,
+				Requirements: requirements,
+				Issues:       issues,
+				Releases:     releases,
+				OpenAPI:      openapi,
+				GraphQL:      graphql,
 				Admin: endpoints.Admin{
 					Prefix: adminPrefix,
 					Users:  users,
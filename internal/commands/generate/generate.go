@@ -5,6 +5,7 @@ import (
 
 	"github.com/curious-kitten/scratch-post/internal/commands/generate/adminconfig"
 	"github.com/curious-kitten/scratch-post/internal/commands/generate/apiconfig"
+	"github.com/curious-kitten/scratch-post/internal/commands/generate/issuetrackerconfig"
 	"github.com/curious-kitten/scratch-post/internal/commands/generate/storeconfig"
 )
 
@@ -13,6 +14,7 @@ func init() {
 		storeconfig.Command,
 		adminconfig.Command,
 		apiconfig.Command,
+		issuetrackerconfig.Command,
 	)
 }
 
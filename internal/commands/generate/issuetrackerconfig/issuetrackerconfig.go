@@ -0,0 +1,89 @@
+package issuetrackerconfig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/pkg/issuetracker"
+)
+
+var trackerType string
+var refreshInterval string
+var closedStates []string
+var webhookSecret string
+var issueURL string
+var trackerURL string
+var stateField string
+var headers map[string]string
+var webhookLinkField string
+var webhookLinkTemplate string
+var webhookStateField string
+var createURL string
+var createBody string
+var createdLinkField string
+var createdLinkTemplate string
+var file string
+
+func init() {
+	Command.Flags().StringVar(&trackerType, "type", "rest", "type of the issue tracker connector")
+	Command.Flags().StringVar(&refreshInterval, "refreshInterval", "15m", "time between two refreshes of the linked issues. Empty disables the refresh")
+	Command.Flags().StringSliceVar(&closedStates, "closedStates", issuetracker.DefaultClosedStates, "states of an issue which are considered closed")
+	Command.Flags().StringVar(&webhookSecret, "webhookSecret", "", "secret sent by the issue tracker when calling the webhook. Empty disables the webhook")
+	Command.Flags().StringVar(&issueURL, "issueURL", "", "template of the URL used to retrieve an issue, ie. https://tracker/rest/api/issue/{id}. The issue link is used when empty")
+	Command.Flags().StringVar(&trackerURL, "trackerURL", "", "base URL of the issue tracker. When issueURL is empty, only the issue links under it are retrieved")
+	Command.Flags().StringVar(&stateField, "stateField", "state", "field of the issue that contains the state, ie. fields.status.name")
+	Command.Flags().StringToStringVar(&headers, "headers", map[string]string{}, "headers added to the requests sent to the issue tracker, ie. Authorization=\"Bearer token\"")
+	Command.Flags().StringVar(&webhookLinkField, "webhookLinkField", "link", "field of the webhook payload that contains the issue link or ID")
+	Command.Flags().StringVar(&webhookLinkTemplate, "webhookLinkTemplate", "", "template used to build the issue link from the webhook, ie. https://tracker/browse/{id}")
+	Command.Flags().StringVar(&webhookStateField, "webhookStateField", "state", "field of the webhook payload that contains the state of the issue")
+	Command.Flags().StringVar(&createURL, "createURL", "", "URL to which new defects are sent. Defects cannot be created when empty")
+	Command.Flags().StringVar(&createBody, "createBody", "", "Go template of the body used to create a defect, ie. {\"summary\": {{json .Title}}}. The defect is sent as JSON when empty")
+	Command.Flags().StringVar(&createdLinkField, "createdLinkField", "link", "field of the response to the creation of a defect that contains the issue link or ID")
+	Command.Flags().StringVar(&createdLinkTemplate, "createdLinkTemplate", "", "template used to build the issue link of a created defect, ie. https://tracker/browse/{id}")
+	Command.Flags().StringVar(&file, "file", "issuetracker.json", "file which will contain the configuration")
+}
+
+var Command = &cobra.Command{
+	Use:   "issue-tracker-config",
+	Short: "issue-tracker-config generates JSON file for configuring the connection to an issue tracker",
+	Long: `issue-tracker-config generates JSON file for configuring the connection to an issue tracker.
+	The issue tracker is used to keep the state of linked issues up to date.
+	Information provided by this config file is:
+	- type:         the connector used to talk to the issue tracker
+	- closedStates: the states of an issue which are considered closed
+	- rest:         how issues and webhook payloads are read and defects are created by the generic REST connector`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trackerConfig := issuetracker.Config{
+			Type:            trackerType,
+			RefreshInterval: refreshInterval,
+			ClosedStates:    closedStates,
+			WebhookSecret:   webhookSecret,
+			REST: issuetracker.RESTConfig{
+				IssueURL:            issueURL,
+				TrackerURL:          trackerURL,
+				StateField:          stateField,
+				Headers:             headers,
+				WebhookLinkField:    webhookLinkField,
+				WebhookLinkTemplate: webhookLinkTemplate,
+				WebhookStateField:   webhookStateField,
+				CreateURL:           createURL,
+				CreateBody:          createBody,
+				CreatedLinkField:    createdLinkField,
+				CreatedLinkTemplate: createdLinkTemplate,
+			},
+		}
+		if err := trackerConfig.Validate(); err != nil {
+			return err
+		}
+		cfg, err := json.MarshalIndent(trackerConfig, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(file, cfg, 0600); err != nil {
+			return err
+		}
+		return nil
+	},
+}
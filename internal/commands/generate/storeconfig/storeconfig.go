@@ -15,6 +15,10 @@ var projects string
 var scenarios string
 var testplans string
 var executions string
+var stepblocks string
+var folders string
+var requirements string
+var releases string
 var file string
 
 func init() {
@@ -24,6 +28,10 @@ func init() {
 	Command.Flags().StringVar(&scenarios, "scenarios", "scenarios", "collection name to be used for scenarios")
 	Command.Flags().StringVar(&testplans, "testplans", "testplans", "collection name to be used for testplans")
 	Command.Flags().StringVar(&executions, "executions", "executions", "collection name to be used for executions")
+	Command.Flags().StringVar(&stepblocks, "stepblocks", "stepblocks", "collection name to be used for step blocks")
+	Command.Flags().StringVar(&folders, "folders", "folders", "collection name to be used for folders")
+	Command.Flags().StringVar(&requirements, "requirements", "requirements", "collection name to be used for requirements")
+	Command.Flags().StringVar(&releases, "releases", "releases", "collection name to be used for releases")
 	Command.Flags().StringVar(&file, "file", "testdb.json", "file which will contain the configuration")
 	// address and databse are mandatory fields
 	_ = cobra.MarkFlagRequired(Command.Flags(), "address")
@@ -44,10 +52,14 @@ var Command = &cobra.Command{
 			Address:  address,
 			DataBase: database,
 			Collections: store.Collections{
-				Projects:   projects,
-				Scenarios:  scenarios,
-				TestPlans:  testplans,
-				Executions: executions,
+				Projects:     projects,
+				Scenarios:    scenarios,
+				TestPlans:    testplans,
+				Executions:   executions,
+				StepBlocks:   stepblocks,
+				Folders:      folders,
+				Requirements: requirements,
+				Releases:     releases,
 			},
 		}
 		cfg, err := json.MarshalIndent(storeConfig, "", "  ")
@@ -0,0 +1,97 @@
+package gherkinimport
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+var server remote.Flags
+var projectID string
+var folderID string
+var update bool
+
+func init() {
+	server.Register(Command)
+	Command.Flags().StringVar(&projectID, "project", "", "ID of the project in which the scenarios are imported")
+	Command.Flags().StringVar(&folderID, "folder", "", "ID of the folder in which new scenarios are placed")
+	Command.Flags().BoolVar(&update, "update", false, "update the scenarios previously imported from the same feature file")
+}
+
+var Command = &cobra.Command{
+	Use:   "gherkin [feature files or directories]",
+	Short: "gherkin imports Gherkin feature files as scenarios",
+	Long: `gherkin imports Gherkin feature files as scenarios.
+	Directories are searched for files with the .feature extension.
+	Each scenario is identified by the path of its feature file, as given on the command line, and its name,
+	so running the import again with --update keeps the scenarios in sync with the feature files.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if projectID == "" {
+			return fmt.Errorf("project is mandatory")
+		}
+		files, err := featureFiles(args)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		client, err := server.Login(ctx)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			request := &scenariov1.GherkinImportRequest{
+				ProjectId:   projectID,
+				FolderId:    folderID,
+				FeaturePath: filepath.ToSlash(file),
+				Content:     string(content),
+				Update:      update,
+			}
+			result := &scenariov1.ImportResult{}
+			if err := client.Post(ctx, "/scenarios/import/gherkin", request, result); err != nil {
+				return fmt.Errorf("could not import '%s': %w", file, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %d created, %d updated, %d skipped\n", file, len(result.Created), len(result.Updated), len(result.Skipped))
+		}
+		return nil
+	},
+}
+
+func featureFiles(paths []string) ([]string, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, filepath.Clean(path))
+			continue
+		}
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.HasSuffix(file, ".feature") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
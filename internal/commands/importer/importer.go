@@ -0,0 +1,23 @@
+package importer
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/importer/gherkinimport"
+	"github.com/curious-kitten/scratch-post/internal/commands/importer/testlinkimport"
+	"github.com/curious-kitten/scratch-post/internal/commands/importer/testrailimport"
+)
+
+func init() {
+	Command.AddCommand(
+		gherkinimport.Command,
+		testlinkimport.Command,
+		testrailimport.Command,
+	)
+}
+
+// Command is used to colocate all the import commands
+var Command = &cobra.Command{
+	Use:   "import",
+	Short: "import is used to load test cases from other formats and tools into scratch-post",
+}
@@ -0,0 +1,174 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	"github.com/curious-kitten/scratch-post/internal/store"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	"github.com/curious-kitten/scratch-post/pkg/client"
+	"github.com/curious-kitten/scratch-post/pkg/folders"
+	"github.com/curious-kitten/scratch-post/pkg/metadata"
+	"github.com/curious-kitten/scratch-post/pkg/migration"
+	"github.com/curious-kitten/scratch-post/pkg/projects"
+	"github.com/curious-kitten/scratch-post/pkg/scenarios"
+	"github.com/curious-kitten/scratch-post/pkg/stepblocks"
+)
+
+type parser func(content io.Reader) (*migration.Folder, error)
+type create func(ctx context.Context, author string, data io.Reader) (interface{}, error)
+
+type options struct {
+	server      remote.Flags
+	projectID   string
+	projectName string
+	storeCfg    string
+}
+
+// NewCommand returns a command that imports the test cases exported by another test management tool.
+// The export is read by parse and the ID of each test case is kept in a `<source>:<ID>` label
+func NewCommand(use string, short string, long string, source string, parse parser) *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   use + " [export file]",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			root, err := parse(file)
+			if err != nil {
+				return fmt.Errorf("could not read '%s': %w", args[0], err)
+			}
+			switch {
+			case o.projectName != "":
+				root.Name = o.projectName
+			case root.Name == "":
+				root.Name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+			}
+
+			ctx := context.Background()
+			createProject, createFolder, createScenario, err := o.creators(ctx)
+			if err != nil {
+				return err
+			}
+			result, err := migration.Import(ctx, source, root, o.projectID, createProject, createFolder, createScenario)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "project %s: %d folders, %d scenarios created\n", result.ProjectID, result.Folders, result.Scenarios)
+			for _, failure := range result.Failed {
+				fmt.Fprintln(out, failure)
+			}
+			if len(result.Failed) != 0 {
+				return fmt.Errorf("%d test cases could not be imported", len(result.Failed))
+			}
+			return nil
+		},
+	}
+	o.server.Register(cmd)
+	cmd.Flags().StringVar(&o.projectID, "project", "", "ID of an existing project in which the test cases are imported. A new project is created when empty")
+	cmd.Flags().StringVar(&o.projectName, "name", "", "name of the created project. Defaults to the name of the exported suite or of the file")
+	cmd.Flags().StringVar(&o.storeCfg, "testdb", "", "path to DB config settings. When set, the test cases are written directly to the store, as the user of --username, instead of being sent to the server")
+	return cmd
+}
+
+// creators returns the functions creating the projects, folders and scenarios through the server or in the store
+func (o *options) creators(ctx context.Context) (migration.Creator, migration.Creator, migration.Creator, error) {
+	if o.storeCfg == "" {
+		client, err := o.server.Login(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return remoteCreator(client, "/projects"), remoteCreator(client, "/folders"), remoteCreator(client, "/scenarios"), nil
+	}
+
+	if o.server.Username == "" {
+		return nil, nil, nil, fmt.Errorf("username is mandatory")
+	}
+	contents, err := os.Open(o.storeCfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not read test DB config", err)
+	}
+	defer contents.Close()
+	cfg := &store.Config{}
+	if err := decoder.Decode(cfg, contents); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not decode test DB config", err)
+	}
+	client, err := store.Client(ctx, cfg.Address)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "DB connection error", err)
+	}
+	projectsCollection, err := store.Collection(cfg.DataBase, cfg.Collections.Projects, client, []string{"name"})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not start collection", err)
+	}
+	scenarioCollection, err := store.Collection(cfg.DataBase, cfg.Collections.Scenarios, client, []string{scenarios.ProjectFilterKey, scenarios.NameFilterKey})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not start collection", err)
+	}
+	stepBlockCollection, err := store.Collection(cfg.DataBase, cfg.Collections.StepBlocks, client, []string{stepblocks.ProjectFilterKey, stepblocks.NameFilterKey})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not start collection", err)
+	}
+	folderCollection, err := store.Collection(cfg.DataBase, cfg.Collections.Folders, client, []string{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s : %w", "could not start collection", err)
+	}
+	meta := metadata.NewMetaManager()
+	author := o.server.Username
+	return storeCreator(projects.New(meta, projectsCollection), author),
+		storeCreator(folders.New(meta, folderCollection, projects.Get(projectsCollection)), author),
+		storeCreator(
+			scenarios.New(meta, scenarioCollection, projects.Get(projectsCollection), stepblocks.Steps(stepblocks.Get(stepBlockCollection)), folders.InProject(folderCollection)),
+			author,
+		),
+		nil
+}
+
+func remoteCreator(c *client.Client, path string) migration.Creator {
+	return func(ctx context.Context, item interface{}) (string, error) {
+		created := struct {
+			Identity struct {
+				ID string `json:"id"`
+			} `json:"identity"`
+		}{}
+		if err := c.Post(ctx, path, item, &created); err != nil {
+			return "", err
+		}
+		return created.Identity.ID, nil
+	}
+}
+
+func storeCreator(createItem create, author string) migration.Creator {
+	return func(ctx context.Context, item interface{}) (string, error) {
+		payload, err := mediatype.MarshalJSON(item)
+		if err != nil {
+			return "", err
+		}
+		created, err := createItem(ctx, author, bytes.NewReader(payload))
+		if err != nil {
+			return "", err
+		}
+		identified, ok := created.(interface{ GetIdentity() *metadatav1.Identity })
+		if !ok {
+			return "", fmt.Errorf("created item has no identity")
+		}
+		return identified.GetIdentity().GetId(), nil
+	}
+}
@@ -0,0 +1,15 @@
+package testlinkimport
+
+import (
+	"github.com/curious-kitten/scratch-post/internal/commands/importer/migrate"
+	"github.com/curious-kitten/scratch-post/pkg/testlink"
+)
+
+var Command = migrate.NewCommand(
+	"testlink",
+	"testlink imports a TestLink XML export of test suites or test cases",
+	`testlink imports a TestLink XML export of test suites or test cases.
+	The test suites become folders and the test cases scenarios, labeled with testlink:<external ID>.`,
+	"testlink",
+	testlink.Parse,
+)
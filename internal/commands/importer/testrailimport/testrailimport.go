@@ -0,0 +1,16 @@
+package testrailimport
+
+import (
+	"github.com/curious-kitten/scratch-post/internal/commands/importer/migrate"
+	"github.com/curious-kitten/scratch-post/pkg/testrail"
+)
+
+var Command = migrate.NewCommand(
+	"testrail",
+	"testrail imports a TestRail CSV export of test cases",
+	`testrail imports a TestRail CSV export of test cases.
+	The sections become folders and the test cases scenarios, labeled with testrail:<ID>.
+	Steps are read from the separated steps columns, one step per row, or from the Steps and Expected Result columns.`,
+	"testrail",
+	testrail.Parse,
+)
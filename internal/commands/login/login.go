@@ -0,0 +1,40 @@
+package login
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+)
+
+var server remote.Flags
+var config remote.Config
+
+func init() {
+	server.Register(Command)
+	config.Register(Command)
+}
+
+var Command = &cobra.Command{
+	Use:   "login",
+	Short: "login authenticates the user on a scratch-post server and keeps the session for the other commands",
+	Long: `login authenticates the user on a scratch-post server and keeps the session for the other commands.
+	The server and the received token are written to the config file, which is only readable by the user. The password is read from stdin with --password-stdin or from the ` + remote.PasswordEnv + ` environment variable and is not kept.`,
+	Example: "  scratch-post login --server http://localhost:9090/api/v1 --username tester --password-stdin < password.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if server.Username == "" {
+			return fmt.Errorf("username is mandatory")
+		}
+		c, err := server.Login(context.Background())
+		if err != nil {
+			return err
+		}
+		if err := config.Save(&remote.Session{Server: server.Server, Username: server.Username, Token: c.Token()}); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "logged in as '%s' on %s\n", server.Username, server.Server)
+		return nil
+	},
+}
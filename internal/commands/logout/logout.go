@@ -0,0 +1,38 @@
+package logout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	"github.com/curious-kitten/scratch-post/pkg/client"
+)
+
+var config remote.Config
+
+func init() {
+	config.Register(Command)
+}
+
+var Command = &cobra.Command{
+	Use:   "logout",
+	Short: "logout invalidates the session kept by login and removes it from the config file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		session, err := config.Load()
+		if err != nil {
+			return err
+		}
+		c := client.New(session.Server, client.WithToken(session.Token))
+		// an expired session only has to be removed
+		if err := c.Logout(context.Background()); err != nil && !client.IsUnauthorized(err) {
+			return err
+		}
+		if err := config.Remove(); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "logged out of %s\n", session.Server)
+		return nil
+	},
+}
@@ -0,0 +1,69 @@
+package planrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/executions/executionupdate"
+	"github.com/curious-kitten/scratch-post/internal/output"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/client"
+)
+
+var config remote.Config
+var format output.Flags
+var scenarioIDs []string
+var all bool
+var releaseID string
+
+func init() {
+	config.Register(Command)
+	format.Register(Command)
+	Command.Flags().StringSliceVar(&scenarioIDs, "scenario", []string{}, "ID of a scenario to execute")
+	Command.Flags().BoolVar(&all, "all", false, "execute all the scenarios of the project of the test plan")
+	Command.Flags().StringVar(&releaseID, "release", "", "ID of the release of the executions. The release of the test plan is used when empty")
+}
+
+var Command = &cobra.Command{
+	Use:   "run ID",
+	Short: "run starts a run of a test plan and prints the created executions",
+	Long: `run starts a run of a test plan and prints the created executions.
+	An execution is created for each scenario on each configuration of the matrix of the test plan.`,
+	Example: "  scratch-post plans run 4c658d70800b9c5 --scenario 4c658344000b9c5,4c658344000b9c6",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(scenarioIDs) == 0 && !all {
+			return fmt.Errorf("scenario or all is mandatory")
+		}
+		if err := format.Validate(); err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if all {
+			testPlan, err := c.TestPlans.Get(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			scenarios, err := c.Scenarios.List(ctx, &client.ListOptions{Filter: map[string][]string{"projectid": {testPlan.ProjectId}}}).All()
+			if err != nil {
+				return err
+			}
+			scenarioIDs = make([]string, 0, len(scenarios))
+			for _, scenario := range scenarios {
+				scenarioIDs = append(scenarioIDs, scenario.Identity.GetId())
+			}
+		}
+		run, err := c.TestPlans.Run(ctx, args[0], &executionv1.RunRequest{ScenarioIds: scenarioIDs, ReleaseId: releaseID})
+		if err != nil {
+			return err
+		}
+		return format.Print(cmd.OutOrStdout(), run.Executions, executionupdate.Columns(run.Executions...))
+	},
+}
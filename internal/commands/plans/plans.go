@@ -0,0 +1,19 @@
+package plans
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/plans/planrun"
+)
+
+func init() {
+	Command.AddCommand(
+		planrun.Command,
+	)
+}
+
+// Command is used to colocate all the test plan commands
+var Command = &cobra.Command{
+	Use:   "plans",
+	Short: "plans is used to manage the test plans of the server the user is logged in to",
+}
@@ -0,0 +1,48 @@
+package projectcreate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/projects/projectlist"
+	"github.com/curious-kitten/scratch-post/internal/output"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+)
+
+var config remote.Config
+var format output.Flags
+var name string
+var description string
+
+func init() {
+	config.Register(Command)
+	format.Register(Command)
+	Command.Flags().StringVar(&name, "name", "", "name of the project")
+	Command.Flags().StringVar(&description, "description", "", "description of the project")
+}
+
+var Command = &cobra.Command{
+	Use:     "create",
+	Short:   "create creates a project and prints it",
+	Example: `  scratch-post projects create --name shop --description "Online shop"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if name == "" {
+			return fmt.Errorf("name is mandatory")
+		}
+		if err := format.Validate(); err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		project, err := c.Projects.Create(context.Background(), &projectv1.Project{Name: name, Description: description})
+		if err != nil {
+			return err
+		}
+		return format.Print(cmd.OutOrStdout(), project, projectlist.Columns(project))
+	},
+}
@@ -0,0 +1,50 @@
+package projectlist
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/output"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+)
+
+var config remote.Config
+var format output.Flags
+
+func init() {
+	config.Register(Command)
+	format.Register(Command)
+}
+
+var Command = &cobra.Command{
+	Use:     "list",
+	Short:   "list prints all the projects",
+	Example: "  scratch-post projects list --format yaml",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := format.Validate(); err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		projects, err := c.Projects.List(context.Background(), nil).All()
+		if err != nil {
+			return err
+		}
+		return format.Print(cmd.OutOrStdout(), projects, Columns(projects...))
+	},
+}
+
+// Columns describes the table of projects
+func Columns(projects ...*projectv1.Project) output.Columns {
+	return output.Columns{
+		Headers: []string{"ID", "NAME", "DESCRIPTION"},
+		Rows:    len(projects),
+		Row: func(i int) []string {
+			return []string{projects[i].Identity.GetId(), projects[i].Name, projects[i].Description}
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package projects
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/projects/projectcreate"
+	"github.com/curious-kitten/scratch-post/internal/commands/projects/projectlist"
+)
+
+func init() {
+	Command.AddCommand(
+		projectlist.Command,
+		projectcreate.Command,
+	)
+}
+
+// Command is used to colocate all the project commands
+var Command = &cobra.Command{
+	Use:   "projects",
+	Short: "projects is used to manage the projects of the server the user is logged in to",
+}
@@ -0,0 +1,73 @@
+package gotestreport
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+)
+
+var server remote.Flags
+var testPlanID string
+var releaseID string
+var configuration map[string]string
+var createScenarios bool
+
+func init() {
+	server.Register(Command)
+	// stdin holds the output of the tests
+	_ = Command.Flags().MarkHidden("password-stdin")
+	Command.Flags().StringVar(&testPlanID, "testPlan", "", "ID of the test plan in which the executions are created")
+	Command.Flags().StringVar(&releaseID, "release", "", "ID of the release of the executions. The release of the test plan is used when empty")
+	Command.Flags().StringToStringVar(&configuration, "configuration", map[string]string{}, "configuration the tests were run on, ie. browser=chrome,os=linux")
+	Command.Flags().BoolVar(&createScenarios, "createScenarios", false, "create a scenario for the tests that do not match a scenario")
+}
+
+var Command = &cobra.Command{
+	Use:   "go-test",
+	Short: "go-test reads the output of `go test -json` from stdin and creates an execution for every test",
+	Long: `go-test reads the output of ` + "`go test -json`" + ` from stdin and creates an execution for every test.
+	Tests, including subtests, are matched with the scenarios through the automation key package.Test, ie. github.com/org/shop/cart.TestAdd.
+	Since stdin holds the output of the tests, the password is read from the ` + remote.PasswordEnv + ` environment variable.`,
+	Example: "  go test -json ./... | scratch-post report go-test --testPlan 4c658d70800b9c5 --username tester",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if testPlanID == "" {
+			return fmt.Errorf("testPlan is mandatory")
+		}
+		if server.PasswordStdin {
+			return fmt.Errorf("stdin holds the output of the tests, set the password in %s instead of using --password-stdin", remote.PasswordEnv)
+		}
+		content, err := ioutil.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		client, err := server.Login(ctx)
+		if err != nil {
+			return err
+		}
+		request := &executionv1.ReportRequest{
+			Content:         string(content),
+			CreateScenarios: createScenarios,
+			ReleaseId:       releaseID,
+			Configuration:   configuration,
+		}
+		report, err := client.TestPlans.ReportResults(ctx, testPlanID, "go-test", request)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "%d executions created, %d scenarios created, %d tests unmatched, %d tests not approved\n", len(report.Executions), len(report.CreatedScenarios), len(report.Unmatched), len(report.NotApproved))
+		for _, key := range report.Unmatched {
+			fmt.Fprintf(out, "unmatched: %s\n", key)
+		}
+		for _, key := range report.NotApproved {
+			fmt.Fprintf(out, "not approved: %s\n", key)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/report/gotestreport"
+	"github.com/curious-kitten/scratch-post/internal/commands/report/testplanreport"
+)
+
+func init() {
+	Command.AddCommand(
+		gotestreport.Command,
+		testplanreport.Command,
+	)
+}
+
+// Command is used to colocate all the report commands
+var Command = &cobra.Command{
+	Use:   "report",
+	Short: "report is used to send test results to a running scratch-post server and to download test plan reports",
+}
@@ -0,0 +1,72 @@
+package testplanreport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+)
+
+var server remote.Flags
+var testPlanID string
+var format string
+var output string
+var releaseID string
+var configuration map[string]string
+
+func init() {
+	server.Register(Command)
+	Command.Flags().StringVar(&testPlanID, "testPlan", "", "ID of the test plan to report on")
+	Command.Flags().StringVar(&format, "format", "html", "format of the report, html or markdown")
+	Command.Flags().StringVar(&output, "output", "", "file in which the report is written. The report is written to stdout when empty")
+	Command.Flags().StringVar(&releaseID, "release", "", "only report the executions of the release")
+	Command.Flags().StringToStringVar(&configuration, "configuration", map[string]string{}, "only report the executions run on the configuration, ie. browser=chrome,os=linux")
+}
+
+var Command = &cobra.Command{
+	Use:   "testplan",
+	Short: "testplan downloads the report of the executions of a test plan as HTML or Markdown",
+	Long: `testplan downloads the report of the executions of a test plan as a self-contained HTML page or as a Markdown document.
+	The report contains the number of executions with each status, then the result of every scenario with the result of its steps, its attachments and its issues.`,
+	Example: "  scratch-post report testplan --testPlan 4c658d70800b9c5 --format markdown --output report.md --username tester",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if testPlanID == "" {
+			return fmt.Errorf("testPlan is mandatory")
+		}
+		if format != "html" && format != "markdown" {
+			return fmt.Errorf("format has to be html or markdown")
+		}
+		query := url.Values{}
+		if releaseID != "" {
+			query.Set("releaseId", releaseID)
+		}
+		for dimension, value := range configuration {
+			query.Add("configuration", dimension+":"+value)
+		}
+		path := "/testplans/" + url.PathEscape(testPlanID) + "/report/" + format
+		if len(query) != 0 {
+			path += "?" + query.Encode()
+		}
+
+		ctx := context.Background()
+		client, err := server.Login(ctx)
+		if err != nil {
+			return err
+		}
+		var out io.Writer = cmd.OutOrStdout()
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		return client.Download(ctx, path, out)
+	},
+}
@@ -0,0 +1,128 @@
+package scenarioapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+var config remote.Config
+var files []string
+var projectID string
+
+func init() {
+	config.Register(Command)
+	Command.Flags().StringSliceVarP(&files, "file", "f", []string{}, "JSON or YAML file holding a scenario or a list of scenarios. Directories are read recursively")
+	Command.Flags().StringVar(&projectID, "project", "", "ID of the project of the scenarios that do not set a projectId")
+}
+
+var Command = &cobra.Command{
+	Use:   "apply",
+	Short: "apply creates the scenarios of the files or updates the existing ones",
+	Long: `apply creates the scenarios of the files or updates the existing ones, so that the scenarios can be kept in version control.
+	A scenario with an ID updates the scenario with the ID. A scenario without an ID updates the scenario of the project with the same name, or creates a new one.
+	Scenarios that already match their file are not updated, so that approved scenarios are not sent back to review.
+	The files are JSON or YAML, with the fields of the scenarios of the REST API, and hold a scenario or a list of scenarios.`,
+	Example: "  scratch-post scenarios apply -f scenarios/ --project 4c2f2b65400a665",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(files) == 0 {
+			return fmt.Errorf("file is mandatory")
+		}
+		paths, err := scenarioFiles(files)
+		if err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		for _, path := range paths {
+			scenarios, err := read(path)
+			if err != nil {
+				return fmt.Errorf("could not read '%s': %w", path, err)
+			}
+			for _, scenario := range scenarios {
+				if scenario.ProjectId == "" {
+					scenario.ProjectId = projectID
+				}
+				applied, result, err := c.Scenarios.Apply(ctx, scenario)
+				if err != nil {
+					return fmt.Errorf("could not apply scenario '%s' of '%s': %w", scenario.Name, path, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "scenario '%s' %s (%s)\n", applied.Name, result, applied.Identity.GetId())
+			}
+		}
+		return nil
+	},
+}
+
+// scenarioFiles returns the files and the JSON and YAML files of the directories
+func scenarioFiles(names []string) ([]string, error) {
+	paths := []string{}
+	for _, name := range names {
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, name)
+			continue
+		}
+		err = filepath.WalkDir(name, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".json", ".yaml", ".yml":
+				if !entry.IsDir() {
+					paths = append(paths, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// read decodes the scenario or the list of scenarios of a file
+func read(path string) ([]*scenariov1.Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mediaType := mediatype.JSON
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		mediaType = mediatype.YAML
+	}
+	items := []json.RawMessage{}
+	if err := mediatype.Unmarshal(data, &items, mediaType); err != nil {
+		// the file holds a single scenario
+		scenario := &scenariov1.Scenario{}
+		if err := mediatype.Unmarshal(data, scenario, mediaType); err != nil {
+			return nil, err
+		}
+		return []*scenariov1.Scenario{scenario}, nil
+	}
+	scenarios := make([]*scenariov1.Scenario, len(items))
+	for i, item := range items {
+		scenarios[i] = &scenariov1.Scenario{}
+		if err := mediatype.Unmarshal(item, scenarios[i], mediatype.JSON); err != nil {
+			return nil, err
+		}
+	}
+	return scenarios, nil
+}
@@ -0,0 +1,62 @@
+package scenarioget
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/output"
+	"github.com/curious-kitten/scratch-post/internal/remote"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+var config remote.Config
+var format output.Flags
+
+func init() {
+	config.Register(Command)
+	format.Register(Command)
+}
+
+var Command = &cobra.Command{
+	Use:   "get ID...",
+	Short: "get prints the scenarios with the IDs",
+	Long: `get prints the scenarios with the IDs.
+	The scenarios printed as JSON or YAML can be edited and sent back with apply.`,
+	Example: "  scratch-post scenarios get 4c658344000b9c5 --format yaml > login.yaml",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := format.Validate(); err != nil {
+			return err
+		}
+		c, err := config.Client()
+		if err != nil {
+			return err
+		}
+		scenarios := make([]*scenariov1.Scenario, 0, len(args))
+		for _, id := range args {
+			scenario, err := c.Scenarios.Get(context.Background(), id)
+			if err != nil {
+				return err
+			}
+			scenarios = append(scenarios, scenario)
+		}
+		if len(scenarios) == 1 {
+			return format.Print(cmd.OutOrStdout(), scenarios[0], Columns(scenarios...))
+		}
+		return format.Print(cmd.OutOrStdout(), scenarios, Columns(scenarios...))
+	},
+}
+
+// Columns describes the table of scenarios
+func Columns(scenarios ...*scenariov1.Scenario) output.Columns {
+	return output.Columns{
+		Headers: []string{"ID", "PROJECT", "NAME", "STATE", "AUTOMATED"},
+		Rows:    len(scenarios),
+		Row: func(i int) []string {
+			s := scenarios[i]
+			return []string{s.Identity.GetId(), s.ProjectId, s.Name, s.State.String(), strconv.FormatBool(s.Automated || len(s.AutomationKeys) != 0)}
+		},
+	}
+}
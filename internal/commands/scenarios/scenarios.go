@@ -0,0 +1,21 @@
+package scenarios
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/commands/scenarios/scenarioapply"
+	"github.com/curious-kitten/scratch-post/internal/commands/scenarios/scenarioget"
+)
+
+func init() {
+	Command.AddCommand(
+		scenarioget.Command,
+		scenarioapply.Command,
+	)
+}
+
+// Command is used to colocate all the scenario commands
+var Command = &cobra.Command{
+	Use:   "scenarios",
+	Short: "scenarios is used to manage the scenarios of the server the user is logged in to",
+}
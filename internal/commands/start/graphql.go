@@ -0,0 +1,153 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	folderv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	releasev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/release"
+	requirementv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	stepblockv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/stepblock"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/executions"
+	"github.com/curious-kitten/scratch-post/pkg/graphql"
+)
+
+// apiSchema describes the entities served by the GraphQL endpoint and their relationships
+func apiSchema(listProjects, listScenarios, listTestPlans, listExecutions, listStepBlocks, listFolders, listRequirements, listReleases graphql.ListFunc) *graphql.Schema {
+	s := graphql.NewSchema()
+	project := s.Entity(&projectv1.Project{}, "project", "projects", listProjects)
+	scenario := s.Entity(&scenariov1.Scenario{}, "scenario", "scenarios", listScenarios)
+	testPlan := s.Entity(&testplanv1.TestPlan{}, "testPlan", "testPlans", listTestPlans)
+	execution := s.Entity(&executionv1.Execution{}, "execution", "executions", listExecutions)
+	stepBlock := s.Entity(&stepblockv1.StepBlock{}, "stepBlock", "stepBlocks", listStepBlocks)
+	folder := s.Entity(&folderv1.Folder{}, "folder", "folders", listFolders)
+	requirement := s.Entity(&requirementv1.Requirement{}, "requirement", "requirements", listRequirements)
+	release := s.Entity(&releasev1.Release{}, "release", "releases", listReleases)
+
+	s.Collection(project, "scenarios", scenario, "projectId")
+	s.Collection(project, "testPlans", testPlan, "projectId")
+	s.Collection(project, "executions", execution, "projectId")
+	s.Collection(project, "stepBlocks", stepBlock, "projectId")
+	s.Collection(project, "folders", folder, "projectId")
+	s.Collection(project, "requirements", requirement, "projectId")
+	s.Collection(project, "releases", release, "projectId")
+
+	s.Reference(scenario, "project", "projectId", project)
+	s.Reference(scenario, "folder", "folderId", folder)
+	s.Reference(scenario, "requirements", "requirementIds", requirement)
+	s.Collection(scenario, "executions", execution, "scenarioId")
+
+	s.Reference(testPlan, "project", "projectId", project)
+	s.Reference(testPlan, "release", "releaseId", release)
+	s.Collection(testPlan, "executions", execution, "testPlanId")
+
+	s.Reference(execution, "project", "projectId", project)
+	s.Reference(execution, "scenario", "scenarioId", scenario)
+	s.Reference(execution, "testPlan", "testPlanId", testPlan)
+	s.Reference(execution, "release", "releaseId", release)
+
+	s.Reference(stepBlock, "project", "projectId", project)
+	s.Collection(stepBlock, "scenarios", scenario, "steps.stepBlockId")
+
+	s.Reference(folder, "project", "projectId", project)
+	s.Reference(folder, "parent", "parentId", folder)
+	s.Collection(folder, "children", folder, "parentId")
+	s.Collection(folder, "scenarios", scenario, "folderId")
+
+	s.Reference(requirement, "project", "projectId", project)
+	s.Reference(requirement, "parent", "parentId", requirement)
+	s.Collection(requirement, "children", requirement, "parentId")
+	s.Collection(requirement, "scenarios", scenario, "requirementIds")
+
+	s.Reference(release, "project", "projectId", project)
+	s.Collection(release, "testPlans", testPlan, "releaseId")
+	s.Collection(release, "executions", execution, "releaseId")
+
+	s.Object("Run", "Executions of a test plan on a configuration of its matrix")
+	s.Field("Run", "configuration", graphql.Field{
+		Type: graphql.Map + "!",
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			values := make([]interface{}, len(parents))
+			for i, parent := range parents {
+				values[i] = parent.(*run).configuration
+			}
+			return values, nil
+		},
+	})
+	s.Field("Run", "executions", graphql.Field{
+		Type: "[" + execution + "!]!",
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			values := make([]interface{}, len(parents))
+			for i, parent := range parents {
+				values[i] = parent.(*run).executions
+			}
+			return values, nil
+		},
+	})
+	s.Field(testPlan, "runs", graphql.Field{
+		Description: "The executions of the test plan grouped by configuration",
+		Type:        "[Run!]",
+		Resolve:     testPlanRuns(listExecutions),
+	})
+	return s
+}
+
+// run groups the executions of a test plan which have the same configuration
+type run struct {
+	configuration map[string]string
+	executions    []interface{}
+}
+
+// testPlanRuns returns the resolver of the runs of the test plans. The executions of all the test plans are read at once
+func testPlanRuns(listExecutions graphql.ListFunc) graphql.Resolver {
+	return func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		ids := make([]string, len(parents))
+		for i, parent := range parents {
+			ids[i] = parent.(*testplanv1.TestPlan).GetIdentity().GetId()
+		}
+		items, err := listExecutions(ctx, map[string][]string{executions.TestPlanFilterKey: ids}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		runs := map[string][]*run{}
+		byConfiguration := map[string]*run{}
+		for _, item := range items {
+			e := item.(*executionv1.Execution)
+			key := e.TestPlanId + "/" + configurationKey(e.Configuration)
+			r, ok := byConfiguration[key]
+			if !ok {
+				r = &run{configuration: map[string]string{}, executions: []interface{}{}}
+				for name, value := range e.Configuration {
+					r.configuration[name] = value
+				}
+				byConfiguration[key] = r
+				runs[e.TestPlanId] = append(runs[e.TestPlanId], r)
+			}
+			r.executions = append(r.executions, e)
+		}
+		values := make([]interface{}, len(parents))
+		for i, id := range ids {
+			planRuns := make([]interface{}, len(runs[id]))
+			for j, r := range runs[id] {
+				planRuns[j] = r
+			}
+			values[i] = planRuns
+		}
+		return values, nil
+	}
+}
+
+func configurationKey(configuration map[string]string) string {
+	pairs := make([]string, 0, len(configuration))
+	for name, value := range configuration {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
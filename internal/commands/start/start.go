@@ -3,30 +3,60 @@ package start
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
 	"github.com/curious-kitten/scratch-post/internal/db"
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/health"
 	"github.com/curious-kitten/scratch-post/internal/http/endpoints"
 	"github.com/curious-kitten/scratch-post/internal/http/methods"
+	"github.com/curious-kitten/scratch-post/internal/http/openapi"
 	"github.com/curious-kitten/scratch-post/internal/http/router"
 	"github.com/curious-kitten/scratch-post/internal/info"
 	"github.com/curious-kitten/scratch-post/internal/keys"
 	"github.com/curious-kitten/scratch-post/internal/logger"
+	"github.com/curious-kitten/scratch-post/internal/rpc"
 	"github.com/curious-kitten/scratch-post/internal/store"
 	"github.com/curious-kitten/scratch-post/pkg/administration/users"
 	"github.com/curious-kitten/scratch-post/pkg/administration/users/auth"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	folderv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	issuev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	releasev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/release"
+	requirementv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+	stepblockv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/stepblock"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/cucumber"
 	"github.com/curious-kitten/scratch-post/pkg/executions"
+	"github.com/curious-kitten/scratch-post/pkg/folders"
+	"github.com/curious-kitten/scratch-post/pkg/gotest"
+	"github.com/curious-kitten/scratch-post/pkg/graphql"
+	"github.com/curious-kitten/scratch-post/pkg/issues"
+	"github.com/curious-kitten/scratch-post/pkg/issuetracker"
+	"github.com/curious-kitten/scratch-post/pkg/junit"
 	"github.com/curious-kitten/scratch-post/pkg/metadata"
 	"github.com/curious-kitten/scratch-post/pkg/projects"
+	"github.com/curious-kitten/scratch-post/pkg/releases"
+	"github.com/curious-kitten/scratch-post/pkg/requirements"
 	"github.com/curious-kitten/scratch-post/pkg/scenarios"
+	"github.com/curious-kitten/scratch-post/pkg/stepblocks"
+	"github.com/curious-kitten/scratch-post/pkg/tap"
 	"github.com/curious-kitten/scratch-post/pkg/testplans"
+	"github.com/curious-kitten/scratch-post/pkg/testreport"
 )
 
 var storeCfgFile string
@@ -34,6 +64,7 @@ var adminDBCfgFile string
 var apiCfgFile string
 var securityFile string
 var isJWT bool
+var issueTrackerCfgFile string
 
 func init() {
 	Command.Flags().StringVar(&storeCfgFile, "testdb", "testdb.json", "Path to DB config settings")
@@ -42,6 +73,7 @@ func init() {
 	Command.Flags().StringVar(&securityFile, "scenarios", "scenarios", "collection name to be used for scenarios")
 	Command.Flags().BoolVar(&isJWT, "isJWT", false, "Sets the authentication type to JWT. Default is session ID")
 	Command.Flags().StringVar(&securityFile, "securityFile", "security.txt", "Path to file which contains the JWT security string")
+	Command.Flags().StringVar(&issueTrackerCfgFile, "issueTracker", "", "Path to issue tracker config settings. The issue tracker is not used when empty")
 }
 
 var Command = &cobra.Command{
@@ -111,13 +143,44 @@ var Command = &cobra.Command{
 			log.Errorw("fatal error during startup", "error", err)
 			return err
 		}
+		// Reading issue tracker config file
+		trackerCfg := &issuetracker.Config{}
+		var tracker issuetracker.Connector
+		if issueTrackerCfgFile != "" {
+			trackerCfgContents, err := os.Open(issueTrackerCfgFile)
+			if err != nil {
+				err = fmt.Errorf("%s : %w", "could not read issue tracker config", err)
+				log.Errorw("fatal error during startup", "error", err)
+				return err
+			}
+			err = decoder.Decode(trackerCfg, trackerCfgContents)
+			if err != nil {
+				err = fmt.Errorf("%s : %w", "could not decode issue tracker config", err)
+				log.Errorw("fatal error during startup", "error", err)
+				return err
+			}
+			tracker, err = issuetracker.New(*trackerCfg, &http.Client{Timeout: time.Second * 10})
+			if err != nil {
+				err = fmt.Errorf("%s : %w", "could not create issue tracker connector", err)
+				log.Errorw("fatal error during startup", "error", err)
+				return err
+			}
+		}
 
 		log.Info("Starting app...")
 		r := router.New(log)
 		versionedRouter := r.PathPrefix(apiCfg.RootPrefix).Subrouter()
 
+		// the routes are described while they are registered, for the OpenAPI document
+		spec := openapi.NewSpec()
+
 		conditions := health.NewConditions(app, instance)
-		health.RegisterHTTPProbes(versionedRouter.PathPrefix(apiCfg.Endpoints.Probes).Subrouter(), conditions)
+		alive, ready := health.RegisterHTTPProbes(
+			spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Probes).Subrouter(), openapi.Resource{Name: "probes", Public: true}),
+			conditions,
+		)
+		spec.Describe(alive, openapi.Operation{Summary: "Checks that the server is alive"})
+		spec.Describe(ready, openapi.Operation{Summary: "Checks that the server can handle requests"})
 
 		meta := metadata.NewMetaManager()
 
@@ -194,6 +257,11 @@ var Command = &cobra.Command{
 			authorizer = auth.NewSessionHandler(sql, log)
 		}
 		authorizer.Cleanup(24 * time.Hour)
+		// the requests which need a token are validated against the OpenAPI document once the token is checked
+		authorized := []mux.MiddlewareFunc{auth.Authorization(authorizer)}
+		if apiCfg.ValidateRequests {
+			authorized = append(authorized, spec.Validate)
+		}
 
 		userDB, err := users.NewUserDB(sql)
 		if err != nil {
@@ -204,14 +272,16 @@ var Command = &cobra.Command{
 
 		// Login endpoints
 		authEndpoints := auth.NewEndpoints(ctx, users.IsPasswordCorrect(userDB), authorizer)
-		authEndpoints.Register(versionedRouter)
+		login, logout := authEndpoints.Register(versionedRouter)
+		spec.Describe(login, openapi.Operation{Summary: "Logs the user in. The token is sent in the `auth-token` cookie", Request: &auth.LoginRequest{}, Public: true})
+		spec.Describe(logout, openapi.Operation{Summary: "Invalidates the token of the request", Public: true})
 
 		// Admin endpoints
 		administrationRouter := versionedRouter.PathPrefix(apiCfg.Endpoints.Admin.Prefix).Subrouter()
 
 		// User endpoints
-		usersRouter := administrationRouter.PathPrefix(apiCfg.Endpoints.Admin.Users).Subrouter()
-		usersRouter.Use(auth.Authorization(authorizer))
+		usersRouter := spec.Resource(administrationRouter.PathPrefix(apiCfg.Endpoints.Admin.Users).Subrouter(), openapi.Resource{Name: "users", Item: &users.User{}})
+		usersRouter.Use(authorized...)
 		methods.Post(ctx, users.Create(userDB), auth.GetUserIDFromRequest, usersRouter, log)
 		methods.Get(ctx, users.Get(userDB), usersRouter, log)
 
@@ -222,43 +292,203 @@ var Command = &cobra.Command{
 			log.Errorw("fatal error during startup", "error", err)
 			return err
 		}
-		projectRouter := versionedRouter.PathPrefix(apiCfg.Endpoints.Projects).Subrouter()
-		projectRouter.Use(auth.Authorization(authorizer))
-		methods.Post(ctx, projects.New(meta, projectsCollection), auth.GetUserIDFromRequest, projectRouter, log)
-		methods.List(ctx, projects.List(projectsCollection), projectRouter, log)
-		methods.Get(ctx, projects.Get(projectsCollection), projectRouter, log)
-		methods.Delete(ctx, projects.Delete(projectsCollection), projectRouter, log)
-		methods.Put(ctx, projects.Update(meta, projectsCollection), auth.GetUserIDFromRequest, projectRouter, log)
+		// the business functions of the items are built once and shared by the HTTP and gRPC servers
+		createProject := projects.New(meta, projectsCollection)
+		listProjects := projects.List(projectsCollection)
+		getProject := projects.Get(projectsCollection)
+		updateProject := projects.Update(meta, projectsCollection)
+		deleteProject := projects.Delete(projectsCollection)
+		projectRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Projects).Subrouter(), openapi.Resource{Name: "projects", Item: &projectv1.Project{}})
+		projectRouter.Use(authorized...)
+		methods.Post(ctx, createProject, auth.GetUserIDFromRequest, projectRouter, log)
+		methods.List(ctx, listProjects, projectRouter, log)
+		methods.Get(ctx, getProject, projectRouter, log)
+		methods.Delete(ctx, deleteProject, projectRouter, log)
+		methods.Put(ctx, updateProject, auth.GetUserIDFromRequest, projectRouter, log)
 
 		// Scenario endpoints
-		scenarioCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Scenarios, client, []string{"projectId", "name"})
+		scenarioCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Scenarios, client, []string{scenarios.ProjectFilterKey, scenarios.NameFilterKey})
 		if err != nil {
 			err = fmt.Errorf("%s : %w", "could not start collection", err)
 			log.Errorw("fatal error during startup", "error", err)
 			return err
 		}
-		scenarioRouter := versionedRouter.PathPrefix(apiCfg.Endpoints.Scenarios).Subrouter()
-		scenarioRouter.Use(auth.Authorization(authorizer))
-		methods.Post(ctx, scenarios.New(meta, scenarioCollection, projects.Get(projectsCollection)), auth.GetUserIDFromRequest, scenarioRouter, log)
-		methods.List(ctx, scenarios.List(scenarioCollection), scenarioRouter, log)
-		methods.Get(ctx, scenarios.Get(scenarioCollection), scenarioRouter, log)
-		methods.Delete(ctx, scenarios.Delete(scenarioCollection), scenarioRouter, log)
-		methods.Put(ctx, scenarios.Update(meta, scenarioCollection, projects.Get(projectsCollection)), auth.GetUserIDFromRequest, scenarioRouter, log)
+		if err := scenarioCollection.UniqueList(ctx, []string{scenarios.ProjectFilterKey, scenarios.AutomationKeyFilterKey}, "automation key is already used by another scenario of the project"); err != nil {
+			err = fmt.Errorf("%s : %w", "could not start collection", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		// scenarios stored before the review workflow have no state and could already be executed, so they stay executable as Approved
+		approved, err := scenarioCollection.SetMissing(ctx, scenarios.StateFilterKey, scenariov1.State_Approved)
+		if err != nil {
+			err = fmt.Errorf("%s : %w", "could not migrate the scenario states", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		if approved != 0 {
+			log.Infow("approved the scenarios stored without a state", "scenarios", approved)
+		}
+		stepBlockCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.StepBlocks, client, []string{stepblocks.ProjectFilterKey, stepblocks.NameFilterKey})
+		if err != nil {
+			err = fmt.Errorf("%s : %w", "could not start collection", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		folderCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Folders, client, []string{})
+		if err != nil {
+			err = fmt.Errorf("%s : %w", "could not start collection", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		stepBlockSteps := stepblocks.Steps(stepblocks.Get(stepBlockCollection))
+		inProjectFolder := folders.InProject(folderCollection)
+		createScenario := scenarios.New(meta, scenarioCollection, getProject, stepBlockSteps, inProjectFolder)
+		listScenarios := scenarios.List(scenarioCollection)
+		getScenario := scenarios.Get(scenarioCollection)
+		updateScenario := scenarios.Update(meta, scenarioCollection, getProject, stepBlockSteps, inProjectFolder)
+		deleteScenario := scenarios.Delete(scenarioCollection)
+		scenarioRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Scenarios).Subrouter(), openapi.Resource{Name: "scenarios", Item: &scenariov1.Scenario{}})
+		scenarioRouter.Use(authorized...)
+		methods.Post(ctx, createScenario, auth.GetUserIDFromRequest, scenarioRouter, log)
+		methods.List(ctx, listScenarios, scenarioRouter, log)
+		methods.Get(ctx, getScenario, scenarioRouter, log)
+		methods.Delete(ctx, deleteScenario, scenarioRouter, log)
+		methods.Put(ctx, updateScenario, auth.GetUserIDFromRequest, scenarioRouter, log)
+		spec.Describe(methods.Action(ctx, "/state", scenarios.Transition(meta, scenarioCollection, getProject), auth.GetUserIDFromRequest, scenarioRouter, log), openapi.Operation{Summary: "Moves the scenario to another state", Request: &scenariov1.TransitionRequest{}, Response: &scenariov1.Scenario{}})
+		spec.Describe(methods.Action(ctx, "/reviews", scenarios.Review(meta, scenarioCollection), auth.GetUserIDFromRequest, scenarioRouter, log), openapi.Operation{Summary: "Reviews the scenario", Request: &scenariov1.ReviewRequest{}, Response: &scenariov1.Scenario{}})
+		copyScenarios := scenarios.Copy(meta, scenarioCollection, getProject, stepBlockSteps)
+		spec.Describe(methods.CollectionAction(ctx, "/copy", scenarios.CopySelection(copyScenarios), auth.GetUserIDFromRequest, scenarioRouter, log), openapi.Operation{Summary: "Copies the selected scenarios", Request: &scenariov1.CopyRequest{}, Response: &scenariov1.CopyResult{}})
+		spec.Describe(methods.Action(ctx, "/copy", scenarios.CopyOne(copyScenarios), auth.GetUserIDFromRequest, scenarioRouter, log), openapi.Operation{Summary: "Copies the scenario", Request: &scenariov1.CopyRequest{}, Response: &scenariov1.CopyResult{}})
+		spec.Describe(methods.Find(ctx, "/automation/find", scenarios.ByAutomationKey(scenarioCollection), scenarioRouter, log), openapi.Operation{Summary: "Finds the scenario of an automation key", Response: &scenariov1.Scenario{}})
+		spec.Describe(
+			methods.CollectionAction(
+				ctx,
+				"/import/gherkin",
+				scenarios.ImportGherkin(meta, scenarioCollection, getProject, inProjectFolder),
+				auth.GetUserIDFromRequest,
+				scenarioRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Imports scenarios from Gherkin features", Request: &scenariov1.GherkinImportRequest{}, Response: &scenariov1.ImportResult{}},
+		)
+		spec.Describe(
+			methods.Download(
+				ctx,
+				"/export/gherkin",
+				scenarios.ExportGherkin(
+					scenarioCollection,
+					getProject,
+					stepBlockSteps,
+					folders.Descendants(folderCollection),
+					folders.Paths(folderCollection),
+				),
+				scenarioRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Exports the scenarios as Gherkin features", File: true},
+		)
+		spec.Describe(
+			methods.CollectionAction(
+				ctx,
+				"/import/csv",
+				scenarios.ImportCSV(meta, scenarioCollection, getProject, stepBlockSteps, inProjectFolder),
+				auth.GetUserIDFromRequest,
+				scenarioRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Imports scenarios from CSV", Request: &scenariov1.CSVImportRequest{}, Response: &scenariov1.ImportResult{}},
+		)
+		spec.Describe(
+			methods.Download(
+				ctx,
+				"/export/csv",
+				scenarios.ExportCSV(
+					scenarioCollection,
+					getProject,
+					stepBlockSteps,
+					folders.Descendants(folderCollection),
+					folders.Paths(folderCollection),
+				),
+				scenarioRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Exports the scenarios as CSV", File: true},
+		)
+
+		// Step block endpoints
+		stepBlockRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.StepBlocks).Subrouter(), openapi.Resource{Name: "stepblocks", Item: &stepblockv1.StepBlock{}})
+		stepBlockRouter.Use(authorized...)
+		methods.Post(ctx, stepblocks.New(meta, stepBlockCollection, getProject), auth.GetUserIDFromRequest, stepBlockRouter, log)
+		methods.List(ctx, stepblocks.List(stepBlockCollection), stepBlockRouter, log)
+		methods.Get(ctx, stepblocks.Get(stepBlockCollection), stepBlockRouter, log)
+		spec.Describe(methods.GetRelated(ctx, "/scenarios", stepblocks.Scenarios(stepBlockCollection, listScenarios), stepBlockRouter, log), openapi.Operation{Summary: "Lists the scenarios using the step block", Response: &scenariov1.Scenario{}, List: true})
+		methods.Delete(ctx, stepblocks.Delete(stepBlockCollection, listScenarios), stepBlockRouter, log)
+		methods.Put(ctx, stepblocks.Update(meta, stepBlockCollection, getProject), auth.GetUserIDFromRequest, stepBlockRouter, log)
+
+		// Folder endpoints
+		folderRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Folders).Subrouter(), openapi.Resource{Name: "folders", Item: &folderv1.Folder{}})
+		folderRouter.Use(authorized...)
+		methods.Post(ctx, folders.New(meta, folderCollection, getProject), auth.GetUserIDFromRequest, folderRouter, log)
+		methods.List(ctx, folders.List(folderCollection), folderRouter, log)
+		methods.Get(ctx, folders.Get(folderCollection), folderRouter, log)
+		spec.Describe(methods.GetRelated(ctx, "/scenarios", folders.Scenarios(folderCollection, listScenarios), folderRouter, log), openapi.Operation{Summary: "Lists the scenarios of the folder", Response: &scenariov1.Scenario{}, List: true})
+		spec.Describe(methods.GetSubresource(ctx, "/summary", folders.Summary(folderCollection, listScenarios), folderRouter, log), openapi.Operation{Summary: "Counts the scenarios of the folder", Response: &folderv1.FolderSummary{}})
+		spec.Describe(methods.Action(ctx, "/scenarios", folders.MoveScenarios(folderCollection, scenarios.MoveToFolder(meta, scenarioCollection)), auth.GetUserIDFromRequest, folderRouter, log), openapi.Operation{Summary: "Moves scenarios to the folder", Request: &folderv1.MoveScenariosRequest{}, Response: &scenariov1.Scenario{}, Array: true})
+		methods.Delete(ctx, folders.Delete(folderCollection, listScenarios), folderRouter, log)
+		methods.Put(ctx, folders.Update(meta, folderCollection, getProject), auth.GetUserIDFromRequest, folderRouter, log)
+
+		// Requirement endpoints
+		requirementCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Requirements, client, []string{requirements.ProjectFilterKey, requirements.KeyFilterKey})
+		if err != nil {
+			err = fmt.Errorf("%s : %w", "could not start collection", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		requirementRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Requirements).Subrouter(), openapi.Resource{Name: "requirements", Item: &requirementv1.Requirement{}})
+		requirementRouter.Use(authorized...)
+		methods.Post(ctx, requirements.New(meta, requirementCollection, getProject), auth.GetUserIDFromRequest, requirementRouter, log)
+		methods.List(ctx, requirements.List(requirementCollection), requirementRouter, log)
+		methods.Get(ctx, requirements.Get(requirementCollection), requirementRouter, log)
+		spec.Describe(methods.GetRelated(ctx, "/scenarios", requirements.Scenarios(listScenarios), requirementRouter, log), openapi.Operation{Summary: "Lists the scenarios linked to the requirement", Response: &scenariov1.Scenario{}, List: true})
+		spec.Describe(methods.Action(ctx, "/link", requirements.Link(requirementCollection, scenarios.LinkRequirement(meta, scenarioCollection)), auth.GetUserIDFromRequest, requirementRouter, log), openapi.Operation{Summary: "Links scenarios to the requirement", Request: &requirementv1.LinkScenariosRequest{}, Response: &scenariov1.Scenario{}, Array: true})
+		spec.Describe(methods.Action(ctx, "/unlink", requirements.Unlink(requirementCollection, scenarios.LinkRequirement(meta, scenarioCollection)), auth.GetUserIDFromRequest, requirementRouter, log), openapi.Operation{Summary: "Unlinks scenarios from the requirement", Request: &requirementv1.LinkScenariosRequest{}, Response: &scenariov1.Scenario{}, Array: true})
+		methods.Delete(ctx, requirements.Delete(requirementCollection, listScenarios), requirementRouter, log)
+		methods.Put(ctx, requirements.Update(meta, requirementCollection, getProject), auth.GetUserIDFromRequest, requirementRouter, log)
+
+		// Release endpoints
+		releaseCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Releases, client, []string{releases.ProjectFilterKey, releases.VersionFilterKey})
+		if err != nil {
+			err = fmt.Errorf("%s : %w", "could not start collection", err)
+			log.Errorw("fatal error during startup", "error", err)
+			return err
+		}
+		inProjectRelease := releases.InProject(releaseCollection)
+		releaseRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Releases).Subrouter(), openapi.Resource{Name: "releases", Item: &releasev1.Release{}})
+		releaseRouter.Use(authorized...)
+		methods.Post(ctx, releases.New(meta, releaseCollection, getProject), auth.GetUserIDFromRequest, releaseRouter, log)
+		methods.List(ctx, releases.List(releaseCollection), releaseRouter, log)
+		methods.Get(ctx, releases.Get(releaseCollection), releaseRouter, log)
+		methods.Put(ctx, releases.Update(meta, releaseCollection, getProject), auth.GetUserIDFromRequest, releaseRouter, log)
 
 		// TestPlan endpoints
-		testPlanCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.TestPlans, client, []string{"projectId", "name"})
+		testPlanCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.TestPlans, client, []string{testplans.ProjectFilterKey, testplans.NameFilterKey})
 		if err != nil {
 			err = fmt.Errorf("%s : %w", "could not start collection", err)
 			log.Errorw("fatal error during startup", "error", err)
 			return err
 		}
-		testPlanRouter := versionedRouter.PathPrefix(apiCfg.Endpoints.TestPlans).Subrouter()
-		testPlanRouter.Use(auth.Authorization(authorizer))
-		methods.Post(ctx, testplans.New(meta, testPlanCollection, projects.Get(projectsCollection)), auth.GetUserIDFromRequest, testPlanRouter, log)
-		methods.List(ctx, testplans.List(testPlanCollection), testPlanRouter, log)
-		methods.Get(ctx, testplans.Get(testPlanCollection), testPlanRouter, log)
-		methods.Delete(ctx, testplans.Delete(testPlanCollection), testPlanRouter, log)
-		methods.Put(ctx, testplans.Update(meta, testPlanCollection, projects.Get(projectsCollection)), auth.GetUserIDFromRequest, testPlanRouter, log)
+		createTestPlan := testplans.New(meta, testPlanCollection, getProject, inProjectRelease)
+		listTestPlans := testplans.List(testPlanCollection)
+		getTestPlan := testplans.Get(testPlanCollection)
+		updateTestPlan := testplans.Update(meta, testPlanCollection, getProject, inProjectRelease)
+		deleteTestPlan := testplans.Delete(testPlanCollection)
+		testPlanRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.TestPlans).Subrouter(), openapi.Resource{Name: "testplans", Item: &testplanv1.TestPlan{}})
+		testPlanRouter.Use(authorized...)
+		methods.Post(ctx, createTestPlan, auth.GetUserIDFromRequest, testPlanRouter, log)
+		methods.List(ctx, listTestPlans, testPlanRouter, log)
+		methods.Get(ctx, getTestPlan, testPlanRouter, log)
+		methods.Delete(ctx, deleteTestPlan, testPlanRouter, log)
+		methods.Put(ctx, updateTestPlan, auth.GetUserIDFromRequest, testPlanRouter, log)
 
 		// Executions endpoints
 		executionCollection, err := store.Collection(storeCfg.DataBase, storeCfg.Collections.Executions, client, []string{})
@@ -267,23 +497,200 @@ var Command = &cobra.Command{
 			log.Errorw("fatal error during startup", "error", err)
 			return err
 		}
-		executionRouter := versionedRouter.PathPrefix(apiCfg.Endpoints.Executions).Subrouter()
-		executionRouter.Use(auth.Authorization(authorizer))
-		methods.Post(
-			ctx,
-			executions.New(meta, executionCollection, projects.Get(projectsCollection), scenarios.Get(scenarioCollection), testplans.Get(testPlanCollection)),
-			auth.GetUserIDFromRequest,
-			executionRouter,
-			log,
+		createExecution := executions.New(meta, executionCollection, getProject, getScenario, getTestPlan, stepBlockSteps, inProjectRelease)
+		listExecutions := executions.List(executionCollection)
+		getExecution := executions.Get(executionCollection)
+		updateExecution := executions.Update(meta, executionCollection, getProject, getScenario, getTestPlan)
+		runTestPlan := executions.Run(meta, executionCollection, getProject, getScenario, getTestPlan, stepBlockSteps, inProjectRelease)
+		executionRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Executions).Subrouter(), openapi.Resource{Name: "executions", Item: &executionv1.Execution{}})
+		executionRouter.Use(authorized...)
+		methods.Post(ctx, createExecution, auth.GetUserIDFromRequest, executionRouter, log)
+		methods.List(ctx, listExecutions, executionRouter, log)
+		spec.Describe(methods.Download(ctx, "/export/csv", executions.ExportCSV(executionCollection), executionRouter, log), openapi.Operation{Summary: "Exports the executions as CSV", File: true})
+		blockedExecutionRouter := executionRouter.PathPrefix("/blocked").Subrouter()
+		spec.Describe(methods.List(ctx, issues.Blocked(listExecutions, trackerCfg.Closed()), blockedExecutionRouter, log), openapi.Operation{Summary: "Lists the executions blocked by open issues", Response: &executionv1.Execution{}, List: true})
+		methods.Get(ctx, getExecution, executionRouter, log)
+		methods.Put(ctx, updateExecution, auth.GetUserIDFromRequest, executionRouter, log)
+
+		// Copy of a test plan together with its scenarios
+		spec.Describe(
+			methods.Action(
+				ctx,
+				"/copy",
+				testplans.Copy(meta, testPlanCollection, getProject, listExecutions, copyScenarios),
+				auth.GetUserIDFromRequest,
+				testPlanRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Copies the test plan together with its scenarios", Request: &testplanv1.CopyRequest{}, Response: &testplanv1.CopyResult{}},
+		)
+
+		// Runs of a test plan on the configurations of its matrix
+		spec.Describe(methods.Action(ctx, "/runs", runTestPlan, auth.GetUserIDFromRequest, testPlanRouter, log), openapi.Operation{Summary: "Runs the test plan on the configurations of its matrix", Request: &executionv1.RunRequest{}, Response: &executionv1.Run{}})
+		// Results of automated tests
+		report := openapi.Operation{Summary: "Reports the results of automated tests in the test plan", Request: &executionv1.ReportRequest{}, Response: &executionv1.Report{}}
+		reportResults := func(parse func(io.Reader) ([]*executionv1.TestResult, error)) func(ctx context.Context, author string, id string, data io.Reader) (interface{}, error) {
+			return executions.Report(
+				meta,
+				executionCollection,
+				getTestPlan,
+				scenarios.Automated(scenarioCollection),
+				scenarios.CreateAutomated(meta, scenarioCollection, getProject),
+				stepBlockSteps,
+				inProjectRelease,
+				parse,
+			)
+		}
+		spec.Describe(methods.Action(ctx, "/results/junit", reportResults(junit.Parse), auth.GetUserIDFromRequest, testPlanRouter, log), report)
+		spec.Describe(methods.Action(ctx, "/results/go-test", reportResults(gotest.Parse), auth.GetUserIDFromRequest, testPlanRouter, log), report)
+		spec.Describe(methods.Action(ctx, "/results/cucumber", reportResults(cucumber.Parse), auth.GetUserIDFromRequest, testPlanRouter, log), report)
+		spec.Describe(methods.Action(ctx, "/results/tap", reportResults(tap.Parse), auth.GetUserIDFromRequest, testPlanRouter, log), report)
+		spec.Describe(methods.GetSubresource(ctx, "/summary", testplans.Summary(testPlanCollection, listExecutions), testPlanRouter, log), openapi.Operation{Summary: "Counts the executions of the test plan", Response: &testplanv1.Summary{}})
+		spec.Describe(methods.DownloadSubresource(ctx, "/report/html", testplans.Report(testPlanCollection, listExecutions, ".html", testreport.HTML), testPlanRouter, log), openapi.Operation{Summary: "Downloads the report of the test plan as HTML", File: true})
+		spec.Describe(methods.DownloadSubresource(ctx, "/report/markdown", testplans.Report(testPlanCollection, listExecutions, ".md", testreport.Markdown), testPlanRouter, log), openapi.Operation{Summary: "Downloads the report of the test plan as Markdown", File: true})
+
+		// Automated scenarios that did not produce a result in the last runs of their project
+		spec.Describe(
+			methods.List(
+				ctx,
+				scenarios.Unreported(scenarioCollection, listTestPlans, listExecutions),
+				scenarioRouter.PathPrefix("/automation/unreported").Subrouter(),
+				log,
+			),
+			openapi.Operation{Summary: "Lists the automated scenarios without results in the last runs", Response: &scenariov1.Scenario{}, List: true},
+		)
+
+		// Test plans, executions and readiness of a release
+		spec.Describe(methods.GetRelated(ctx, "/testplans", releases.TestPlans(listTestPlans), releaseRouter, log), openapi.Operation{Summary: "Lists the test plans of the release", Response: &testplanv1.TestPlan{}, List: true})
+		spec.Describe(methods.GetRelated(ctx, "/executions", releases.Executions(listExecutions), releaseRouter, log), openapi.Operation{Summary: "Lists the executions of the release", Response: &executionv1.Execution{}, List: true})
+		spec.Describe(
+			methods.GetSubresource(
+				ctx,
+				"/readiness",
+				releases.Readiness(
+					releaseCollection,
+					listTestPlans,
+					listExecutions,
+					requirements.List(requirementCollection),
+					listScenarios,
+					trackerCfg.Closed(),
+				),
+				releaseRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Returns the readiness of the release", Response: &releasev1.Readiness{}},
+		)
+		methods.Delete(ctx, releases.Delete(releaseCollection, listTestPlans, listExecutions), releaseRouter, log)
+
+		// Custom field summary of a project
+		spec.Describe(
+			methods.GetSubresource(
+				ctx,
+				"/customfields/summary",
+				projects.FieldSummary(projectsCollection, listScenarios, listExecutions),
+				projectRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Counts the values of the custom fields of the project", Response: &customfieldv1.Summary{}},
+		)
+
+		// Requirement coverage of a project
+		spec.Describe(
+			methods.GetSubresource(
+				ctx,
+				"/coverage",
+				requirements.Coverage(requirementCollection, listScenarios, listExecutions),
+				projectRouter,
+				log,
+			),
+			openapi.Operation{Summary: "Returns the requirement coverage of the project", Response: &requirementv1.Coverage{}},
 		)
-		methods.List(ctx, executions.List(executionCollection), executionRouter, log)
-		methods.Get(ctx, executions.Get(executionCollection), executionRouter, log)
-		methods.Put(
-			ctx,
-			executions.Update(meta, executionCollection, projects.Get(projectsCollection), scenarios.Get(scenarioCollection), testplans.Get(testPlanCollection)),
-			auth.GetUserIDFromRequest,
-			executionRouter,
-			log)
+
+		// Issue tracker endpoints
+		if tracker != nil {
+			setScenarioIssues := scenarios.SetIssueStates(meta, scenarioCollection)
+			setExecutionIssues := executions.SetIssueStates(meta, executionCollection)
+			refreshIssues := issues.Refresh(tracker, listScenarios, listExecutions, setScenarioIssues, setExecutionIssues)
+			if trackerCfg.WebhookSecret != "" {
+				// the issue tracker authenticates with the webhook secret instead of a user
+				webhookRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Issues+"/webhook").Subrouter(), openapi.Resource{Name: "issues", Public: true})
+				spec.Describe(
+					methods.CollectionAction(ctx, "", issues.Webhook(tracker, setScenarioIssues, setExecutionIssues), trackerCfg.WebhookUser, webhookRouter, log),
+					openapi.Operation{Summary: "Receives the changes of the issues from the issue tracker", Response: &issuev1.SyncResult{}},
+				)
+			}
+			issueRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.Issues).Subrouter(), openapi.Resource{Name: "issues"})
+			issueRouter.Use(authorized...)
+			refresher := issues.NewRefresher(refreshIssues)
+			go refresher.Watch(ctx, trackerCfg.Interval(), issuetracker.User, log)
+			spec.Describe(methods.CollectionAction(ctx, "/refresh", issues.RefreshNow(refresher.Request), auth.GetUserIDFromRequest, issueRouter, log), openapi.Operation{Summary: "Refreshes the states of the linked issues", Response: &issuev1.SyncResult{}})
+			spec.Describe(
+				methods.Action(
+					ctx,
+					"/defects",
+					issues.ReportDefect(tracker, getExecution, getScenario, executions.AddIssue(meta, executionCollection)),
+					auth.GetUserIDFromRequest,
+					executionRouter,
+					log,
+				),
+				openapi.Operation{Summary: "Reports a defect of the execution in the issue tracker", Request: &issuev1.DefectRequest{}, Response: &executionv1.Execution{}},
+			)
+		}
+
+		// GraphQL endpoint for queries across the entities
+		if apiCfg.Endpoints.GraphQL != "" {
+			graphqlRouter := spec.Resource(versionedRouter.PathPrefix(apiCfg.Endpoints.GraphQL).Subrouter(), openapi.Resource{Name: "graphql"})
+			graphqlRouter.Use(authorized...)
+			schema := apiSchema(
+				projects.List(projectsCollection),
+				listScenarios,
+				listTestPlans,
+				listExecutions,
+				stepblocks.List(stepBlockCollection),
+				folders.List(folderCollection),
+				requirements.List(requirementCollection),
+				releases.List(releaseCollection),
+			)
+			get, post := graphql.Register(ctx, schema, graphqlRouter, log)
+			spec.Describe(get, openapi.Operation{Summary: "Runs the GraphQL query of the `query`, `operationName` and `variables` parameters", Response: &graphql.Response{}})
+			spec.Describe(post, openapi.Operation{Summary: "Runs a GraphQL query", Request: &graphql.Request{}, Response: &graphql.Response{}})
+		}
+
+		// OpenAPI document of the registered routes
+		if apiCfg.Endpoints.OpenAPI != "" || apiCfg.ValidateRequests {
+			doc, err := spec.Generate(r, openapi.Info{Title: app.Name, Version: path.Base(apiCfg.RootPrefix)})
+			if err != nil {
+				err = fmt.Errorf("%s : %w", "could not generate OpenAPI document", err)
+				log.Errorw("fatal error during startup", "error", err)
+				return err
+			}
+			if apiCfg.Endpoints.OpenAPI != "" {
+				versionedRouter.Handle(apiCfg.Endpoints.OpenAPI, doc).Methods(http.MethodGet)
+			}
+		}
+
+		// Start gRPC Server
+		if apiCfg.GRPCPort != "" {
+			grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(logger.GRPCLogging(log), auth.UnaryAuthorization(authorizer, auth.PublicMethods...)))
+			servicev1.RegisterAuthServiceServer(grpcServer, auth.NewService(users.IsPasswordCorrect(userDB), authorizer))
+			servicev1.RegisterProjectServiceServer(grpcServer, rpc.NewProjects(createProject, listProjects, getProject, updateProject, deleteProject, auth.GetUserIDFromContext))
+			servicev1.RegisterScenarioServiceServer(grpcServer, rpc.NewScenarios(createScenario, listScenarios, getScenario, updateScenario, deleteScenario, auth.GetUserIDFromContext))
+			servicev1.RegisterTestPlanServiceServer(grpcServer, rpc.NewTestPlans(createTestPlan, listTestPlans, getTestPlan, updateTestPlan, deleteTestPlan, runTestPlan, auth.GetUserIDFromContext))
+			servicev1.RegisterExecutionServiceServer(grpcServer, rpc.NewExecutions(createExecution, listExecutions, getExecution, updateExecution, auth.GetUserIDFromContext))
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%s", apiCfg.GRPCPort))
+			if err != nil {
+				err = fmt.Errorf("%s : %w", "could not listen on gRPC port", err)
+				log.Errorw("fatal error during startup", "error", err)
+				return err
+			}
+			go func() {
+				if err := grpcServer.Serve(listener); err != nil {
+					log.Fatal(err)
+				}
+			}()
+			log.Infof("gRPC server started on port %s", apiCfg.GRPCPort)
+			defer grpcServer.GracefulStop()
+		}
 
 		// Start HTTP Server
 		srv := &http.Server{
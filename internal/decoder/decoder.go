@@ -1,9 +1,11 @@
 package decoder
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
 )
 
 // Validatable represents an item that has constraints on what a correct structure is an imposes these constraints through the Validate method
@@ -11,14 +13,31 @@ type Validatable interface {
 	Validate() error
 }
 
+// body is a request body which knows the media type of its content
+type body struct {
+	io.Reader
+	mediaType string
+}
+
+// WithContentType marks the data with the media type of a Content-Type header, so that Decode can read JSON, YAML or protobuf.
+// Data which is not marked is read as JSON
+func WithContentType(data io.Reader, contentType string) io.Reader {
+	return &body{Reader: data, mediaType: mediatype.FromContentType(contentType)}
+}
+
 // Decode is used to unmarshall the given data into an object
 func Decode(item Validatable, data io.Reader) error {
-	decoder := json.NewDecoder(data)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(item)
+	mediaType := mediatype.JSON
+	if b, ok := data.(*body); ok {
+		mediaType = b.mediaType
+	}
+	content, err := ioutil.ReadAll(data)
 	if err != nil {
 		return NewValidationError(fmt.Sprintf("invalid body: %s", err.Error()))
 	}
+	if err := mediatype.Unmarshal(content, item, mediaType); err != nil {
+		return NewValidationError(fmt.Sprintf("invalid body: %s", err.Error()))
+	}
 	if err = item.Validate(); err != nil {
 		return NewValidationError(err.Error())
 	}
@@ -15,19 +15,20 @@ type conditions interface {
 	IsAlive() (bool, interface{})
 }
 
-// RegisterHTTPProbes adds the probe api to the given router
-func RegisterHTTPProbes(r *mux.Router, c conditions) {
-	r.HandleFunc("/alive", conditionHandler(c.IsAlive)).Methods(http.MethodGet)
-	r.HandleFunc("/ready", conditionHandler(c.IsReady)).Methods(http.MethodGet)
+// RegisterHTTPProbes adds the probe api to the given router. It returns the registered routes
+func RegisterHTTPProbes(r *mux.Router, c conditions) (alive *mux.Route, ready *mux.Route) {
+	alive = r.HandleFunc("/alive", conditionHandler(c.IsAlive)).Methods(http.MethodGet)
+	ready = r.HandleFunc("/ready", conditionHandler(c.IsReady)).Methods(http.MethodGet)
+	return alive, ready
 }
 
 func conditionHandler(c check) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ready, status := c()
 		if ready {
-			response.Send(w, status, http.StatusOK)
+			response.Send(w, r, status, http.StatusOK)
 		} else {
-			response.Send(w, status, http.StatusInternalServerError)
+			response.Send(w, r, status, http.StatusInternalServerError)
 		}
 	}
 }
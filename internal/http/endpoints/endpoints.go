@@ -23,19 +23,28 @@ func (e *errList) Error() string {
 
 // Config represents the API information
 type Config struct {
-	RootPrefix string    `json:"rootPrefix"`
-	Port       string    `json:"port"`
-	Endpoints  Endpoints `json:"endpoints"`
+	RootPrefix       string    `json:"rootPrefix"`
+	Port             string    `json:"port"`
+	GRPCPort         string    `json:"grpcPort,omitempty"`
+	ValidateRequests bool      `json:"validateRequests,omitempty"`
+	Endpoints        Endpoints `json:"endpoints"`
 }
 
 // Endpoints represent the endpoints that are exposed by the server
 type Endpoints struct {
-	Probes     string `json:"probes"`
-	Projects   string `json:"projects"`
-	Scenarios  string `json:"scenarios"`
-	TestPlans  string `json:"testplans"`
-	Executions string `json:"executions"`
-	Admin      Admin  `json:"admin"`
+	Probes       string `json:"probes"`
+	Projects     string `json:"projects"`
+	Scenarios    string `json:"scenarios"`
+	TestPlans    string `json:"testplans"`
+	Executions   string `json:"executions"`
+	StepBlocks   string `json:"stepblocks"`
+	Folders      string `json:"folders"`
+	Requirements string `json:"requirements"`
+	Issues       string `json:"issues"`
+	Releases     string `json:"releases"`
+	OpenAPI      string `json:"openapi,omitempty"`
+	GraphQL      string `json:"graphql,omitempty"`
+	Admin        Admin  `json:"admin"`
 }
 
 // Admin represent the administration endpoints
@@ -80,6 +89,21 @@ func (c Endpoints) Validate() error {
 	if c.Executions == "" {
 		errs.add("executions field is mandatory")
 	}
+	if c.StepBlocks == "" {
+		errs.add("stepblocks field is mandatory")
+	}
+	if c.Folders == "" {
+		errs.add("folders field is mandatory")
+	}
+	if c.Requirements == "" {
+		errs.add("requirements field is mandatory")
+	}
+	if c.Issues == "" {
+		errs.add("issues field is mandatory")
+	}
+	if c.Releases == "" {
+		errs.add("releases field is mandatory")
+	}
 	if !errs.isEmpty() {
 		return errs
 	}
@@ -2,6 +2,7 @@ package methods
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
@@ -9,11 +10,15 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/http/response"
 	"github.com/curious-kitten/scratch-post/internal/logger"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
 	"github.com/curious-kitten/scratch-post/internal/store"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
 )
 
 type create func(ctx context.Context, author string, body io.Reader) (interface{}, error)
@@ -21,33 +26,41 @@ type list func(ctx context.Context, filter map[string][]string, sortBy string, r
 type get func(ctx context.Context, id string) (interface{}, error)
 type updateItem func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error)
 type deleteItem func(ctx context.Context, id string) error
+type related func(ctx context.Context, id string) ([]interface{}, error)
+type action func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error)
+type download func(ctx context.Context, filter map[string][]string) (string, []byte, error)
+type find func(ctx context.Context, filter map[string][]string) (interface{}, error)
+type downloadSubresource func(ctx context.Context, id string, filter map[string][]string) (string, []byte, error)
+
+// extractUserName returns the user that sent the request. Requests without a user are rejected as unauthenticated.
 type extractUserName func(r *http.Request) (string, error)
 
 // Post reponds to a HTTP Post request to a collection
-func Post(ctx context.Context, createFunc create, getUser extractUserName, r *mux.Router, log logger.Logger) {
+func Post(ctx context.Context, createFunc create, getUser extractUserName, r *mux.Router, log logger.Logger) *mux.Route {
 	c := func(w http.ResponseWriter, r *http.Request) {
 		user, err := getUser(r)
 		if err != nil {
-			response.SendError(w, err.Error(), http.StatusBadRequest)
+			response.SendError(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
 		defer cancel()
 
-		item, err := createFunc(toctx, user, r.Body)
+		item, err := createFunc(toctx, user, decoder.WithContentType(r.Body, r.Header.Get("Content-Type")))
 		if err != nil {
 			handleError(err, w)
 			return
 		}
-		response.Send(w, item, http.StatusCreated)
+		response.Send(w, r, item, http.StatusCreated)
 	}
 	route := r.HandleFunc("", c).Methods(http.MethodPost)
 	path, _ := route.GetPathTemplate()
 	log.Infow("added endpoint", "path", path, "method", http.MethodPost)
+	return route
 }
 
 // List reponds to a HTTP Get request for a collection
-func List(ctx context.Context, listFunc list, r *mux.Router, log logger.Logger) {
+func List(ctx context.Context, listFunc list, r *mux.Router, log logger.Logger) *mux.Route {
 	l := func(w http.ResponseWriter, r *http.Request) {
 		var err error
 		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
@@ -80,18 +93,19 @@ func List(ctx context.Context, listFunc list, r *mux.Router, log logger.Logger)
 		}
 		items, err := listFunc(toctx, queries, sortBy, reverse, count, lastFoundValue)
 		if err != nil {
-			response.SendError(w, err.Error(), http.StatusInternalServerError)
+			handleError(err, w)
 			return
 		}
 		itemList := &ItemList{
 			Count: len(items),
 			Items: items,
 		}
-		response.Send(w, itemList, http.StatusOK)
+		response.Send(w, r, itemList, http.StatusOK)
 	}
 	route := r.HandleFunc("", l).Methods(http.MethodGet)
 	path, _ := route.GetPathTemplate()
 	log.Infow("added endpoint", "path", path, "method", http.MethodGet)
+	return route
 }
 
 // ItemList formats the collection get response to a list
@@ -100,8 +114,36 @@ type ItemList struct {
 	Items []interface{} `json:"items"`
 }
 
+// MarshalJSON encodes the items with protojson, as when they are sent on their own
+func (l *ItemList) MarshalJSON() ([]byte, error) {
+	items, err := mediatype.MarshalJSON(l.Items)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Count int             `json:"count"`
+		Items json.RawMessage `json:"items"`
+	}{Count: l.Count, Items: items})
+}
+
+// ToProto converts the list for the protobuf responses. The items have to be protobuf messages
+func (l *ItemList) ToProto() (proto.Message, error) {
+	list := &servicev1.ItemList{Count: int32(l.Count), Items: make([]*anypb.Any, len(l.Items))}
+	for i, item := range l.Items {
+		message, ok := item.(proto.Message)
+		if !ok {
+			return nil, mediatype.ErrNotProtobuf
+		}
+		var err error
+		if list.Items[i], err = anypb.New(message); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
 // Get returns a single instance of an item based on the ID in the path
-func Get(ctx context.Context, getterFunc get, r *mux.Router, log logger.Logger) {
+func Get(ctx context.Context, getterFunc get, r *mux.Router, log logger.Logger) *mux.Route {
 	i := func(w http.ResponseWriter, r *http.Request) {
 		params := mux.Vars(r)
 		id := params["id"]
@@ -112,15 +154,169 @@ func Get(ctx context.Context, getterFunc get, r *mux.Router, log logger.Logger)
 			handleError(err, w)
 			return
 		}
-		response.Send(w, item, http.StatusOK)
+		response.Send(w, r, item, http.StatusOK)
 	}
 	route := r.HandleFunc("/{id}", i).Methods(http.MethodGet)
 	path, _ := route.GetPathTemplate()
 	log.Infow("added endpoint", "path", path, "method", http.MethodGet)
+	return route
+}
+
+// GetRelated returns the items related to the item with the ID in the path. The related items are exposed under the provided path
+func GetRelated(ctx context.Context, path string, relatedFunc related, r *mux.Router, log logger.Logger) *mux.Route {
+	i := func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		id := params["id"]
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		items, err := relatedFunc(toctx, id)
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		itemList := &ItemList{
+			Count: len(items),
+			Items: items,
+		}
+		response.Send(w, r, itemList, http.StatusOK)
+	}
+	route := r.HandleFunc("/{id}"+path, i).Methods(http.MethodGet)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodGet)
+	return route
+}
+
+// GetSubresource returns a single item exposed under the provided path of the item with the ID in the path
+func GetSubresource(ctx context.Context, path string, getterFunc get, r *mux.Router, log logger.Logger) *mux.Route {
+	i := func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		id := params["id"]
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		item, err := getterFunc(toctx, id)
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		response.Send(w, r, item, http.StatusOK)
+	}
+	route := r.HandleFunc("/{id}"+path, i).Methods(http.MethodGet)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodGet)
+	return route
+}
+
+// Action responds to a HTTP Post request used to perform an action on the item with the ID in the path
+func Action(ctx context.Context, path string, actionFunc action, getUser extractUserName, r *mux.Router, log logger.Logger) *mux.Route {
+	a := func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		id := params["id"]
+		user, err := getUser(r)
+		if err != nil {
+			response.SendError(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		item, err := actionFunc(toctx, user, id, decoder.WithContentType(r.Body, r.Header.Get("Content-Type")))
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		response.Send(w, r, item, http.StatusOK)
+	}
+	route := r.HandleFunc("/{id}"+path, a).Methods(http.MethodPost)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodPost)
+	return route
+}
+
+// CollectionAction responds to a HTTP Post request used to perform an action on multiple items of a collection
+func CollectionAction(ctx context.Context, path string, actionFunc create, getUser extractUserName, r *mux.Router, log logger.Logger) *mux.Route {
+	a := func(w http.ResponseWriter, r *http.Request) {
+		user, err := getUser(r)
+		if err != nil {
+			response.SendError(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		item, err := actionFunc(toctx, user, decoder.WithContentType(r.Body, r.Header.Get("Content-Type")))
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		response.Send(w, r, item, http.StatusOK)
+	}
+	route := r.HandleFunc(path, a).Methods(http.MethodPost)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodPost)
+	return route
+}
+
+// Find responds to a HTTP Get request with a single item found in a collection. The query parameters are used as filter.
+// The find function returns a nil item when nothing matches the filter
+func Find(ctx context.Context, path string, findFunc find, r *mux.Router, log logger.Logger) *mux.Route {
+	f := func(w http.ResponseWriter, r *http.Request) {
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		item, err := findFunc(toctx, r.URL.Query())
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		if item == nil {
+			response.SendError(w, "could not find requested item", http.StatusNotFound)
+			return
+		}
+		response.Send(w, r, item, http.StatusOK)
+	}
+	route := r.HandleFunc(path, f).Methods(http.MethodGet)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodGet)
+	return route
+}
+
+// Download responds to a HTTP Get request with a file generated from the items of a collection. The query parameters are used as filter
+func Download(ctx context.Context, path string, downloadFunc download, r *mux.Router, log logger.Logger) *mux.Route {
+	d := func(w http.ResponseWriter, r *http.Request) {
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		name, content, err := downloadFunc(toctx, r.URL.Query())
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		response.SendFile(w, name, content)
+	}
+	route := r.HandleFunc(path, d).Methods(http.MethodGet)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodGet)
+	return route
+}
+
+// DownloadSubresource responds to a HTTP Get request with a file generated from the item with the ID in the path. The query parameters are used as filter
+func DownloadSubresource(ctx context.Context, path string, downloadFunc downloadSubresource, r *mux.Router, log logger.Logger) *mux.Route {
+	d := func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		id := params["id"]
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		name, content, err := downloadFunc(toctx, id, r.URL.Query())
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+		response.SendFile(w, name, content)
+	}
+	route := r.HandleFunc("/{id}"+path, d).Methods(http.MethodGet)
+	routePath, _ := route.GetPathTemplate()
+	log.Infow("added endpoint", "path", routePath, "method", http.MethodGet)
+	return route
 }
 
 // Delete provides an API endpoint used to delete an intem
-func Delete(ctx context.Context, deleterFunc deleteItem, r *mux.Router, log logger.Logger) {
+func Delete(ctx context.Context, deleterFunc deleteItem, r *mux.Router, log logger.Logger) *mux.Route {
 	d := func(w http.ResponseWriter, r *http.Request) {
 		params := mux.Vars(r)
 		id := params["id"]
@@ -130,37 +326,39 @@ func Delete(ctx context.Context, deleterFunc deleteItem, r *mux.Router, log logg
 			handleError(err, w)
 			return
 		}
-		response.Send(w, struct {
+		response.Send(w, r, struct {
 			Item string `json:"item"`
 		}{Item: id}, http.StatusOK)
 	}
 	route := r.HandleFunc("/{id}", d).Methods(http.MethodDelete)
 	path, _ := route.GetPathTemplate()
 	log.Infow("added endpoint", "path", path, "method", http.MethodDelete)
+	return route
 }
 
 // Put provides an API endpoint used to update an intem
-func Put(ctx context.Context, updateFunc updateItem, getUser extractUserName, r *mux.Router, log logger.Logger) {
+func Put(ctx context.Context, updateFunc updateItem, getUser extractUserName, r *mux.Router, log logger.Logger) *mux.Route {
 	u := func(w http.ResponseWriter, r *http.Request) {
 		params := mux.Vars(r)
 		id := params["id"]
 		user, err := getUser(r)
 		if err != nil {
-			response.SendError(w, err.Error(), http.StatusBadRequest)
+			response.SendError(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
 		defer cancel()
-		item, err := updateFunc(toctx, user, id, r.Body)
+		item, err := updateFunc(toctx, user, id, decoder.WithContentType(r.Body, r.Header.Get("Content-Type")))
 		if err != nil {
 			handleError(err, w)
 			return
 		}
-		response.Send(w, item, http.StatusOK)
+		response.Send(w, r, item, http.StatusOK)
 	}
 	route := r.HandleFunc("/{id}", u).Methods(http.MethodPut)
 	path, _ := route.GetPathTemplate()
 	log.Infow("added endpoint", "path", path, "method", http.MethodPut)
+	return route
 }
 
 func handleError(err error, w http.ResponseWriter) {
@@ -170,7 +368,7 @@ func handleError(err error, w http.ResponseWriter) {
 	case decoder.IsValidationError(err):
 		response.SendError(w, err.Error(), http.StatusBadRequest)
 	case store.IsDuplicateError(err):
-		response.SendError(w, "item already exists", http.StatusBadRequest)
+		response.SendError(w, store.DuplicateMessage(err), http.StatusBadRequest)
 	default:
 		response.SendError(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -0,0 +1,52 @@
+package methods_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/curious-kitten/scratch-post/internal/http/methods"
+)
+
+func noUser(r *http.Request) (string, error) {
+	return "", fmt.Errorf("request has an invalid webhook secret")
+}
+
+func TestMethods_Unauthenticated(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	log := zap.NewNop().Sugar()
+	called := false
+	create := func(ctx context.Context, author string, body io.Reader) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	update := func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	r := mux.NewRouter()
+	methods.Post(ctx, create, noUser, r.PathPrefix("/items").Subrouter(), log)
+	methods.Put(ctx, update, noUser, r.PathPrefix("/items").Subrouter(), log)
+	methods.Action(ctx, "/run", update, noUser, r.PathPrefix("/items").Subrouter(), log)
+	methods.CollectionAction(ctx, "", create, noUser, r.PathPrefix("/webhook").Subrouter(), log)
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("{}")),
+		httptest.NewRequest(http.MethodPut, "/items/item", strings.NewReader("{}")),
+		httptest.NewRequest(http.MethodPost, "/items/item/run", strings.NewReader("{}")),
+		httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}")),
+	} {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, request)
+		g.Expect(recorder.Code).To(Equal(http.StatusUnauthorized), "%s %s without a user was not rejected as unauthenticated", request.Method, request.URL.Path)
+	}
+	g.Expect(called).To(BeFalse(), "business function was called without a user")
+}
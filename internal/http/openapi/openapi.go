@@ -0,0 +1,353 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/http/response"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+)
+
+// Version of the OpenAPI specification followed by the documents
+const Version = "3.0.3"
+
+// authCookie is the cookie in which the server expects the auth token
+const authCookie = "auth-token"
+
+// Operation describes what a route reads and sends. Request and Response are values of the types, ie. &projectv1.Project{}
+type Operation struct {
+	Summary  string
+	Request  interface{}
+	Response interface{}
+	// List is set when the response is a list of Response with its count, as sent by the collection endpoints
+	List bool
+	// Array is set when the response is a JSON array of Response
+	Array bool
+	// File is set when the response is a file to download
+	File bool
+	// Public is set when the route is called without a token
+	Public bool
+}
+
+// Resource describes the routes registered on a router and on its subrouters
+type Resource struct {
+	Name string
+	// Item is the type of the items of a collection. POST and GET on the router and GET, PUT and DELETE on `/{id}` are described from it
+	Item interface{}
+	// Public is set when the routes are called without a token
+	Public bool
+}
+
+// Spec collects the descriptions of the routes while they are registered, so that the document is generated from the registrations
+type Spec struct {
+	resources  map[*mux.Router]Resource
+	operations map[*mux.Route]Operation
+	document   *Document
+}
+
+// NewSpec returns an empty spec
+func NewSpec() *Spec {
+	return &Spec{
+		resources:  map[*mux.Router]Resource{},
+		operations: map[*mux.Route]Operation{},
+	}
+}
+
+// Resource describes the routes of the router. It returns the router
+func (s *Spec) Resource(r *mux.Router, resource Resource) *mux.Router {
+	s.resources[r] = resource
+	return r
+}
+
+// Describe sets the operation of the route. It returns the route
+func (s *Spec) Describe(route *mux.Route, op Operation) *mux.Route {
+	s.operations[route] = op
+	return route
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document is an OpenAPI 3 document. It is served as JSON or YAML
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+	Security   []map[string][]string            `json:"security"`
+
+	// bodies create the values into which the bodies of the routes are decoded, keyed by method and path template
+	bodies map[string]func() interface{}
+}
+
+type components struct {
+	Schemas         schemas                   `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+type operation struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Parameters  []parameter            `json:"parameters,omitempty"`
+	RequestBody *requestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*reply      `json:"responses"`
+	Security    *[]map[string][]string `json:"security,omitempty"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type reply struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+var pathParameter = regexp.MustCompile(`\{(\w+)(:[^}]*)?\}`)
+
+// Generate describes the routes registered on the router. The routes which are not described, nor part of a resource with items, are
+// documented without schemas. The document is also the one used by Validate
+func (s *Spec) Generate(r *mux.Router, info Info) (*Document, error) {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]map[string]*operation{},
+		Components: components{
+			Schemas: schemas{
+				"Error": {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"error": {Type: "string"},
+						"code":  {Type: "integer", Format: "int32"},
+					},
+				},
+			},
+			SecuritySchemes: map[string]securityScheme{"cookieAuth": {Type: "apiKey", In: "cookie", Name: authCookie}},
+		},
+		Security: []map[string][]string{{"cookieAuth": {}}},
+		bodies:   map[string]func() interface{}{},
+	}
+	// the subrouters are only known from the routes registered on them
+	subrouters := map[*mux.Route]*mux.Router{}
+	err := r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if len(ancestors) > 0 {
+			subrouters[ancestors[len(ancestors)-1]] = router
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// path prefixes of the subrouters do not handle requests
+			return nil
+		}
+		resource, prefix := s.resourceOf(router, ancestors, subrouters)
+		for _, method := range methods {
+			op, described := s.operations[route]
+			status := http.StatusOK
+			if !described {
+				op, status = describe(method, strings.TrimPrefix(template, prefix), resource)
+			}
+			doc.add(method, template, resource, op, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.document = doc
+	return doc, nil
+}
+
+// resourceOf returns the resource of the closest router of the route, and the path prefix of that router
+func (s *Spec) resourceOf(router *mux.Router, ancestors []*mux.Route, subrouters map[*mux.Route]*mux.Router) (Resource, string) {
+	if len(ancestors) == 0 {
+		return s.resources[router], ""
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		resource, ok := s.resources[subrouters[ancestors[i]]]
+		if !ok {
+			continue
+		}
+		prefix, err := ancestors[i].GetPathTemplate()
+		if err != nil {
+			continue
+		}
+		return resource, prefix
+	}
+	return Resource{}, ""
+}
+
+// describe returns the operation of a route which was not described, as deduced from the items of its resource
+func describe(method string, path string, resource Resource) (Operation, int) {
+	if resource.Item == nil {
+		return Operation{}, http.StatusOK
+	}
+	name := typeName(resource.Item)
+	switch strings.TrimSpace(method + " " + path) {
+	case http.MethodPost:
+		return Operation{Summary: "Creates " + article(name), Request: resource.Item, Response: resource.Item}, http.StatusCreated
+	case http.MethodGet:
+		return Operation{Summary: "Lists the " + resource.Name, Response: resource.Item, List: true}, http.StatusOK
+	case http.MethodGet + " /{id}":
+		return Operation{Summary: "Returns the " + name, Response: resource.Item}, http.StatusOK
+	case http.MethodPut + " /{id}":
+		return Operation{Summary: "Replaces the " + name, Request: resource.Item, Response: resource.Item}, http.StatusOK
+	case http.MethodDelete + " /{id}":
+		return Operation{Summary: "Deletes the " + name, Response: &deleted{}}, http.StatusOK
+	}
+	return Operation{}, http.StatusOK
+}
+
+// deleted is the response of the deletions
+type deleted struct {
+	Item string `json:"item"`
+}
+
+func (d *Document) add(method string, template string, resource Resource, op Operation, status int) {
+	described := &operation{
+		Summary:   op.Summary,
+		Responses: map[string]*reply{},
+	}
+	if resource.Name != "" {
+		described.Tags = []string{resource.Name}
+	}
+	if resource.Public || op.Public {
+		described.Security = &[]map[string][]string{}
+	} else {
+		described.Responses["401"] = &reply{Description: "the request has no valid token"}
+	}
+	for _, match := range pathParameter.FindAllStringSubmatch(template, -1) {
+		described.Parameters = append(described.Parameters, parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	// mux allows patterns in the variables, OpenAPI only their names
+	path := pathParameter.ReplaceAllString(template, "{$1}")
+	if op.List {
+		described.Parameters = append(described.Parameters,
+			parameter{Name: "sortBy", In: "query", Description: "field to sort by, as `field:asc` or `field:desc`", Schema: &Schema{Type: "string"}},
+			parameter{Name: "count", In: "query", Description: "maximum number of items", Schema: &Schema{Type: "integer", Format: "int32"}},
+			parameter{Name: "lastValue", In: "query", Description: "value of the sort field of the last item of the previous page", Schema: &Schema{Type: "string"}},
+		)
+	}
+	if op.Request != nil {
+		described.RequestBody = &requestBody{Required: true, Content: d.content(op.Request, d.Components.Schemas.of(op.Request))}
+		d.bodies[method+" "+template] = constructor(op.Request)
+	}
+	success := &reply{Description: "success"}
+	switch {
+	case op.File:
+		success.Content = map[string]mediaType{"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case op.List:
+		list := &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"count": {Type: "integer", Format: "int32"},
+				"items": {Type: "array", Items: d.Components.Schemas.of(op.Response)},
+			},
+		}
+		success.Content = d.content(op.Response, list)
+	case op.Array:
+		success.Content = d.content(nil, &Schema{Type: "array", Items: d.Components.Schemas.of(op.Response)})
+	case op.Response != nil:
+		success.Content = d.content(op.Response, d.Components.Schemas.of(op.Response))
+	}
+	described.Responses[fmt.Sprint(status)] = success
+	errorContent := map[string]mediaType{mediatype.JSON: {Schema: d.Components.Schemas.ref("Error")}}
+	described.Responses["400"] = &reply{Description: "the request is not valid", Content: errorContent}
+	if strings.Contains(path, "{") {
+		described.Responses["404"] = &reply{Description: "the item does not exist", Content: errorContent}
+	}
+	described.Responses["500"] = &reply{Description: "the request could not be handled", Content: errorContent}
+
+	if d.Paths[path] == nil {
+		d.Paths[path] = map[string]*operation{}
+	}
+	d.Paths[path][strings.ToLower(method)] = described
+}
+
+// content lists the media types of a body. Protobuf is listed only for the protobuf messages
+func (d *Document) content(value interface{}, schema *Schema) map[string]mediaType {
+	content := map[string]mediaType{
+		mediatype.JSON: {Schema: schema},
+		mediatype.YAML: {Schema: schema},
+	}
+	if _, ok := value.(proto.Message); ok {
+		content[mediatype.Protobuf] = mediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	return content
+}
+
+// ServeHTTP sends the document, in the media type accepted by the request
+func (d *Document) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response.Send(w, r, d, http.StatusOK)
+}
+
+// constructor returns a function which creates new values of the type of the value
+func constructor(value interface{}) func() interface{} {
+	if message, ok := value.(proto.Message); ok {
+		return func() interface{} {
+			return message.ProtoReflect().New().Interface()
+		}
+	}
+	t := reflect.TypeOf(value)
+	return func() interface{} {
+		if t.Kind() == reflect.Ptr {
+			return reflect.New(t.Elem()).Interface()
+		}
+		return reflect.New(t).Interface()
+	}
+}
+
+func typeName(value interface{}) string {
+	if message, ok := value.(proto.Message); ok {
+		return string(message.ProtoReflect().Descriptor().Name())
+	}
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func article(name string) string {
+	if name != "" && strings.ContainsRune("AEIOU", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
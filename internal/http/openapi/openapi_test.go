@@ -0,0 +1,145 @@
+package openapi_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/http/openapi"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+)
+
+type login struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// api registers a small API, described as the start command describes its routes
+func api(spec *openapi.Spec) *mux.Router {
+	r := mux.NewRouter()
+	versioned := r.PathPrefix("/api/v1").Subrouter()
+	spec.Describe(versioned.HandleFunc("/login", ok).Methods(http.MethodPost), openapi.Operation{Summary: "Logs the user in", Request: &login{}, Public: true})
+	versioned.HandleFunc("/version", ok).Methods(http.MethodGet)
+
+	projects := spec.Resource(versioned.PathPrefix("/projects").Subrouter(), openapi.Resource{Name: "projects", Item: &projectv1.Project{}})
+	projects.Use(spec.Validate)
+	projects.HandleFunc("", ok).Methods(http.MethodPost)
+	projects.HandleFunc("", ok).Methods(http.MethodGet)
+	projects.HandleFunc("/{id}", ok).Methods(http.MethodGet)
+	projects.HandleFunc("/{id}", ok).Methods(http.MethodDelete)
+	spec.Describe(projects.HandleFunc("/{id}/export", ok).Methods(http.MethodGet), openapi.Operation{Summary: "Exports the project", File: true})
+	spec.Describe(projects.PathPrefix("/archived").Subrouter().HandleFunc("", ok).Methods(http.MethodGet), openapi.Operation{Summary: "Lists the archived projects", Response: &projectv1.Project{}, List: true})
+	return r
+}
+
+func TestSpec_Generate(t *testing.T) {
+	g := NewWithT(t)
+	spec := openapi.NewSpec()
+	doc, err := spec.Generate(api(spec), openapi.Info{Title: "scratch-post", Version: "v1"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(doc.OpenAPI).To(Equal(openapi.Version))
+	g.Expect(doc.Paths).To(HaveLen(6), "registered paths are not described")
+
+	create := doc.Paths["/api/v1/projects"]["post"]
+	g.Expect(create.Summary).To(Equal("Creates a Project"), "operation was not deduced from the items of the resource")
+	g.Expect(create.Tags).To(Equal([]string{"projects"}))
+	g.Expect(create.RequestBody.Content).To(HaveKey(mediatype.Protobuf), "protobuf bodies are not listed for messages")
+	g.Expect(create.RequestBody.Content[mediatype.JSON].Schema.Ref).To(Equal("#/components/schemas/project.scratchpost.curiouskitten.Project"))
+	g.Expect(create.Responses).To(HaveKey("201"))
+	g.Expect(create.Responses).To(HaveKey("401"), "routes need a token unless they are public")
+	g.Expect(doc.Components.Schemas).To(HaveKey("project.scratchpost.curiouskitten.Project"), "schema of the message was not added")
+
+	list := doc.Paths["/api/v1/projects"]["get"]
+	g.Expect(list.Parameters).To(HaveLen(3), "paging parameters are missing")
+	g.Expect(list.Responses["200"].Content[mediatype.JSON].Schema.Properties).To(HaveKey("items"))
+
+	get := doc.Paths["/api/v1/projects/{id}"]["get"]
+	g.Expect(get.Parameters[0].Name).To(Equal("id"))
+	g.Expect(get.Responses).To(HaveKey("404"))
+	g.Expect(doc.Paths["/api/v1/projects/{id}"]).To(HaveKey("delete"))
+
+	export := doc.Paths["/api/v1/projects/{id}/export"]["get"]
+	g.Expect(export.Summary).To(Equal("Exports the project"), "description of the route was not used")
+	g.Expect(export.Responses["200"].Content).To(HaveKey("application/octet-stream"))
+
+	archived := doc.Paths["/api/v1/projects/archived"]["get"]
+	g.Expect(archived.Tags).To(Equal([]string{"projects"}), "routes of the subrouters are not part of the resource")
+	g.Expect(archived.Summary).To(Equal("Lists the archived projects"))
+
+	loginOp := doc.Paths["/api/v1/login"]["post"]
+	g.Expect(*loginOp.Security).To(BeEmpty(), "public routes need a token")
+	g.Expect(loginOp.Responses).NotTo(HaveKey("401"))
+	g.Expect(loginOp.RequestBody.Content).NotTo(HaveKey(mediatype.Protobuf), "protobuf bodies are listed for Go types")
+	g.Expect(doc.Components.Schemas["openapi_test.login"].Properties).To(HaveKey("username"))
+
+	version := doc.Paths["/api/v1/version"]["get"]
+	g.Expect(version.Tags).To(BeEmpty(), "routes outside of the resources are tagged")
+	g.Expect(version.RequestBody).To(BeNil())
+}
+
+func TestSpec_Validate(t *testing.T) {
+	spec := openapi.NewSpec()
+	r := api(spec)
+	_, err := spec.Generate(r, openapi.Info{Title: "scratch-post", Version: "v1"})
+	NewWithT(t).Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{name: "valid body", method: http.MethodPost, target: "/api/v1/projects", body: `{"name": "shop"}`, status: http.StatusOK},
+		{name: "invalid body", method: http.MethodPost, target: "/api/v1/projects", body: `{"name": 5}`, status: http.StatusBadRequest},
+		{name: "not a body", method: http.MethodPost, target: "/api/v1/projects", body: `shop`, status: http.StatusBadRequest},
+		{name: "valid count", method: http.MethodGet, target: "/api/v1/projects?count=5", status: http.StatusOK},
+		{name: "invalid count", method: http.MethodGet, target: "/api/v1/projects?count=five", status: http.StatusBadRequest},
+		{name: "route without body", method: http.MethodDelete, target: "/api/v1/projects/shop", body: `shop`, status: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			request := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", mediatype.JSON)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, request)
+			g.Expect(recorder.Code).To(Equal(tc.status), recorder.Body.String())
+		})
+	}
+}
+
+func TestSpec_ValidateKeepsBody(t *testing.T) {
+	g := NewWithT(t)
+	spec := openapi.NewSpec()
+	r := mux.NewRouter()
+	projects := spec.Resource(r.PathPrefix("/projects").Subrouter(), openapi.Resource{Name: "projects", Item: &projectv1.Project{}})
+	projects.Use(spec.Validate)
+	received := ""
+	projects.HandleFunc("", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+	}).Methods(http.MethodPost)
+	_, err := spec.Generate(r, openapi.Info{Title: "scratch-post", Version: "v1"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(`{"name": "shop"}`)))
+	g.Expect(received).To(Equal(`{"name": "shop"}`), "body was not kept for the handler")
+}
+
+func TestSpec_ValidateBeforeGenerate(t *testing.T) {
+	g := NewWithT(t)
+	spec := openapi.NewSpec()
+	r := api(spec)
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/projects", strings.NewReader(`{"name": 5}`)))
+	g.Expect(recorder.Code).To(Equal(http.StatusOK), "requests are checked before the document is generated")
+}
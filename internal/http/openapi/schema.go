@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Schema is the OpenAPI description of a value
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+var messageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// schemas describes the types of the operations. The messages and the structs are added to the components and referenced
+type schemas map[string]*Schema
+
+func (s schemas) ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// of returns the schema of the type of the value, a protobuf message or a Go type
+func (s schemas) of(value interface{}) *Schema {
+	if value == nil {
+		return &Schema{}
+	}
+	if message, ok := value.(proto.Message); ok {
+		return s.message(message.ProtoReflect().Descriptor())
+	}
+	return s.goType(reflect.TypeOf(value))
+}
+
+// message describes a protobuf message as protojson encodes it
+func (s schemas) message(descriptor protoreflect.MessageDescriptor) *Schema {
+	name := string(descriptor.FullName())
+	if name == "google.protobuf.Any" {
+		return &Schema{
+			Type:                 "object",
+			Properties:           map[string]*Schema{"@type": {Type: "string"}},
+			AdditionalProperties: true,
+		}
+	}
+	if _, ok := s[name]; !ok {
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		// added before the fields, so that recursive messages reference it
+		s[name] = schema
+		fields := descriptor.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			schema.Properties[fields.Get(i).JSONName()] = s.field(fields.Get(i))
+		}
+	}
+	return s.ref(name)
+}
+
+func (s schemas) field(field protoreflect.FieldDescriptor) *Schema {
+	if field.IsMap() {
+		return &Schema{Type: "object", AdditionalProperties: s.singular(field.MapValue())}
+	}
+	if field.IsList() {
+		return &Schema{Type: "array", Items: s.singular(field)}
+	}
+	return s.singular(field)
+}
+
+func (s schemas) singular(field protoreflect.FieldDescriptor) *Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson sends the 64-bit integers as strings
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return &Schema{Type: "string", Enum: names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return s.message(field.Message())
+	}
+	return &Schema{Type: "string"}
+}
+
+// goType describes a Go type as encoding/json encodes it
+func (s schemas) goType(t reflect.Type) *Schema {
+	if t.Implements(messageType) {
+		return s.of(reflect.Zero(t).Interface())
+	}
+	if reflect.PtrTo(t).Implements(messageType) {
+		return s.of(reflect.New(t).Interface())
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.goType(t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := s[name]; !ok {
+			schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+			s[name] = schema
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				if field.PkgPath != "" {
+					continue
+				}
+				key := strings.Split(field.Tag.Get("json"), ",")[0]
+				if key == "-" {
+					continue
+				}
+				if key == "" {
+					key = field.Name
+				}
+				schema.Properties[key] = s.goType(field.Type)
+			}
+		}
+		return s.ref(name)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.goType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.goType(t.Elem())}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	}
+	return &Schema{}
+}
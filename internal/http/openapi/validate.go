@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/curious-kitten/scratch-post/internal/http/response"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+)
+
+// Validate is a middleware which rejects the requests that do not match the generated document: a body which cannot be decoded into
+// the type of the operation or a `count` which is not a number. It is used on the routers before their routes are registered,
+// so the requests are let through until the document is generated. Used after the authorization, only the requests with a valid token are read
+func (s *Spec) Validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.document != nil {
+			if err := s.document.check(r); err != nil {
+				response.SendError(w, fmt.Sprintf("request does not match the OpenAPI document: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// check validates the request against the operation of its route. The body is kept for the handler
+func (d *Document) check(r *http.Request) error {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return nil
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return nil
+	}
+	if count := r.URL.Query().Get("count"); count != "" {
+		if _, err := strconv.Atoi(count); err != nil {
+			return fmt.Errorf("count has to be a number")
+		}
+	}
+	body, ok := d.bodies[r.Method+" "+template]
+	if !ok || r.Body == nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return mediatype.Unmarshal(data, body(), mediatype.FromContentType(r.Header.Get("Content-Type")))
+}
@@ -2,7 +2,13 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
 )
 
 type executionError struct {
@@ -26,13 +32,34 @@ func SendError(w http.ResponseWriter, message string, code int) {
 	_, _ = w.Write(js)
 }
 
-// Send writes a json body to the response writter
-func Send(w http.ResponseWriter, value interface{}, code int) {
-	js, err := json.Marshal(value)
+// Send writes the value to the response writter, in the media type accepted by the request: JSON, YAML or protobuf.
+// JSON is sent when the request does not accept any of them or when the value cannot be encoded as protobuf
+func Send(w http.ResponseWriter, r *http.Request, value interface{}, code int) {
+	mediaType := mediatype.Negotiate(r.Header.Get("Accept"))
+	body, err := mediatype.Marshal(value, mediaType)
+	if errors.Is(err, mediatype.ErrNotProtobuf) {
+		mediaType = mediatype.JSON
+		body, err = mediatype.Marshal(value, mediaType)
+	}
 	if err != nil {
-		SendError(w, err.Error(), 400)
+		SendError(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Add("Vary", "Accept")
 	w.WriteHeader(code)
-	_, _ = w.Write(js)
+	_, _ = w.Write(body)
+}
+
+// SendFile writes the content of a file as an attachment. The content type is deduced from the extension of the file name
+func SendFile(w http.ResponseWriter, name string, content []byte) {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content)
 }
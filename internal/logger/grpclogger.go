@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCLogging wraps the gRPC calls to perform logging when calls are made
+func GRPCLogging(logger Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = status.Error(codes.Internal, "internal server error")
+				stack := string(debug.Stack())
+				logger.Errorw(
+					"error occurred",
+					"err", r,
+					"trace", stack,
+				)
+			}
+		}()
+
+		resp, err = handler(ctx, req)
+		logger.Debugw(
+			"call received",
+			"code", status.Code(err).String(),
+			"method", info.FullMethod,
+		)
+		return resp, err
+	}
+}
@@ -0,0 +1,244 @@
+package mediatype
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// JSON is the default media type. Protobuf messages are encoded with protojson, so enums are sent by name
+	JSON = "application/json"
+	// Protobuf is the binary encoding of protobuf messages
+	Protobuf = "application/x-protobuf"
+	// YAML has the same fields as JSON
+	YAML = "application/yaml"
+)
+
+// aliases are the other names the clients use for the supported media types
+var aliases = map[string]string{
+	JSON:                   JSON,
+	Protobuf:               Protobuf,
+	"application/protobuf": Protobuf,
+	YAML:                   YAML,
+	"application/x-yaml":   YAML,
+	"text/yaml":            YAML,
+	"text/x-yaml":          YAML,
+	"*/*":                  JSON,
+	"application/*":        JSON,
+}
+
+// ErrNotProtobuf is returned when a value that is not a protobuf message is encoded or decoded as protobuf
+var ErrNotProtobuf = errors.New("value is not a protobuf message")
+
+// ProtoConverter is implemented by the values that are not protobuf messages, but have a protobuf representation, ie. lists of items
+type ProtoConverter interface {
+	ToProto() (proto.Message, error)
+}
+
+// FromContentType returns the media type of a Content-Type header. JSON is used when the header is missing or not supported
+func FromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return JSON
+	}
+	if supported, ok := aliases[mediaType]; ok {
+		return supported
+	}
+	return JSON
+}
+
+// Negotiate returns the supported media type preferred by an Accept header. JSON is used when the header is missing or none of its media types is supported
+func Negotiate(accept string) string {
+	type candidate struct {
+		mediaType string
+		quality   float64
+	}
+	candidates := []candidate{}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		supported, ok := aliases[mediaType]
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{mediaType: supported, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return JSON
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].mediaType
+}
+
+// Marshal encodes the value in the media type
+func Marshal(value interface{}, mediaType string) ([]byte, error) {
+	switch mediaType {
+	case Protobuf:
+		switch v := value.(type) {
+		case proto.Message:
+			return proto.Marshal(v)
+		case ProtoConverter:
+			message, err := v.ToProto()
+			if err != nil {
+				return nil, err
+			}
+			return proto.Marshal(message)
+		}
+		return nil, ErrNotProtobuf
+	case YAML:
+		data, err := MarshalJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		return jsonToYAML(data)
+	}
+	return MarshalJSON(value)
+}
+
+// MarshalJSON encodes the value as JSON. Protobuf messages, on their own or in a slice, are encoded with protojson
+func MarshalJSON(value interface{}) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		// protojson varies the spacing of its output on purpose, compacting it keeps the responses stable
+		compacted := &bytes.Buffer{}
+		if err := json.Compact(compacted, data); err != nil {
+			return nil, err
+		}
+		return compacted.Bytes(), nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice && !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]json.RawMessage, v.Len())
+		for i := range items {
+			data, err := MarshalJSON(v.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			items[i] = data
+		}
+		return json.Marshal(items)
+	}
+	return json.Marshal(value)
+}
+
+// Unmarshal decodes the data, encoded in the media type, into the item. Unknown fields are rejected
+func Unmarshal(data []byte, item interface{}, mediaType string) error {
+	switch mediaType {
+	case Protobuf:
+		message, ok := item.(proto.Message)
+		if !ok {
+			return ErrNotProtobuf
+		}
+		return proto.Unmarshal(data, message)
+	case YAML:
+		converted, err := yamlToJSON(data)
+		if err != nil {
+			return err
+		}
+		data = converted
+	}
+	if message, ok := item.(proto.Message); ok {
+		return protojson.Unmarshal(data, message)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(item)
+}
+
+// jsonToYAML converts JSON to YAML, keeping the order of the fields
+func jsonToYAML(data []byte) ([]byte, error) {
+	value, err := decodeOrdered(json.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+// decodeOrdered decodes the next JSON value, with the objects as MapSlices so that the fields keep their order
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// yamlToJSON converts YAML to JSON, so that it can be decoded into the API types
+func yamlToJSON(data []byte) ([]byte, error) {
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(stringKeys(generic))
+}
+
+// stringKeys converts the maps decoded from YAML, which can have any key, into maps with string keys
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = stringKeys(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stringKeys(item)
+		}
+	}
+	return value
+}
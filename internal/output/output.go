@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+)
+
+const (
+	// Table prints the items as a table with a line per item
+	Table = "table"
+	// JSON prints the items as indented JSON, as sent by the server
+	JSON = "json"
+	// YAML prints the items as YAML, with the same fields as the JSON
+	YAML = "yaml"
+)
+
+// Flags are the command line flags used to choose how the items are printed
+type Flags struct {
+	Format string
+}
+
+// Register adds the flags to the command
+func (f *Flags) Register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Format, "format", Table, "format in which the items are printed, table, json or yaml")
+}
+
+// Validate checks that the format is known
+func (f *Flags) Validate() error {
+	switch f.Format {
+	case Table, JSON, YAML:
+		return nil
+	}
+	return fmt.Errorf("format has to be table, json or yaml")
+}
+
+// Columns describes a table. Row returns the cells of the item at an index
+type Columns struct {
+	Headers []string
+	Rows    int
+	Row     func(i int) []string
+}
+
+// Print writes value, an item or a list of items, in the format of the flags. The columns are used for the table format
+func (f *Flags) Print(w io.Writer, value interface{}, columns Columns) error {
+	switch f.Format {
+	case JSON:
+		data, err := mediatype.Marshal(value, mediatype.JSON)
+		if err != nil {
+			return err
+		}
+		indented := &bytes.Buffer{}
+		if err := json.Indent(indented, data, "", "  "); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, indented.String())
+		return err
+	case YAML:
+		data, err := mediatype.Marshal(value, mediatype.YAML)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns.Headers, "\t"))
+	for i := 0; i < columns.Rows; i++ {
+		fmt.Fprintln(tw, strings.Join(columns.Row(i), "\t"))
+	}
+	return tw.Flush()
+}
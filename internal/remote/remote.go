@@ -0,0 +1,71 @@
+package remote
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/pkg/client"
+)
+
+// PasswordEnv is the environment variable holding the password when it is not read from stdin
+const PasswordEnv = "SCRATCH_POST_PASSWORD"
+
+// Flags are the command line flags used to connect to a server.
+// The password is not a flag, so that it does not show in the process list nor in the shell history: it is read from stdin or from PasswordEnv
+type Flags struct {
+	Server        string
+	Username      string
+	PasswordStdin bool
+	cmd           *cobra.Command
+}
+
+// Register adds the flags to the command
+func (f *Flags) Register(cmd *cobra.Command) {
+	f.cmd = cmd
+	cmd.Flags().StringVar(&f.Server, "server", "http://localhost:9090/api/v1", "URL of the scratch-post API, including the root prefix")
+	cmd.Flags().StringVar(&f.Username, "username", "", "user used to log in")
+	cmd.Flags().BoolVar(&f.PasswordStdin, "password-stdin", false, "read the password of the user from the first line of stdin instead of the "+PasswordEnv+" environment variable")
+}
+
+// Login authenticates the user of the flags on the server and returns a client which uses the received token
+func (f *Flags) Login(ctx context.Context) (*client.Client, error) {
+	password, err := f.password()
+	if err != nil {
+		return nil, err
+	}
+	c := client.New(f.Server)
+	if err := c.Login(ctx, f.Username, password); err != nil {
+		return nil, fmt.Errorf("could not log in as '%s': %w", f.Username, err)
+	}
+	return c, nil
+}
+
+// password returns the first line of stdin when PasswordStdin is set, otherwise the value of PasswordEnv
+func (f *Flags) password() (string, error) {
+	if !f.PasswordStdin {
+		password, ok := os.LookupEnv(PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("the password is read from stdin with --password-stdin or from the %s environment variable", PasswordEnv)
+		}
+		return password, nil
+	}
+	in := io.Reader(os.Stdin)
+	if f.cmd != nil {
+		in = f.cmd.InOrStdin()
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("could not read the password from stdin: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("no password was read from stdin")
+	}
+	return password, nil
+}
@@ -0,0 +1,74 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/internal/remote"
+)
+
+func TestConfig_Save(t *testing.T) {
+	g := NewWithT(t)
+	config := &remote.Config{File: filepath.Join(t.TempDir(), "scratch-post", "config.json")}
+	g.Expect(os.MkdirAll(filepath.Dir(config.File), 0700)).To(Succeed())
+	g.Expect(os.WriteFile(config.File, []byte("{}"), 0644)).To(Succeed())
+
+	g.Expect(config.Save(&remote.Session{Server: "http://localhost:9090/api/v1", Username: "tester", Token: "token"})).To(Succeed())
+	info, err := os.Stat(config.File)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)), "existing config file kept its permissions")
+	session, err := config.Load()
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(session.Token).To(Equal("token"))
+	entries, err := os.ReadDir(filepath.Dir(config.File))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(entries).To(HaveLen(1), "temporary file was left behind")
+}
+
+// passwordServer returns a server that accepts the logins of tester with the password
+func passwordServer(t *testing.T, password string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credentials := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil || credentials["Password"] != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "auth-token", Value: "token"})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFlags_Login(t *testing.T) {
+	g := NewWithT(t)
+	server := passwordServer(t, "secret")
+	ctx := context.Background()
+
+	cmd := &cobra.Command{}
+	flags := &remote.Flags{}
+	flags.Register(cmd)
+	g.Expect(cmd.Flags().Parse([]string{"--server", server.URL, "--username", "tester", "--password-stdin"})).To(Succeed())
+	cmd.SetIn(strings.NewReader("secret\n"))
+	_, err := flags.Login(ctx)
+	g.Expect(err).ShouldNot(HaveOccurred(), "password was not read from stdin")
+
+	cmd.SetIn(strings.NewReader(""))
+	_, err = flags.Login(ctx)
+	g.Expect(err).To(MatchError("no password was read from stdin"))
+
+	flags.PasswordStdin = false
+	t.Setenv(remote.PasswordEnv, "secret")
+	_, err = flags.Login(ctx)
+	g.Expect(err).ShouldNot(HaveOccurred(), "password was not read from the environment")
+
+	g.Expect(cmd.Flags().Lookup("password")).To(BeNil(), "password can be given on the command line")
+}
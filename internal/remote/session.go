@@ -0,0 +1,100 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/curious-kitten/scratch-post/pkg/client"
+)
+
+// Session is the server and the token received when logging in, kept in the config file between commands
+type Session struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Token    string `json:"token"`
+}
+
+// Config is the command line flag of the file in which the session is kept
+type Config struct {
+	File string
+}
+
+// Register adds the flag to the command
+func (c *Config) Register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.File, "config", defaultConfigFile(), "file in which the session is kept after logging in")
+}
+
+// defaultConfigFile is the config file in the config directory of the user, ie. ~/.config/scratch-post/config.json
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "scratch-post.json"
+	}
+	return filepath.Join(dir, "scratch-post", "config.json")
+}
+
+// Save writes the session to the config file. The file is only readable by the user since it holds the token:
+// the session is written to a new file, which replaces the config file, so that an existing file does not keep its permissions
+func (c *Config) Save(session *Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(c.File)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	// CreateTemp creates the file with the 0600 permissions
+	file, err := os.CreateTemp(dir, filepath.Base(c.File)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), c.File)
+}
+
+// Load reads the session from the config file
+func (c *Config) Load() (*Session, error) {
+	data, err := os.ReadFile(c.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("not logged in, use `scratch-post login` first")
+	}
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("invalid config file '%s': %w", c.File, err)
+	}
+	return session, nil
+}
+
+// Remove deletes the config file
+func (c *Config) Remove() error {
+	if err := os.Remove(c.File); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Client returns a client which uses the session of the config file
+func (c *Config) Client() (*client.Client, error) {
+	session, err := c.Load()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(session.Server, client.WithToken(session.Token)), nil
+}
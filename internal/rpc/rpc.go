@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+	"github.com/curious-kitten/scratch-post/internal/store"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+)
+
+type create func(ctx context.Context, author string, body io.Reader) (interface{}, error)
+type list func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type get func(ctx context.Context, id string) (interface{}, error)
+type updateItem func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error)
+type deleteItem func(ctx context.Context, id string) error
+type extractUserName func(ctx context.Context) (string, error)
+
+// timeout is the time the business functions have to handle a call, as for the HTTP requests
+const timeout = time.Second * 10
+
+var errInvalidItem = status.Error(codes.Internal, "invalid data structure in DB")
+
+// handleError converts the errors of the business functions to gRPC statuses, with the same categories as the HTTP errors
+func handleError(err error) error {
+	switch {
+	case store.IsNotFoundError(err):
+		return status.Error(codes.NotFound, "could not find requested item")
+	case decoder.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case store.IsDuplicateError(err):
+		return status.Error(codes.AlreadyExists, store.DuplicateMessage(err))
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// body encodes the message as the protobuf body read by the business functions
+func body(message proto.Message) (io.Reader, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return decoder.WithContentType(bytes.NewReader(data), mediatype.Protobuf), nil
+}
+
+func createItem(ctx context.Context, createFunc create, getUser extractUserName, item proto.Message) (interface{}, error) {
+	user, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := body(item)
+	if err != nil {
+		return nil, err
+	}
+	toctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	created, err := createFunc(toctx, user, data)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return created, nil
+}
+
+func listItems(ctx context.Context, listFunc list, req *servicev1.ListRequest) ([]interface{}, error) {
+	filter := make(map[string][]string, len(req.Filter))
+	for key, values := range req.Filter {
+		filter[key] = values.GetValues()
+	}
+	lastValue := ""
+	if req.SortBy != "" {
+		lastValue = req.LastValue
+	}
+	toctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	items, err := listFunc(toctx, filter, req.SortBy, req.Descending, int(req.Count), lastValue)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return items, nil
+}
+
+func getItem(ctx context.Context, getterFunc get, id string) (interface{}, error) {
+	toctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	item, err := getterFunc(toctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return item, nil
+}
+
+func updateWith(ctx context.Context, updateFunc updateItem, getUser extractUserName, id string, item proto.Message) (interface{}, error) {
+	user, err := getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := body(item)
+	if err != nil {
+		return nil, err
+	}
+	toctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	updated, err := updateFunc(toctx, user, id, data)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return updated, nil
+}
+
+func deleteWith(ctx context.Context, deleterFunc deleteItem, id string) (*servicev1.DeleteResponse, error) {
+	toctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := deleterFunc(toctx, id); err != nil {
+		return nil, handleError(err)
+	}
+	return &servicev1.DeleteResponse{}, nil
+}
@@ -0,0 +1,179 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/rpc"
+	"github.com/curious-kitten/scratch-post/internal/store"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+)
+
+func tester(ctx context.Context) (string, error) {
+	return "tester", nil
+}
+
+// projectFuncs returns the business functions of a project service whose calls all fail with the error
+func projectFuncs(err error) *rpc.Projects {
+	return rpc.NewProjects(
+		func(ctx context.Context, author string, body io.Reader) (interface{}, error) { return nil, err },
+		func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+			return nil, err
+		},
+		func(ctx context.Context, id string) (interface{}, error) { return nil, err },
+		func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error) {
+			return nil, err
+		},
+		func(ctx context.Context, id string) error { return err },
+		tester,
+	)
+}
+
+func TestProjects_Create(t *testing.T) {
+	g := NewWithT(t)
+	create := func(ctx context.Context, author string, body io.Reader) (interface{}, error) {
+		g.Expect(author).To(Equal("tester"), "author is not the user of the call")
+		project := &projectv1.Project{}
+		g.Expect(decoder.Decode(project, body)).To(Succeed(), "request is not the body of the business function")
+		project.Identity = &metadatav1.Identity{Id: "project", CreatedBy: author}
+		return project, nil
+	}
+	service := rpc.NewProjects(create, nil, nil, nil, nil, tester)
+	created, err := service.Create(context.Background(), &projectv1.Project{Name: "shop"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.Name).To(Equal("shop"))
+	g.Expect(created.Identity.Id).To(Equal("project"))
+}
+
+func TestProjects_CreateWithoutUser(t *testing.T) {
+	g := NewWithT(t)
+	noUser := func(ctx context.Context) (string, error) {
+		return "", status.Error(codes.Unauthenticated, "no user")
+	}
+	service := rpc.NewProjects(nil, nil, nil, nil, nil, noUser)
+	_, err := service.Create(context.Background(), &projectv1.Project{Name: "shop"})
+	g.Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+}
+
+func TestProjects_List(t *testing.T) {
+	g := NewWithT(t)
+	list := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{"name": {"shop", "bank"}}), "filter was not converted")
+		g.Expect(sortBy).To(Equal("name"))
+		g.Expect(reverse).To(BeTrue())
+		g.Expect(count).To(Equal(2))
+		g.Expect(previousLastValue).To(Equal("shop"))
+		return []interface{}{&projectv1.Project{Name: "shop"}, &projectv1.Project{Name: "bank"}}, nil
+	}
+	service := rpc.NewProjects(nil, list, nil, nil, nil, tester)
+	projects, err := service.List(context.Background(), &servicev1.ListRequest{
+		Filter:     map[string]*servicev1.Values{"name": {Values: []string{"shop", "bank"}}},
+		SortBy:     "name",
+		Descending: true,
+		Count:      2,
+		LastValue:  "shop",
+	})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(projects.Count).To(Equal(int32(2)))
+	g.Expect(projects.Items[1].Name).To(Equal("bank"))
+}
+
+func TestProjects_ListLastValueWithoutSort(t *testing.T) {
+	g := NewWithT(t)
+	list := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(previousLastValue).To(BeEmpty(), "last value is used without a sort field")
+		return []interface{}{}, nil
+	}
+	service := rpc.NewProjects(nil, list, nil, nil, nil, tester)
+	projects, err := service.List(context.Background(), &servicev1.ListRequest{LastValue: "shop"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(projects.Items).To(BeEmpty())
+}
+
+func TestProjects_UpdateAndDelete(t *testing.T) {
+	g := NewWithT(t)
+	update := func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error) {
+		project := &projectv1.Project{}
+		g.Expect(decoder.Decode(project, body)).To(Succeed())
+		project.Identity = &metadatav1.Identity{Id: id, UpdatedBy: author}
+		return project, nil
+	}
+	deleted := ""
+	deleteItem := func(ctx context.Context, id string) error {
+		deleted = id
+		return nil
+	}
+	service := rpc.NewProjects(nil, nil, nil, update, deleteItem, tester)
+	updated, err := service.Update(context.Background(), &servicev1.UpdateProjectRequest{Id: "project", Project: &projectv1.Project{Name: "shop"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.Identity.Id).To(Equal("project"), "ID of the request was not used")
+	g.Expect(updated.Identity.UpdatedBy).To(Equal("tester"))
+	_, err = service.Delete(context.Background(), &servicev1.DeleteRequest{Id: "project"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(deleted).To(Equal("project"))
+}
+
+func TestProjects_Errors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "E11000 duplicate key error"}}}
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: store.ErrNotFound, code: codes.NotFound},
+		{err: decoder.NewValidationError("invalid name"), code: codes.InvalidArgument},
+		{err: duplicate, code: codes.AlreadyExists},
+		{err: fmt.Errorf("connection lost"), code: codes.Internal},
+	} {
+		err, code := tc.err, tc.code
+		service := projectFuncs(err)
+		_, getErr := service.Get(ctx, &servicev1.GetRequest{Id: "project"})
+		g.Expect(status.Code(getErr)).To(Equal(code), "get error %v was not mapped", err)
+		_, createErr := service.Create(ctx, &projectv1.Project{Name: "shop"})
+		g.Expect(status.Code(createErr)).To(Equal(code), "create error %v was not mapped", err)
+		_, listErr := service.List(ctx, &servicev1.ListRequest{})
+		g.Expect(status.Code(listErr)).To(Equal(code), "list error %v was not mapped", err)
+		_, updateErr := service.Update(ctx, &servicev1.UpdateProjectRequest{Id: "project", Project: &projectv1.Project{Name: "shop"}})
+		g.Expect(status.Code(updateErr)).To(Equal(code), "update error %v was not mapped", err)
+		_, deleteErr := service.Delete(ctx, &servicev1.DeleteRequest{Id: "project"})
+		g.Expect(status.Code(deleteErr)).To(Equal(code), "delete error %v was not mapped", err)
+	}
+	_, err := projectFuncs(decoder.NewValidationError("invalid name")).Get(ctx, &servicev1.GetRequest{})
+	g.Expect(status.Convert(err).Message()).To(Equal("invalid name"), "validation message was not kept")
+}
+
+func TestProjects_InvalidItem(t *testing.T) {
+	g := NewWithT(t)
+	get := func(ctx context.Context, id string) (interface{}, error) {
+		return &testplanv1.TestPlan{}, nil
+	}
+	_, err := rpc.NewProjects(nil, nil, get, nil, nil, tester).Get(context.Background(), &servicev1.GetRequest{Id: "project"})
+	g.Expect(status.Code(err)).To(Equal(codes.Internal), "item of another type was returned")
+}
+
+func TestTestPlans_Run(t *testing.T) {
+	g := NewWithT(t)
+	run := func(ctx context.Context, author string, id string, body io.Reader) (interface{}, error) {
+		request := &executionv1.RunRequest{}
+		g.Expect(decoder.Decode(request, body)).To(Succeed())
+		g.Expect(id).To(Equal("plan"), "test plan of the request was not run")
+		return &executionv1.Run{Executions: []*executionv1.Execution{{ScenarioId: request.ScenarioIds[0]}}}, nil
+	}
+	service := rpc.NewTestPlans(nil, nil, nil, nil, nil, run, tester)
+	result, err := service.Run(context.Background(), &servicev1.RunTestPlanRequest{TestPlanId: "plan", Run: &executionv1.RunRequest{ScenarioIds: []string{"login"}}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Executions[0].ScenarioId).To(Equal("login"))
+}
@@ -0,0 +1,303 @@
+package rpc
+
+import (
+	"context"
+
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+)
+
+// Projects serves the projects through gRPC with the business functions used by the HTTP endpoints
+type Projects struct {
+	servicev1.UnimplementedProjectServiceServer
+	create  create
+	list    list
+	get     get
+	update  updateItem
+	delete  deleteItem
+	getUser extractUserName
+}
+
+// NewProjects is used to setup the project service
+func NewProjects(createFunc create, listFunc list, getterFunc get, updateFunc updateItem, deleterFunc deleteItem, getUser extractUserName) *Projects {
+	return &Projects{create: createFunc, list: listFunc, get: getterFunc, update: updateFunc, delete: deleterFunc, getUser: getUser}
+}
+
+func asProject(item interface{}) (*projectv1.Project, error) {
+	project, ok := item.(*projectv1.Project)
+	if !ok {
+		return nil, errInvalidItem
+	}
+	return project, nil
+}
+
+// Create creates a project
+func (p *Projects) Create(ctx context.Context, project *projectv1.Project) (*projectv1.Project, error) {
+	item, err := createItem(ctx, p.create, p.getUser, project)
+	if err != nil {
+		return nil, err
+	}
+	return asProject(item)
+}
+
+// Get returns the project with the ID
+func (p *Projects) Get(ctx context.Context, req *servicev1.GetRequest) (*projectv1.Project, error) {
+	item, err := getItem(ctx, p.get, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return asProject(item)
+}
+
+// List returns the projects selected by the request
+func (p *Projects) List(ctx context.Context, req *servicev1.ListRequest) (*servicev1.ProjectList, error) {
+	items, err := listItems(ctx, p.list, req)
+	if err != nil {
+		return nil, err
+	}
+	projects := &servicev1.ProjectList{Count: int32(len(items)), Items: make([]*projectv1.Project, len(items))}
+	for i, item := range items {
+		if projects.Items[i], err = asProject(item); err != nil {
+			return nil, err
+		}
+	}
+	return projects, nil
+}
+
+// Update replaces the project with the ID
+func (p *Projects) Update(ctx context.Context, req *servicev1.UpdateProjectRequest) (*projectv1.Project, error) {
+	item, err := updateWith(ctx, p.update, p.getUser, req.Id, req.Project)
+	if err != nil {
+		return nil, err
+	}
+	return asProject(item)
+}
+
+// Delete deletes the project with the ID
+func (p *Projects) Delete(ctx context.Context, req *servicev1.DeleteRequest) (*servicev1.DeleteResponse, error) {
+	return deleteWith(ctx, p.delete, req.Id)
+}
+
+// Scenarios serves the scenarios through gRPC with the business functions used by the HTTP endpoints
+type Scenarios struct {
+	servicev1.UnimplementedScenarioServiceServer
+	create  create
+	list    list
+	get     get
+	update  updateItem
+	delete  deleteItem
+	getUser extractUserName
+}
+
+// NewScenarios is used to setup the scenario service
+func NewScenarios(createFunc create, listFunc list, getterFunc get, updateFunc updateItem, deleterFunc deleteItem, getUser extractUserName) *Scenarios {
+	return &Scenarios{create: createFunc, list: listFunc, get: getterFunc, update: updateFunc, delete: deleterFunc, getUser: getUser}
+}
+
+func asScenario(item interface{}) (*scenariov1.Scenario, error) {
+	scenario, ok := item.(*scenariov1.Scenario)
+	if !ok {
+		return nil, errInvalidItem
+	}
+	return scenario, nil
+}
+
+// Create creates a scenario
+func (s *Scenarios) Create(ctx context.Context, scenario *scenariov1.Scenario) (*scenariov1.Scenario, error) {
+	item, err := createItem(ctx, s.create, s.getUser, scenario)
+	if err != nil {
+		return nil, err
+	}
+	return asScenario(item)
+}
+
+// Get returns the scenario with the ID
+func (s *Scenarios) Get(ctx context.Context, req *servicev1.GetRequest) (*scenariov1.Scenario, error) {
+	item, err := getItem(ctx, s.get, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return asScenario(item)
+}
+
+// List returns the scenarios selected by the request
+func (s *Scenarios) List(ctx context.Context, req *servicev1.ListRequest) (*servicev1.ScenarioList, error) {
+	items, err := listItems(ctx, s.list, req)
+	if err != nil {
+		return nil, err
+	}
+	scenarios := &servicev1.ScenarioList{Count: int32(len(items)), Items: make([]*scenariov1.Scenario, len(items))}
+	for i, item := range items {
+		if scenarios.Items[i], err = asScenario(item); err != nil {
+			return nil, err
+		}
+	}
+	return scenarios, nil
+}
+
+// Update replaces the scenario with the ID
+func (s *Scenarios) Update(ctx context.Context, req *servicev1.UpdateScenarioRequest) (*scenariov1.Scenario, error) {
+	item, err := updateWith(ctx, s.update, s.getUser, req.Id, req.Scenario)
+	if err != nil {
+		return nil, err
+	}
+	return asScenario(item)
+}
+
+// Delete deletes the scenario with the ID
+func (s *Scenarios) Delete(ctx context.Context, req *servicev1.DeleteRequest) (*servicev1.DeleteResponse, error) {
+	return deleteWith(ctx, s.delete, req.Id)
+}
+
+// TestPlans serves the test plans through gRPC with the business functions used by the HTTP endpoints
+type TestPlans struct {
+	servicev1.UnimplementedTestPlanServiceServer
+	create  create
+	list    list
+	get     get
+	update  updateItem
+	delete  deleteItem
+	run     updateItem
+	getUser extractUserName
+}
+
+// NewTestPlans is used to setup the test plan service
+func NewTestPlans(createFunc create, listFunc list, getterFunc get, updateFunc updateItem, deleterFunc deleteItem, runFunc updateItem, getUser extractUserName) *TestPlans {
+	return &TestPlans{create: createFunc, list: listFunc, get: getterFunc, update: updateFunc, delete: deleterFunc, run: runFunc, getUser: getUser}
+}
+
+func asTestPlan(item interface{}) (*testplanv1.TestPlan, error) {
+	testplan, ok := item.(*testplanv1.TestPlan)
+	if !ok {
+		return nil, errInvalidItem
+	}
+	return testplan, nil
+}
+
+// Create creates a test plan
+func (t *TestPlans) Create(ctx context.Context, testplan *testplanv1.TestPlan) (*testplanv1.TestPlan, error) {
+	item, err := createItem(ctx, t.create, t.getUser, testplan)
+	if err != nil {
+		return nil, err
+	}
+	return asTestPlan(item)
+}
+
+// Get returns the test plan with the ID
+func (t *TestPlans) Get(ctx context.Context, req *servicev1.GetRequest) (*testplanv1.TestPlan, error) {
+	item, err := getItem(ctx, t.get, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return asTestPlan(item)
+}
+
+// List returns the test plans selected by the request
+func (t *TestPlans) List(ctx context.Context, req *servicev1.ListRequest) (*servicev1.TestPlanList, error) {
+	items, err := listItems(ctx, t.list, req)
+	if err != nil {
+		return nil, err
+	}
+	testplans := &servicev1.TestPlanList{Count: int32(len(items)), Items: make([]*testplanv1.TestPlan, len(items))}
+	for i, item := range items {
+		if testplans.Items[i], err = asTestPlan(item); err != nil {
+			return nil, err
+		}
+	}
+	return testplans, nil
+}
+
+// Update replaces the test plan with the ID
+func (t *TestPlans) Update(ctx context.Context, req *servicev1.UpdateTestPlanRequest) (*testplanv1.TestPlan, error) {
+	item, err := updateWith(ctx, t.update, t.getUser, req.Id, req.TestPlan)
+	if err != nil {
+		return nil, err
+	}
+	return asTestPlan(item)
+}
+
+// Delete deletes the test plan with the ID
+func (t *TestPlans) Delete(ctx context.Context, req *servicev1.DeleteRequest) (*servicev1.DeleteResponse, error) {
+	return deleteWith(ctx, t.delete, req.Id)
+}
+
+// Run creates the executions of a run of the test plan
+func (t *TestPlans) Run(ctx context.Context, req *servicev1.RunTestPlanRequest) (*executionv1.Run, error) {
+	item, err := updateWith(ctx, t.run, t.getUser, req.TestPlanId, req.Run)
+	if err != nil {
+		return nil, err
+	}
+	run, ok := item.(*executionv1.Run)
+	if !ok {
+		return nil, errInvalidItem
+	}
+	return run, nil
+}
+
+// Executions serves the executions through gRPC with the business functions used by the HTTP endpoints
+type Executions struct {
+	servicev1.UnimplementedExecutionServiceServer
+	create  create
+	list    list
+	get     get
+	update  updateItem
+	getUser extractUserName
+}
+
+// NewExecutions is used to setup the execution service
+func NewExecutions(createFunc create, listFunc list, getterFunc get, updateFunc updateItem, getUser extractUserName) *Executions {
+	return &Executions{create: createFunc, list: listFunc, get: getterFunc, update: updateFunc, getUser: getUser}
+}
+
+func asExecution(item interface{}) (*executionv1.Execution, error) {
+	execution, ok := item.(*executionv1.Execution)
+	if !ok {
+		return nil, errInvalidItem
+	}
+	return execution, nil
+}
+
+// Create creates an execution
+func (e *Executions) Create(ctx context.Context, execution *executionv1.Execution) (*executionv1.Execution, error) {
+	item, err := createItem(ctx, e.create, e.getUser, execution)
+	if err != nil {
+		return nil, err
+	}
+	return asExecution(item)
+}
+
+// Get returns the execution with the ID
+func (e *Executions) Get(ctx context.Context, req *servicev1.GetRequest) (*executionv1.Execution, error) {
+	item, err := getItem(ctx, e.get, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return asExecution(item)
+}
+
+// List returns the executions selected by the request
+func (e *Executions) List(ctx context.Context, req *servicev1.ListRequest) (*servicev1.ExecutionList, error) {
+	items, err := listItems(ctx, e.list, req)
+	if err != nil {
+		return nil, err
+	}
+	executions := &servicev1.ExecutionList{Count: int32(len(items)), Items: make([]*executionv1.Execution, len(items))}
+	for i, item := range items {
+		if executions.Items[i], err = asExecution(item); err != nil {
+			return nil, err
+		}
+	}
+	return executions, nil
+}
+
+// Update replaces the execution with the ID
+func (e *Executions) Update(ctx context.Context, req *servicev1.UpdateExecutionRequest) (*executionv1.Execution, error) {
+	item, err := updateWith(ctx, e.update, e.getUser, req.Id, req.Execution)
+	if err != nil {
+		return nil, err
+	}
+	return asExecution(item)
+}
@@ -30,10 +30,14 @@ type Config struct {
 
 // Collections represent the various collections in the store
 type Collections struct {
-	Projects   string `json:"projects"`
-	Scenarios  string `json:"scenarios"`
-	TestPlans  string `json:"testplans"`
-	Executions string `json:"executions"`
+	Projects     string `json:"projects"`
+	Scenarios    string `json:"scenarios"`
+	TestPlans    string `json:"testplans"`
+	Executions   string `json:"executions"`
+	StepBlocks   string `json:"stepblocks"`
+	Folders      string `json:"folders"`
+	Requirements string `json:"requirements"`
+	Releases     string `json:"releases"`
 }
 
 // Validate that the config object is correct
@@ -69,6 +73,18 @@ func (c Collections) Validate() error {
 	if c.Executions == "" {
 		errs.add("executions field is mandatory")
 	}
+	if c.StepBlocks == "" {
+		errs.add("stepblocks field is mandatory")
+	}
+	if c.Folders == "" {
+		errs.add("folders field is mandatory")
+	}
+	if c.Requirements == "" {
+		errs.add("requirements field is mandatory")
+	}
+	if c.Releases == "" {
+		errs.add("releases field is mandatory")
+	}
 	if !errs.isEmpty() {
 		return errs
 	}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -31,10 +32,15 @@ func Collection(dbName, collectionName string, client *mongo.Client, constraints
 	}
 	if len(constraints) > 0 {
 		bsonConstraint := bson.D{}
-		for _, v := range constraints {
+		names := make([]string, len(constraints))
+		for i, v := range constraints {
 			bsonConstraint = append(bsonConstraint, bson.E{Key: v, Value: 1})
+			names[i] = v + "_1"
 		}
 		indexModel = append(indexModel, mongo.IndexModel{Keys: bsonConstraint, Options: options.Index().SetUnique(true)})
+		if err := dropMiscased(context.Background(), coll, strings.Join(names, "_")); err != nil {
+			return nil, err
+		}
 	}
 
 	_, err := coll.Indexes().CreateMany(
@@ -47,6 +53,56 @@ func Collection(dbName, collectionName string, client *mongo.Client, constraints
 	return &Data{coll: coll}, nil
 }
 
+// dropMiscased removes the unique indexes named as the index with other letter cases, ie. `projectId_1_name_1` for `projectid_1_name_1`.
+// The fields of the documents are lowercased, so such indexes were built on missing fields and make the values unique across the whole collection
+func dropMiscased(ctx context.Context, coll *mongo.Collection, name string) error {
+	indexes, err := coll.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index.Name != name && strings.EqualFold(index.Name, name) {
+			if _, err := coll.Indexes().DropOne(ctx, index.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// duplicateMessages holds the messages of the unique list indexes by index name, see UniqueList
+var duplicateMessages = map[string]string{}
+
+// UniqueList adds a unique index over the keys of the collection, the last of which holds a list: every value of the list is unique together with
+// the other keys, ie. an automation key within a project. The documents without values in the list are not indexed.
+// The message describes the duplicate errors raised by the index, see DuplicateMessage
+func (d *Data) UniqueList(ctx context.Context, keys []string, message string) error {
+	index := bson.D{}
+	for _, v := range keys {
+		index = append(index, bson.E{Key: v, Value: 1})
+	}
+	name := strings.Join(keys, "_") + "_unique"
+	duplicateMessages[name] = message
+	_, err := d.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: index,
+		Options: options.Index().
+			SetUnique(true).
+			SetName(name).
+			SetPartialFilterExpression(bson.M{keys[len(keys)-1]: bson.M{"$type": "string"}}),
+	})
+	return err
+}
+
+// SetMissing sets the key to the value in the documents of the collection that do not have the key, ie. the documents stored before the
+// key was added. The number of changed documents is returned
+func (d *Data) SetMissing(ctx context.Context, key string, value interface{}) (int64, error) {
+	result, err := d.coll.UpdateMany(ctx, bson.M{key: bson.M{"$exists": false}}, bson.M{"$set": bson.M{key: value}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
 // Data is used to manipulate the collections
 type Data struct {
 	coll *mongo.Collection
@@ -126,9 +182,12 @@ func (d *Data) Update(ctx context.Context, id string, item interface{}) error {
 	return err
 }
 
+// ErrNotFound is returned when an item could not be found
+var ErrNotFound = mongo.ErrNoDocuments
+
 // IsNotFoundError checks if an error is no ducument error
 func IsNotFoundError(err error) bool {
-	return err == mongo.ErrNoDocuments
+	return err == ErrNotFound
 }
 
 // IsDuplicateError checks if an error is a duplacte index error
@@ -143,6 +202,20 @@ func IsDuplicateError(err error) bool {
 	return false
 }
 
+// DuplicateMessage describes a duplicate index error: the message of the unique list index that raised it, or that the item already exists
+func DuplicateMessage(err error) string {
+	if we, ok := err.(mongo.WriteException); ok {
+		for _, e := range we.WriteErrors {
+			for name, message := range duplicateMessages {
+				if e.Code == 11000 && strings.Contains(e.Message, "index: "+name+" ") {
+					return message
+				}
+			}
+		}
+	}
+	return "item already exists"
+}
+
 func generateFilter(filter map[string][]string) []bson.M {
 	m := []bson.M{}
 	if len(filter) == 0 {
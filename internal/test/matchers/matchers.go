@@ -4,6 +4,10 @@ import (
 	"fmt"
 
 	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 )
 
 type ofType struct {
@@ -24,3 +28,38 @@ func OfType(item interface{}) gomock.Matcher {
 		t: fmt.Sprintf("%T", item),
 	}
 }
+
+type equalProto struct {
+	expected proto.Message
+}
+
+func (e *equalProto) Match(actual interface{}) (bool, error) {
+	message, ok := actual.(proto.Message)
+	if !ok {
+		return false, fmt.Errorf("EqualProto expects a protobuf message, got %T", actual)
+	}
+	return proto.Equal(message, e.expected), nil
+}
+
+func (e *equalProto) FailureMessage(actual interface{}) string {
+	return format.Message(text(actual), "to equal", text(e.expected))
+}
+
+func (e *equalProto) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(text(actual), "not to equal", text(e.expected))
+}
+
+// text shows the fields of a message instead of its internal state
+func text(value interface{}) interface{} {
+	if message, ok := value.(proto.Message); ok {
+		return prototext.Format(message)
+	}
+	return value
+}
+
+// EqualProto returns a gomega matcher which compares protobuf messages by their fields, ignoring their internal state
+func EqualProto(expected proto.Message) types.GomegaMatcher {
+	return &equalProto{
+		expected: expected,
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/curious-kitten/scratch-post/internal/http/response"
 )
 
+//go:generate mockgen -source ./endpoints.go -destination mocks/endpoints.go
+
 type checkPassword func(ctx context.Context, username, password string) error
 
 // LoginRequest is used to request access to the API
@@ -58,7 +60,7 @@ func NewEndpoints(ctx context.Context, isPasswordCorrect checkPassword, token Au
 
 func (e *Endpoints) login(w http.ResponseWriter, r *http.Request) {
 	user := &LoginRequest{}
-	if err := decoder.Decode(user, r.Body); err != nil {
+	if err := decoder.Decode(user, decoder.WithContentType(r.Body, r.Header.Get("Content-Type"))); err != nil {
 		response.SendError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -95,10 +97,11 @@ func (e *Endpoints) logout(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Register is used to attach the authorization endpoints to a given mux router
-func (e *Endpoints) Register(r *mux.Router) {
-	r.HandleFunc("/login", e.login).Methods(http.MethodPost)
-	r.HandleFunc("/logout", e.logout).Methods(http.MethodPost)
+// Register is used to attach the authorization endpoints to a given mux router. It returns the registered routes
+func (e *Endpoints) Register(r *mux.Router) (login *mux.Route, logout *mux.Route) {
+	login = r.HandleFunc("/login", e.login).Methods(http.MethodPost)
+	logout = r.HandleFunc("/logout", e.logout).Methods(http.MethodPost)
+	return login, logout
 }
 
 // SetAuthCookie add an auth cookie to the response writer
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+)
+
+// authorizationMetadata is the gRPC metadata which holds the token, as `Bearer {token}`
+const authorizationMetadata = "authorization"
+
+type userIDContextKey struct{}
+
+// PublicMethods are the gRPC methods that are called without a token
+var PublicMethods = []string{
+	"/service.scratchpost.curiouskitten.AuthService/Login",
+	"/service.scratchpost.curiouskitten.AuthService/Logout",
+}
+
+// UnaryAuthorization verifies a gRPC call has a valid token associated, as Authorization does for HTTP requests.
+// The calls to the public methods, ie. PublicMethods, are not verified
+func UnaryAuthorization(authorizer Authorizer, publicMethods ...string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		token, err := GetTokenFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valid, username, err := authorizer.Validate(token)
+		if err != nil {
+			if err == jwt.ErrSignatureInvalid {
+				return nil, status.Error(codes.Unauthenticated, "invalid token")
+			}
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if !valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ContextWithUserID(ctx, username), req)
+	}
+}
+
+// GetTokenFromContext extracts the auth token from the metadata of a gRPC call
+func GetTokenFromContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadata)
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	if token == "" || token == values[0] {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata has to be `Bearer {token}`")
+	}
+	return token, nil
+}
+
+// ContextWithUserID adds the user ID to the context of a gRPC call
+func ContextWithUserID(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, username)
+}
+
+// GetUserIDFromContext returns the user ID from the context of a gRPC call
+func GetUserIDFromContext(ctx context.Context) (string, error) {
+	id, _ := ctx.Value(userIDContextKey{}).(string)
+	if id == "" {
+		return "", status.Error(codes.Unauthenticated, "request is missing user ID")
+	}
+	return id, nil
+}
+
+// Service serves the login and the logout through gRPC
+type Service struct {
+	servicev1.UnimplementedAuthServiceServer
+	token             Authorizer
+	isPasswordCorrect checkPassword
+}
+
+// NewService is used to setup the authorization service
+func NewService(isPasswordCorrect checkPassword, token Authorizer) *Service {
+	return &Service{
+		token:             token,
+		isPasswordCorrect: isPasswordCorrect,
+	}
+}
+
+// Login returns a token for the user
+func (s *Service) Login(ctx context.Context, req *servicev1.LoginRequest) (*servicev1.LoginResponse, error) {
+	user := &LoginRequest{Username: req.Username, Password: req.Password}
+	if err := user.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.isPasswordCorrect(ctx, user.Username, user.Password); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	tokenString, expirationTime, err := s.token.GenerateSecurityString(user.Username)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return &servicev1.LoginResponse{Token: tokenString, ExpirationTime: expirationTime.Unix()}, nil
+}
+
+// Logout invalidates the token of the call
+func (s *Service) Logout(ctx context.Context, req *servicev1.LogoutRequest) (*servicev1.LogoutResponse, error) {
+	token, err := GetTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.token.Invalidate(token); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &servicev1.LogoutResponse{}, nil
+}
@@ -0,0 +1,98 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/curious-kitten/scratch-post/pkg/administration/users/auth"
+	mock_auth "github.com/curious-kitten/scratch-post/pkg/administration/users/auth/mocks"
+	servicev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/service"
+)
+
+func withToken(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestUnaryAuthorization(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	authorizer := mock_auth.NewMockAuthorizer(ctrl)
+	authorizer.EXPECT().Validate("valid").Return(true, "tester", nil).AnyTimes()
+	authorizer.EXPECT().Validate("expired").Return(false, "", nil).AnyTimes()
+	authorizer.EXPECT().Validate("forged").Return(false, "", jwt.ErrSignatureInvalid).AnyTimes()
+	authorizer.EXPECT().Validate("malformed").Return(false, "", fmt.Errorf("token is malformed")).AnyTimes()
+
+	interceptor := auth.UnaryAuthorization(authorizer, auth.PublicMethods...)
+	info := &grpc.UnaryServerInfo{FullMethod: "/service.scratchpost.curiouskitten.ProjectService/Get"}
+	user := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return auth.GetUserIDFromContext(ctx)
+	}
+
+	resp, err := interceptor(withToken("Bearer valid"), nil, info, user)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(resp).To(Equal("tester"), "user was not added to the context")
+
+	tests := map[string]struct {
+		ctx  context.Context
+		code codes.Code
+	}{
+		"missing token":   {ctx: context.Background(), code: codes.Unauthenticated},
+		"not a bearer":    {ctx: withToken("valid"), code: codes.Unauthenticated},
+		"expired token":   {ctx: withToken("Bearer expired"), code: codes.Unauthenticated},
+		"forged token":    {ctx: withToken("Bearer forged"), code: codes.Unauthenticated},
+		"malformed token": {ctx: withToken("Bearer malformed"), code: codes.InvalidArgument},
+	}
+	for name, test := range tests {
+		_, err := interceptor(test.ctx, nil, info, user)
+		g.Expect(status.Code(err)).To(Equal(test.code), name)
+	}
+
+	login := &grpc.UnaryServerInfo{FullMethod: "/service.scratchpost.curiouskitten.AuthService/Login"}
+	_, err = interceptor(context.Background(), nil, login, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	g.Expect(err).ShouldNot(HaveOccurred(), "public method was verified")
+}
+
+func TestService(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	authorizer := mock_auth.NewMockAuthorizer(ctrl)
+	isPasswordCorrect := func(ctx context.Context, username, password string) error {
+		if password != "secret" {
+			return fmt.Errorf("incorrect password")
+		}
+		return nil
+	}
+	service := auth.NewService(isPasswordCorrect, authorizer)
+	expiration := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	authorizer.EXPECT().GenerateSecurityString("tester").Return("token", expiration, nil).Times(1)
+	authorizer.EXPECT().Invalidate("token").Return(nil).Times(1)
+
+	resp, err := service.Login(context.Background(), &servicev1.LoginRequest{Username: "tester", Password: "secret"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(resp.Token).To(Equal("token"))
+	g.Expect(resp.ExpirationTime).To(Equal(expiration.Unix()))
+
+	_, err = service.Login(context.Background(), &servicev1.LoginRequest{Username: "tester", Password: "wrong"})
+	g.Expect(status.Code(err)).To(Equal(codes.Unauthenticated), "wrong password was accepted")
+	_, err = service.Login(context.Background(), &servicev1.LoginRequest{Username: "tester"})
+	g.Expect(status.Code(err)).To(Equal(codes.InvalidArgument), "missing password was accepted")
+
+	_, err = service.Logout(withToken("Bearer token"), &servicev1.LogoutRequest{})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	_, err = service.Logout(context.Background(), &servicev1.LogoutRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unauthenticated), "logout without token was accepted")
+}
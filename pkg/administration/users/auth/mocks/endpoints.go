@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./endpoints.go
+
+// Package mock_auth is a generated GoMock package.
+package mock_auth
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorizer is a mock of Authorizer interface.
+type MockAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizerMockRecorder
+}
+
+// MockAuthorizerMockRecorder is the mock recorder for MockAuthorizer.
+type MockAuthorizerMockRecorder struct {
+	mock *MockAuthorizer
+}
+
+// NewMockAuthorizer creates a new mock instance.
+func NewMockAuthorizer(ctrl *gomock.Controller) *MockAuthorizer {
+	mock := &MockAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizer) EXPECT() *MockAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// Cleanup mocks base method.
+func (m *MockAuthorizer) Cleanup(cleanInterval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Cleanup", cleanInterval)
+}
+
+// Cleanup indicates an expected call of Cleanup.
+func (mr *MockAuthorizerMockRecorder) Cleanup(cleanInterval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockAuthorizer)(nil).Cleanup), cleanInterval)
+}
+
+// GenerateSecurityString mocks base method.
+func (m *MockAuthorizer) GenerateSecurityString(username string) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecurityString", username)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateSecurityString indicates an expected call of GenerateSecurityString.
+func (mr *MockAuthorizerMockRecorder) GenerateSecurityString(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecurityString", reflect.TypeOf((*MockAuthorizer)(nil).GenerateSecurityString), username)
+}
+
+// Invalidate mocks base method.
+func (m *MockAuthorizer) Invalidate(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockAuthorizerMockRecorder) Invalidate(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockAuthorizer)(nil).Invalidate), token)
+}
+
+// Validate mocks base method.
+func (m *MockAuthorizer) Validate(token string) (bool, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockAuthorizerMockRecorder) Validate(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockAuthorizer)(nil).Validate), token)
+}
@@ -0,0 +1,525 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.6.1
+// source: customfield.proto
+
+package customfield
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Type of the values a custom field accepts
+type FieldType int32
+
+const (
+	// free text
+	FieldType_Text FieldType = 0
+	// a decimal number
+	FieldType_Number FieldType = 1
+	// a single value out of the field options
+	FieldType_Enum FieldType = 2
+	// any number of values out of the field options
+	FieldType_MultiSelect FieldType = 3
+	// a date in the YYYY-MM-DD format
+	FieldType_Date FieldType = 4
+	// the name of a user
+	FieldType_User FieldType = 5
+)
+
+// Enum value maps for FieldType.
+var (
+	FieldType_name = map[int32]string{
+		0: "Text",
+		1: "Number",
+		2: "Enum",
+		3: "MultiSelect",
+		4: "Date",
+		5: "User",
+	}
+	FieldType_value = map[string]int32{
+		"Text":        0,
+		"Number":      1,
+		"Enum":        2,
+		"MultiSelect": 3,
+		"Date":        4,
+		"User":        5,
+	}
+)
+
+func (x FieldType) Enum() *FieldType {
+	p := new(FieldType)
+	*p = x
+	return p
+}
+
+func (x FieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_customfield_proto_enumTypes[0].Descriptor()
+}
+
+func (FieldType) Type() protoreflect.EnumType {
+	return &file_customfield_proto_enumTypes[0]
+}
+
+func (x FieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldType.Descriptor instead.
+func (FieldType) EnumDescriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{0}
+}
+
+// Defines a custom field that can be set on the items of a project.
+// The field is referenced by its `name` in the `customFields` of the items.
+type Definition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the field. It is used as a key, so it cannot contain `.` or start with `$`. MANDATORY
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Description is used to add detailed information
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Type of the values the field accepts. Defaults to Text
+	Type FieldType `protobuf:"varint,3,opt,name=type,proto3,enum=customfield.scratchpost.curiouskitten.FieldType" json:"type,omitempty"`
+	// Whether a value has to be set for the field
+	Required bool `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	// Values that can be chosen for Enum and MultiSelect fields
+	Options []string `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	// Values used when the field is not set
+	DefaultValues []string `protobuf:"bytes,6,rep,name=defaultValues,proto3" json:"defaultValues,omitempty"`
+}
+
+func (x *Definition) Reset() {
+	*x = Definition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_customfield_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Definition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Definition) ProtoMessage() {}
+
+func (x *Definition) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Definition.ProtoReflect.Descriptor instead.
+func (*Definition) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Definition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Definition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Definition) GetType() FieldType {
+	if x != nil {
+		return x.Type
+	}
+	return FieldType_Text
+}
+
+func (x *Definition) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Definition) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Definition) GetDefaultValues() []string {
+	if x != nil {
+		return x.DefaultValues
+	}
+	return nil
+}
+
+// Value of a custom field. Fields that are not MultiSelect have exactly one value
+type Value struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_customfield_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Value) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Number of items that have a given value set for a custom field
+type FieldCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the custom field
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Value of the custom field
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Number of items that have the value set
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FieldCount) Reset() {
+	*x = FieldCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_customfield_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldCount) ProtoMessage() {}
+
+func (x *FieldCount) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldCount.ProtoReflect.Descriptor instead.
+func (*FieldCount) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldCount) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FieldCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Counts of the custom field values used by the items of a project
+type Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the project
+	ProjectId string `protobuf:"bytes,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	// Custom field values used by the scenarios of the project
+	Scenarios []*FieldCount `protobuf:"bytes,2,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	// Custom field values used by the executions of the project
+	Executions []*FieldCount `protobuf:"bytes,3,rep,name=executions,proto3" json:"executions,omitempty"`
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_customfield_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Summary) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Summary) GetScenarios() []*FieldCount {
+	if x != nil {
+		return x.Scenarios
+	}
+	return nil
+}
+
+func (x *Summary) GetExecutions() []*FieldCount {
+	if x != nil {
+		return x.Executions
+	}
+	return nil
+}
+
+var File_customfield_proto protoreflect.FileDescriptor
+
+var file_customfield_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x25, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72,
+	0x69, 0x6f, 0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x22, 0xe4, 0x01, 0x0a, 0x0a, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x44, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x73, 0x63, 0x72, 0x61,
+	0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x6b,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x22, 0x1f, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x22, 0x4e, 0x0a, 0x0a, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x07, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x4f, 0x0a, 0x09,
+	0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x73, 0x63,
+	0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75,
+	0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x09, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x12, 0x51, 0x0a,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x31, 0x2e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e,
+	0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69,
+	0x6f, 0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2a, 0x50, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a,
+	0x04, 0x54, 0x65, 0x78, 0x74, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x10, 0x02, 0x12, 0x0f, 0x0a,
+	0x0b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x10, 0x03, 0x12, 0x08,
+	0x0a, 0x04, 0x44, 0x61, 0x74, 0x65, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72,
+	0x10, 0x05, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x2d, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2f,
+	0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x70, 0x6f, 0x73, 0x74, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_customfield_proto_rawDescOnce sync.Once
+	file_customfield_proto_rawDescData = file_customfield_proto_rawDesc
+)
+
+func file_customfield_proto_rawDescGZIP() []byte {
+	file_customfield_proto_rawDescOnce.Do(func() {
+		file_customfield_proto_rawDescData = protoimpl.X.CompressGZIP(file_customfield_proto_rawDescData)
+	})
+	return file_customfield_proto_rawDescData
+}
+
+var file_customfield_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_customfield_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_customfield_proto_goTypes = []interface{}{
+	(FieldType)(0),     // 0: customfield.scratchpost.curiouskitten.FieldType
+	(*Definition)(nil), // 1: customfield.scratchpost.curiouskitten.Definition
+	(*Value)(nil),      // 2: customfield.scratchpost.curiouskitten.Value
+	(*FieldCount)(nil), // 3: customfield.scratchpost.curiouskitten.FieldCount
+	(*Summary)(nil),    // 4: customfield.scratchpost.curiouskitten.Summary
+}
+var file_customfield_proto_depIdxs = []int32{
+	0, // 0: customfield.scratchpost.curiouskitten.Definition.type:type_name -> customfield.scratchpost.curiouskitten.FieldType
+	3, // 1: customfield.scratchpost.curiouskitten.Summary.scenarios:type_name -> customfield.scratchpost.curiouskitten.FieldCount
+	3, // 2: customfield.scratchpost.curiouskitten.Summary.executions:type_name -> customfield.scratchpost.curiouskitten.FieldCount
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_customfield_proto_init() }
+func file_customfield_proto_init() {
+	if File_customfield_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_customfield_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Definition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_customfield_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_customfield_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_customfield_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_customfield_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_customfield_proto_goTypes,
+		DependencyIndexes: file_customfield_proto_depIdxs,
+		EnumInfos:         file_customfield_proto_enumTypes,
+		MessageInfos:      file_customfield_proto_msgTypes,
+	}.Build()
+	File_customfield_proto = out.File
+	file_customfield_proto_rawDesc = nil
+	file_customfield_proto_goTypes = nil
+	file_customfield_proto_depIdxs = nil
+}
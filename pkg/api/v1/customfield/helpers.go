@@ -0,0 +1,162 @@
+package customfield
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+)
+
+// DateLayout is the format used for the values of Date fields
+const DateLayout = "2006-01-02"
+
+// Validate is used to check the integrity of the custom field definition
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return decoder.NewValidationError("name is a mandatory parameter for custom fields")
+	}
+	if strings.Contains(d.Name, ".") || strings.HasPrefix(d.Name, "$") {
+		return decoder.NewValidationError(fmt.Sprintf("custom field name '%s' cannot contain '.' or start with '$'", d.Name))
+	}
+	if _, ok := FieldType_name[int32(d.Type)]; !ok {
+		return decoder.NewValidationError(fmt.Sprintf("custom field '%s' has an unknown type", d.Name))
+	}
+	switch d.Type {
+	case FieldType_Enum, FieldType_MultiSelect:
+		if len(d.Options) == 0 {
+			return decoder.NewValidationError(fmt.Sprintf("custom field '%s' needs at least one option", d.Name))
+		}
+	default:
+		if len(d.Options) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("only Enum and MultiSelect fields can have options, '%s' is %s", d.Name, d.Type))
+		}
+	}
+	if len(d.DefaultValues) != 0 {
+		if err := d.check(d.DefaultValues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateDefinitions checks every definition in the list and that field names are unique
+func ValidateDefinitions(definitions []*Definition) error {
+	names := map[string]bool{}
+	for _, d := range definitions {
+		if err := d.Validate(); err != nil {
+			return err
+		}
+		if names[d.Name] {
+			return decoder.NewValidationError(fmt.Sprintf("custom field '%s' is defined multiple times", d.Name))
+		}
+		names[d.Name] = true
+	}
+	return nil
+}
+
+// Apply checks the values against the definitions and returns the values that should be stored.
+// Fields that are not set receive the default values of their definition.
+func Apply(definitions []*Definition, values map[string]*Value) (map[string]*Value, error) {
+	byName := make(map[string]*Definition, len(definitions))
+	for _, d := range definitions {
+		byName[d.Name] = d
+	}
+	for name := range values {
+		if _, ok := byName[name]; !ok {
+			return nil, decoder.NewValidationError(fmt.Sprintf("custom field '%s' is not defined by the project", name))
+		}
+	}
+	result := map[string]*Value{}
+	for _, d := range definitions {
+		v, ok := values[d.Name]
+		if !ok || v == nil || len(v.Values) == 0 {
+			if len(d.DefaultValues) != 0 {
+				result[d.Name] = &Value{Values: append([]string{}, d.DefaultValues...)}
+				continue
+			}
+			if d.Required {
+				return nil, decoder.NewValidationError(fmt.Sprintf("custom field '%s' is mandatory", d.Name))
+			}
+			continue
+		}
+		if err := d.check(v.Values); err != nil {
+			return nil, err
+		}
+		result[d.Name] = v
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+func (d *Definition) check(values []string) error {
+	if d.Type != FieldType_MultiSelect && len(values) != 1 {
+		return decoder.NewValidationError(fmt.Sprintf("custom field '%s' accepts a single value", d.Name))
+	}
+	seen := map[string]bool{}
+	for _, v := range values {
+		if seen[v] {
+			return decoder.NewValidationError(fmt.Sprintf("value '%s' is set multiple times for custom field '%s'", v, d.Name))
+		}
+		seen[v] = true
+		switch d.Type {
+		case FieldType_Number:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return decoder.NewValidationError(fmt.Sprintf("custom field '%s' needs a number, got '%s'", d.Name, v))
+			}
+		case FieldType_Date:
+			if _, err := time.Parse(DateLayout, v); err != nil {
+				return decoder.NewValidationError(fmt.Sprintf("custom field '%s' needs a date in the YYYY-MM-DD format, got '%s'", d.Name, v))
+			}
+		case FieldType_Enum, FieldType_MultiSelect:
+			if !d.hasOption(v) {
+				return decoder.NewValidationError(fmt.Sprintf("'%s' is not an option of custom field '%s'", v, d.Name))
+			}
+		case FieldType_Text, FieldType_User:
+			if v == "" {
+				return decoder.NewValidationError(fmt.Sprintf("custom field '%s' cannot have an empty value", d.Name))
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Definition) hasOption(value string) bool {
+	for _, o := range d.Options {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns how many of the items use each value of the defined fields.
+// Counts are ordered by the definitions and then by value.
+func Count(definitions []*Definition, items []map[string]*Value) []*FieldCount {
+	counts := []*FieldCount{}
+	for _, d := range definitions {
+		byValue := map[string]int32{}
+		for _, item := range items {
+			v, ok := item[d.Name]
+			if !ok || v == nil {
+				continue
+			}
+			for _, value := range v.Values {
+				byValue[value]++
+			}
+		}
+		values := make([]string, 0, len(byValue))
+		for value := range byValue {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			counts = append(counts, &FieldCount{Field: d.Name, Value: value, Count: byValue[value]})
+		}
+	}
+	return counts
+}
@@ -1,6 +1,11 @@
 package execution
 
 import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
 )
@@ -19,10 +24,117 @@ func (e *Execution) Validate() error {
 	return nil
 }
 
-// PopulateSteps the Execution stepts given scenario steps
-func (e *Execution) PopulateSteps(s []*scenariov1.Step) {
-	e.Steps = make([]*StepExecution, len(s))
-	for i, v := range s {
-		e.Steps[i] = &StepExecution{Definition: v, Status: Status_Pending}
+// PopulateSteps the Execution stepts given scenario steps.
+// Steps that reference a step block are replaced by the steps in the block, which are looked up in blocks by ID.
+// When step blocks are expanded, the positions of the execution steps are renumbered to keep them unique.
+func (e *Execution) PopulateSteps(s []*scenariov1.Step, blocks map[string][]*scenariov1.Step) error {
+	e.Steps = make([]*StepExecution, 0, len(s))
+	expanded := false
+	for _, v := range s {
+		if v.StepBlockId == "" {
+			e.Steps = append(e.Steps, &StepExecution{Definition: v, Status: Status_Pending})
+			continue
+		}
+		blockSteps, ok := blocks[v.StepBlockId]
+		if !ok {
+			return decoder.NewValidationError(fmt.Sprintf("step block '%s' could not be found", v.StepBlockId))
+		}
+		expanded = true
+		for _, bs := range blockSteps {
+			e.Steps = append(e.Steps, &StepExecution{
+				Definition:  proto.Clone(bs).(*scenariov1.Step),
+				Status:      Status_Pending,
+				StepBlockId: v.StepBlockId,
+			})
+		}
+	}
+	if expanded {
+		for i, step := range e.Steps {
+			if step.StepBlockId == "" {
+				step.Definition = proto.Clone(step.Definition).(*scenariov1.Step)
+			}
+			step.Definition.Position = int32(i + 1)
+		}
+	}
+	return nil
+}
+
+// Step returns the step at the position or nil if the execution has no such step
+func (e *Execution) Step(position int32) *StepExecution {
+	for _, step := range e.Steps {
+		if step.Definition != nil && step.Definition.Position == position {
+			return step
+		}
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of the run request
+func (r *RunRequest) Validate() error {
+	if len(r.ScenarioIds) == 0 {
+		return decoder.NewValidationError("at least one scenario ID has to be provided")
+	}
+	return nil
+}
+
+// Record sets the outcome of an automated test on the execution.
+// When the test has step results, every step of the execution is updated with the results of the steps it matches, see Step.Matcher.
+// Otherwise the steps of passed and skipped tests get the status of the test and the steps of failed tests are left as they are
+// since the report does not tell which step failed.
+func (e *Execution) Record(result *TestResult) {
+	e.Status = result.Status
+	e.ActualResult = result.ActualResult
+	e.Duration = result.Duration
+	e.Output = result.Output
+	if len(result.Steps) != 0 {
+		for _, step := range e.Steps {
+			step.record(result.Steps)
+		}
+		return
+	}
+	if result.Status == Status_Fail {
+		return
+	}
+	for _, step := range e.Steps {
+		step.Status = result.Status
+	}
+}
+
+// statusPriority orders the statuses of the step results matching the same step, the highest one is the status of the step
+var statusPriority = map[Status]int{
+	Status_Pending: 0,
+	Status_Pass:    1,
+	Status_Skipped: 2,
+	Status_Fail:    3,
+}
+
+// record sets the status of the step to the most severe status of the results that match it. Steps that match no result are left as they are
+func (s *StepExecution) record(results []*StepResult) {
+	if s.Definition == nil {
+		return
+	}
+	matches := s.Definition.Matcher()
+	var failures []string
+	for _, result := range results {
+		if !matches(result.Text) {
+			continue
+		}
+		if statusPriority[result.Status] > statusPriority[s.Status] {
+			s.Status = result.Status
+		}
+		if result.Status == Status_Fail && result.ActualResult != "" {
+			failures = append(failures, result.ActualResult)
+		}
 	}
+	if len(failures) != 0 {
+		s.ActualResult = strings.Join(failures, "\n")
+	}
+}
+
+// Validate is used to check the integrity of the report request
+func (r *ReportRequest) Validate() error {
+	if r.Content == "" {
+		return decoder.NewValidationError("content is a mandatory parameter")
+	}
+	return nil
 }
@@ -0,0 +1,353 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.6.1
+// source: folder.proto
+
+package folder
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A folder is used to organise the scenarios of a project in a tree.
+// Folders without a `parentId` are at the root of the project.
+type Folder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identity *metadata.Identity `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// ID of the project that owns the folder. MANDATORY
+	ProjectId string `protobuf:"bytes,2,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	// Name of the folder. MANDATORY
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Description is used to add detailed information
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// ID of the parent folder. Empty for folders at the root of the project
+	ParentId string `protobuf:"bytes,5,opt,name=parentId,proto3" json:"parentId,omitempty"`
+}
+
+func (x *Folder) Reset() {
+	*x = Folder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_folder_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Folder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Folder) ProtoMessage() {}
+
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Folder) GetIdentity() *metadata.Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *Folder) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Folder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Folder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Folder) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+// Counts of the scenarios in a folder and its descendants
+type FolderSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the folder
+	FolderId string `protobuf:"bytes,1,opt,name=folderId,proto3" json:"folderId,omitempty"`
+	// Number of scenarios in the folder and its descendants
+	Scenarios int32 `protobuf:"varint,2,opt,name=scenarios,proto3" json:"scenarios,omitempty"`
+	// Number of automated scenarios in the folder and its descendants
+	AutomatedScenarios int32 `protobuf:"varint,3,opt,name=automatedScenarios,proto3" json:"automatedScenarios,omitempty"`
+}
+
+func (x *FolderSummary) Reset() {
+	*x = FolderSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_folder_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FolderSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderSummary) ProtoMessage() {}
+
+func (x *FolderSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderSummary.ProtoReflect.Descriptor instead.
+func (*FolderSummary) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FolderSummary) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *FolderSummary) GetScenarios() int32 {
+	if x != nil {
+		return x.Scenarios
+	}
+	return 0
+}
+
+func (x *FolderSummary) GetAutomatedScenarios() int32 {
+	if x != nil {
+		return x.AutomatedScenarios
+	}
+	return 0
+}
+
+// Used to move multiple scenarios to a folder
+type MoveScenariosRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IDs of the scenarios to be moved. MANDATORY
+	ScenarioIds []string `protobuf:"bytes,1,rep,name=scenarioIds,proto3" json:"scenarioIds,omitempty"`
+}
+
+func (x *MoveScenariosRequest) Reset() {
+	*x = MoveScenariosRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_folder_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveScenariosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveScenariosRequest) ProtoMessage() {}
+
+func (x *MoveScenariosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveScenariosRequest.ProtoReflect.Descriptor instead.
+func (*MoveScenariosRequest) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MoveScenariosRequest) GetScenarioIds() []string {
+	if x != nil {
+		return x.ScenarioIds
+	}
+	return nil
+}
+
+var File_folder_proto protoreflect.FileDescriptor
+
+var file_folder_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x20,
+	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f,
+	0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e,
+	0x1a, 0x17, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc2, 0x01, 0x0a, 0x06, 0x46, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75,
+	0x72, 0x69, 0x6f, 0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x79,
+	0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x75, 0x74,
+	0x6f, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x22, 0x38, 0x0a, 0x14, 0x4d, 0x6f, 0x76,
+	0x65, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x49, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f,
+	0x49, 0x64, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x2d, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e,
+	0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x70, 0x6f, 0x73, 0x74, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_folder_proto_rawDescOnce sync.Once
+	file_folder_proto_rawDescData = file_folder_proto_rawDesc
+)
+
+func file_folder_proto_rawDescGZIP() []byte {
+	file_folder_proto_rawDescOnce.Do(func() {
+		file_folder_proto_rawDescData = protoimpl.X.CompressGZIP(file_folder_proto_rawDescData)
+	})
+	return file_folder_proto_rawDescData
+}
+
+var file_folder_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_folder_proto_goTypes = []interface{}{
+	(*Folder)(nil),               // 0: folder.scratchpost.curiouskitten.Folder
+	(*FolderSummary)(nil),        // 1: folder.scratchpost.curiouskitten.FolderSummary
+	(*MoveScenariosRequest)(nil), // 2: folder.scratchpost.curiouskitten.MoveScenariosRequest
+	(*metadata.Identity)(nil),    // 3: metadata.scratchpost.curiouskitten.Identity
+}
+var file_folder_proto_depIdxs = []int32{
+	3, // 0: folder.scratchpost.curiouskitten.Folder.identity:type_name -> metadata.scratchpost.curiouskitten.Identity
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_folder_proto_init() }
+func file_folder_proto_init() {
+	if File_folder_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_folder_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Folder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_folder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FolderSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_folder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveScenariosRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_folder_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_folder_proto_goTypes,
+		DependencyIndexes: file_folder_proto_depIdxs,
+		MessageInfos:      file_folder_proto_msgTypes,
+	}.Build()
+	File_folder_proto = out.File
+	file_folder_proto_rawDesc = nil
+	file_folder_proto_goTypes = nil
+	file_folder_proto_depIdxs = nil
+}
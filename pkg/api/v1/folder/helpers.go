@@ -0,0 +1,22 @@
+package folder
+
+import "github.com/curious-kitten/scratch-post/internal/decoder"
+
+// Validate is used to check the integrity of the folder object
+func (f *Folder) Validate() error {
+	if f.Name == "" {
+		return decoder.NewValidationError("name is a mandatory parameter")
+	}
+	if f.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of the move request
+func (m *MoveScenariosRequest) Validate() error {
+	if len(m.ScenarioIds) == 0 {
+		return decoder.NewValidationError("scenarioIds is a mandatory parameter")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package issue
+
+import (
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+)
+
+// Validate is used to check the integrity of a defect request
+func (d *DefectRequest) Validate() error {
+	if d.Position <= 0 {
+		return decoder.NewValidationError("position is a mandatory parameter")
+	}
+	if _, ok := metadatav1.Severity_name[int32(d.Severity)]; !ok {
+		return decoder.NewValidationError("unknown severity")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CopyName returns the name of the n-th copy of an item, used to avoid name collisions
+func CopyName(name string, n int) string {
+	if n <= 1 {
+		return fmt.Sprintf("%s (copy)", name)
+	}
+	return fmt.Sprintf("%s (copy %d)", name, n)
+}
+
+// IsValid checks if the strategy is one of the known collision strategies
+func (c CollisionStrategy) IsValid() bool {
+	_, ok := CollisionStrategy_name[int32(c)]
+	return ok
+}
+
+// IsOpen checks if the state of the issue is not one of the closed states. States are compared case insensitive
+func (l *LinkedIssue) IsOpen(closedStates []string) bool {
+	for _, s := range closedStates {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(l.State)) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetIssueStates changes the state of the issues whose link is found in the states. It reports if any state was changed
+func SetIssueStates(issues []*LinkedIssue, states map[string]string) bool {
+	changed := false
+	for _, issue := range issues {
+		if state, ok := states[issue.Link]; ok && state != issue.State {
+			issue.State = state
+			changed = true
+		}
+	}
+	return changed
+}
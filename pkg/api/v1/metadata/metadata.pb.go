@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.23.0
+// 	protoc-gen-go v1.28.0
 // 	protoc        v3.6.1
 // source: metadata.proto
 
@@ -10,7 +10,6 @@ import (
 	reflect "reflect"
 	sync "sync"
 
-	proto "github.com/golang/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
@@ -22,9 +21,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
+// What to do when a copy has the same name as an item that already exists
+type CollisionStrategy int32
+
+const (
+	// add a suffix to the name of the copy
+	CollisionStrategy_SUFFIX CollisionStrategy = 0
+	// do not copy the item
+	CollisionStrategy_SKIP CollisionStrategy = 1
+	// replace the existing item with the copy
+	CollisionStrategy_OVERWRITE CollisionStrategy = 2
+)
+
+// Enum value maps for CollisionStrategy.
+var (
+	CollisionStrategy_name = map[int32]string{
+		0: "SUFFIX",
+		1: "SKIP",
+		2: "OVERWRITE",
+	}
+	CollisionStrategy_value = map[string]int32{
+		"SUFFIX":    0,
+		"SKIP":      1,
+		"OVERWRITE": 2,
+	}
+)
+
+func (x CollisionStrategy) Enum() *CollisionStrategy {
+	p := new(CollisionStrategy)
+	*p = x
+	return p
+}
+
+func (x CollisionStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CollisionStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_metadata_proto_enumTypes[0].Descriptor()
+}
+
+func (CollisionStrategy) Type() protoreflect.EnumType {
+	return &file_metadata_proto_enumTypes[0]
+}
+
+func (x CollisionStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CollisionStrategy.Descriptor instead.
+func (CollisionStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_metadata_proto_rawDescGZIP(), []int{0}
+}
 
 type Severity int32
 
@@ -59,11 +107,11 @@ func (x Severity) String() string {
 }
 
 func (Severity) Descriptor() protoreflect.EnumDescriptor {
-	return file_metadata_proto_enumTypes[0].Descriptor()
+	return file_metadata_proto_enumTypes[1].Descriptor()
 }
 
 func (Severity) Type() protoreflect.EnumType {
-	return &file_metadata_proto_enumTypes[0]
+	return &file_metadata_proto_enumTypes[1]
 }
 
 func (x Severity) Number() protoreflect.EnumNumber {
@@ -72,7 +120,7 @@ func (x Severity) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Severity.Descriptor instead.
 func (Severity) EnumDescriptor() ([]byte, []int) {
-	return file_metadata_proto_rawDescGZIP(), []int{0}
+	return file_metadata_proto_rawDescGZIP(), []int{1}
 }
 
 type IssueType int32
@@ -108,11 +156,11 @@ func (x IssueType) String() string {
 }
 
 func (IssueType) Descriptor() protoreflect.EnumDescriptor {
-	return file_metadata_proto_enumTypes[1].Descriptor()
+	return file_metadata_proto_enumTypes[2].Descriptor()
 }
 
 func (IssueType) Type() protoreflect.EnumType {
-	return &file_metadata_proto_enumTypes[1]
+	return &file_metadata_proto_enumTypes[2]
 }
 
 func (x IssueType) Number() protoreflect.EnumNumber {
@@ -121,7 +169,7 @@ func (x IssueType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IssueType.Descriptor instead.
 func (IssueType) EnumDescriptor() ([]byte, []int) {
-	return file_metadata_proto_rawDescGZIP(), []int{1}
+	return file_metadata_proto_rawDescGZIP(), []int{2}
 }
 
 // Represents a link to an remote issue and issue information
@@ -302,6 +350,82 @@ func (x *Identity) GetUpdateTime() int64 {
 	return 0
 }
 
+// Records the item an item was copied from
+type Provenance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the item that was copied
+	SourceId string `protobuf:"bytes,1,opt,name=sourceId,proto3" json:"sourceId,omitempty"`
+	// ID of the project of the item that was copied
+	SourceProjectId string `protobuf:"bytes,2,opt,name=sourceProjectId,proto3" json:"sourceProjectId,omitempty"`
+	// User that made the copy
+	CopiedBy string `protobuf:"bytes,3,opt,name=copiedBy,proto3" json:"copiedBy,omitempty"`
+	// Unix epoch representation of the time of the copy
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metadata_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_metadata_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_metadata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Provenance) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Provenance) GetSourceProjectId() string {
+	if x != nil {
+		return x.SourceProjectId
+	}
+	return ""
+}
+
+func (x *Provenance) GetCopiedBy() string {
+	if x != nil {
+		return x.CopiedBy
+	}
+	return ""
+}
+
+func (x *Provenance) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
 var File_metadata_proto protoreflect.FileDescriptor
 
 var file_metadata_proto_rawDesc = []byte{
@@ -334,16 +458,28 @@ var file_metadata_proto_rawDesc = []byte{
 	0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
 	0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07,
 	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
-	0x2a, 0x29, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x07, 0x0a, 0x03,
-	0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10,
-	0x01, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x02, 0x2a, 0x2c, 0x0a, 0x09, 0x49,
-	0x73, 0x73, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x50, 0x49, 0x43,
-	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a,
-	0x06, 0x44, 0x45, 0x46, 0x45, 0x43, 0x54, 0x10, 0x02, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x2d,
-	0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x70,
-	0x6f, 0x73, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x82, 0x01, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x42,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x42,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x2a, 0x38, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x55,
+	0x46, 0x46, 0x49, 0x58, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4b, 0x49, 0x50, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x02, 0x2a,
+	0x29, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x07, 0x0a, 0x03, 0x4c,
+	0x4f, 0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x01,
+	0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x02, 0x2a, 0x2c, 0x0a, 0x09, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x50, 0x49, 0x43, 0x10,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x44, 0x45, 0x46, 0x45, 0x43, 0x54, 0x10, 0x02, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x2d, 0x6b,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x70, 0x6f,
+	0x73, 0x74, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -358,17 +494,19 @@ func file_metadata_proto_rawDescGZIP() []byte {
 	return file_metadata_proto_rawDescData
 }
 
-var file_metadata_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_metadata_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_metadata_proto_goTypes = []interface{}{
-	(Severity)(0),       // 0: metadata.scratchpost.curiouskitten.Severity
-	(IssueType)(0),      // 1: metadata.scratchpost.curiouskitten.IssueType
-	(*LinkedIssue)(nil), // 2: metadata.scratchpost.curiouskitten.LinkedIssue
-	(*Identity)(nil),    // 3: metadata.scratchpost.curiouskitten.Identity
+	(CollisionStrategy)(0), // 0: metadata.scratchpost.curiouskitten.CollisionStrategy
+	(Severity)(0),          // 1: metadata.scratchpost.curiouskitten.Severity
+	(IssueType)(0),         // 2: metadata.scratchpost.curiouskitten.IssueType
+	(*LinkedIssue)(nil),    // 3: metadata.scratchpost.curiouskitten.LinkedIssue
+	(*Identity)(nil),       // 4: metadata.scratchpost.curiouskitten.Identity
+	(*Provenance)(nil),     // 5: metadata.scratchpost.curiouskitten.Provenance
 }
 var file_metadata_proto_depIdxs = []int32{
-	0, // 0: metadata.scratchpost.curiouskitten.LinkedIssue.severity:type_name -> metadata.scratchpost.curiouskitten.Severity
-	1, // 1: metadata.scratchpost.curiouskitten.LinkedIssue.IssueType:type_name -> metadata.scratchpost.curiouskitten.IssueType
+	1, // 0: metadata.scratchpost.curiouskitten.LinkedIssue.severity:type_name -> metadata.scratchpost.curiouskitten.Severity
+	2, // 1: metadata.scratchpost.curiouskitten.LinkedIssue.IssueType:type_name -> metadata.scratchpost.curiouskitten.IssueType
 	2, // [2:2] is the sub-list for method output_type
 	2, // [2:2] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
@@ -406,14 +544,26 @@ func file_metadata_proto_init() {
 				return nil
 			}
 		}
+		file_metadata_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Provenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_metadata_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   2,
+			NumEnums:      3,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -1,11 +1,125 @@
 package project
 
-import "github.com/curious-kitten/scratch-post/internal/decoder"
+import (
+	"fmt"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+)
 
 // Validate check whether the constraints on Project have been met
 func (p *Project) Validate() error {
 	if p.Name == "" {
 		return decoder.NewValidationError("name is a mandatory parameter")
 	}
+	if err := customfieldv1.ValidateDefinitions(p.ScenarioFields); err != nil {
+		return err
+	}
+	if err := customfieldv1.ValidateDefinitions(p.ExecutionFields); err != nil {
+		return err
+	}
+	for _, t := range p.ScenarioTransitions {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateDimensions(p.ConfigurationDimensions, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateMatrix checks that the dimensions and values of a configuration matrix are defined by the project
+func (p *Project) ValidateMatrix(matrix []*Dimension) error {
+	allowed := make(map[string]map[string]bool, len(p.ConfigurationDimensions))
+	for _, d := range p.ConfigurationDimensions {
+		allowed[d.Name] = map[string]bool{}
+		for _, v := range d.Values {
+			allowed[d.Name][v] = true
+		}
+	}
+	return validateDimensions(matrix, allowed)
+}
+
+// FilterMatrix returns the part of a configuration matrix that is defined by the project
+func (p *Project) FilterMatrix(matrix []*Dimension) []*Dimension {
+	values := make(map[string][]string, len(p.ConfigurationDimensions))
+	for _, d := range p.ConfigurationDimensions {
+		values[d.Name] = d.Values
+	}
+	var filtered []*Dimension
+	for _, d := range matrix {
+		kept := &Dimension{Name: d.Name}
+		for _, v := range d.Values {
+			for _, allowed := range values[d.Name] {
+				if v == allowed {
+					kept.Values = append(kept.Values, v)
+					break
+				}
+			}
+		}
+		if len(kept.Values) != 0 {
+			filtered = append(filtered, kept)
+		}
+	}
+	return filtered
+}
+
+// Combinations returns every combination of the values of the dimensions, keyed by dimension name.
+// The combinations follow the order of the values of the first dimension, then of the second one and so on
+func Combinations(dimensions []*Dimension) []map[string]string {
+	if len(dimensions) == 0 {
+		return nil
+	}
+	combinations := []map[string]string{{}}
+	for _, d := range dimensions {
+		next := make([]map[string]string, 0, len(combinations)*len(d.Values))
+		for _, c := range combinations {
+			for _, v := range d.Values {
+				combination := make(map[string]string, len(c)+1)
+				for name, value := range c {
+					combination[name] = value
+				}
+				combination[d.Name] = v
+				next = append(next, combination)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// validateDimensions checks that the dimensions and their values are named and unique.
+// When allowed is not nil, the dimensions and values also have to be part of it
+func validateDimensions(dimensions []*Dimension, allowed map[string]map[string]bool) error {
+	names := map[string]bool{}
+	for _, d := range dimensions {
+		if d.Name == "" {
+			return decoder.NewValidationError("name is a mandatory parameter for configuration dimensions")
+		}
+		if names[d.Name] {
+			return decoder.NewValidationError(fmt.Sprintf("configuration dimension '%s' is defined more than once", d.Name))
+		}
+		names[d.Name] = true
+		if allowed != nil && allowed[d.Name] == nil {
+			return decoder.NewValidationError(fmt.Sprintf("configuration dimension '%s' is not defined by the project", d.Name))
+		}
+		if len(d.Values) == 0 {
+			return decoder.NewValidationError(fmt.Sprintf("configuration dimension '%s' has no values", d.Name))
+		}
+		values := map[string]bool{}
+		for _, v := range d.Values {
+			if v == "" {
+				return decoder.NewValidationError(fmt.Sprintf("configuration dimension '%s' has an empty value", d.Name))
+			}
+			if values[v] {
+				return decoder.NewValidationError(fmt.Sprintf("value '%s' of configuration dimension '%s' is defined more than once", v, d.Name))
+			}
+			values[v] = true
+			if allowed != nil && !allowed[d.Name][v] {
+				return decoder.NewValidationError(fmt.Sprintf("value '%s' of configuration dimension '%s' is not defined by the project", v, d.Name))
+			}
+		}
+	}
 	return nil
 }
@@ -0,0 +1,45 @@
+package release
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+)
+
+// Validate is used to check the integrity of the release object
+func (r *Release) Validate() error {
+	if r.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if r.Version == "" {
+		return decoder.NewValidationError("version is a mandatory parameter")
+	}
+	if _, ok := Status_name[int32(r.Status)]; !ok {
+		return decoder.NewValidationError(fmt.Sprintf("status %d is not a valid release status", r.Status))
+	}
+	start, err := parseDate("startDate", r.StartDate)
+	if err != nil {
+		return err
+	}
+	end, err := parseDate("releaseDate", r.ReleaseDate)
+	if err != nil {
+		return err
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return decoder.NewValidationError("releaseDate cannot be before startDate")
+	}
+	return nil
+}
+
+func parseDate(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(customfieldv1.DateLayout, value)
+	if err != nil {
+		return time.Time{}, decoder.NewValidationError(fmt.Sprintf("%s must have the format %s", field, customfieldv1.DateLayout))
+	}
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package requirement
+
+import "github.com/curious-kitten/scratch-post/internal/decoder"
+
+// Validate is used to check the integrity of the requirement object
+func (r *Requirement) Validate() error {
+	if r.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if r.Key == "" {
+		return decoder.NewValidationError("key is a mandatory parameter")
+	}
+	if r.Title == "" {
+		return decoder.NewValidationError("title is a mandatory parameter")
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of the link request
+func (l *LinkScenariosRequest) Validate() error {
+	if len(l.ScenarioIds) == 0 {
+		return decoder.NewValidationError("at least one scenario ID has to be provided")
+	}
+	return nil
+}
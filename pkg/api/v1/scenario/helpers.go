@@ -1,6 +1,23 @@
 package scenario
 
-import "github.com/curious-kitten/scratch-post/internal/decoder"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+)
+
+// DefaultTransitions are the changes of state allowed for projects that do not configure their own
+var DefaultTransitions = []*Transition{
+	{From: State_Draft, To: State_InReview},
+	{From: State_InReview, To: State_Draft},
+	{From: State_Approved, To: State_Draft},
+	{From: State_Approved, To: State_Deprecated},
+	{From: State_Deprecated, To: State_Draft},
+}
 
 // Validate is used to check the integrity of the scenario object
 func (s *Scenario) Validate() error {
@@ -10,13 +27,235 @@ func (s *Scenario) Validate() error {
 	if s.ProjectId == "" {
 		return decoder.NewValidationError("projectId is a mandatory parameter")
 	}
+	if s.Parameters != nil {
+		if err := s.Parameters.Validate(); err != nil {
+			return err
+		}
+	}
+	keys := map[string]bool{}
+	for _, key := range s.AutomationKeys {
+		if key == "" {
+			return decoder.NewValidationError("automation keys cannot be empty")
+		}
+		if keys[key] {
+			return decoder.NewValidationError(fmt.Sprintf("automation key '%s' is used more than once", key))
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of the parameters of a scenario
+func (p *Parameters) Validate() error {
+	if len(p.Names) == 0 && len(p.Rows) != 0 {
+		return decoder.NewValidationError("parameter rows need parameter names")
+	}
+	names := map[string]bool{}
+	for _, name := range p.Names {
+		if name == "" {
+			return decoder.NewValidationError("parameter names cannot be empty")
+		}
+		if names[name] {
+			return decoder.NewValidationError(fmt.Sprintf("parameter '%s' is defined more than once", name))
+		}
+		names[name] = true
+	}
+	for i, row := range p.Rows {
+		if len(row.Values) != len(p.Names) {
+			return decoder.NewValidationError(fmt.Sprintf("parameter row %d has %d values instead of %d", i+1, len(row.Values), len(p.Names)))
+		}
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of a Gherkin import request
+func (g *GherkinImportRequest) Validate() error {
+	if g.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if g.FeaturePath == "" {
+		return decoder.NewValidationError("featurePath is a mandatory parameter")
+	}
+	if g.Content == "" {
+		return decoder.NewValidationError("content is a mandatory parameter")
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of a CSV import request
+func (c *CSVImportRequest) Validate() error {
+	if c.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if c.Content == "" {
+		return decoder.NewValidationError("content is a mandatory parameter")
+	}
 	return nil
 }
 
 // Validate is used to check the integrity of a scenario step
 func (s *Step) Validate() error {
-	if s.Name == "" {
+	if s.Name == "" && s.StepBlockId == "" {
 		return decoder.NewValidationError("name is a mandatory parameter for a step")
 	}
 	return nil
 }
+
+// StepBlockIDs returns the IDs of the step blocks referenced by the scenario
+func (s *Scenario) StepBlockIDs() []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, step := range s.Steps {
+		if step.StepBlockId != "" && !seen[step.StepBlockId] {
+			seen[step.StepBlockId] = true
+			ids = append(ids, step.StepBlockId)
+		}
+	}
+	return ids
+}
+
+// Validate is used to check the integrity of a review request
+func (r *ReviewRequest) Validate() error {
+	switch r.Decision {
+	case ReviewDecision_Approve:
+	case ReviewDecision_RequestChanges:
+		if r.Comment == "" {
+			return decoder.NewValidationError("a comment is mandatory when requesting changes")
+		}
+	default:
+		return decoder.NewValidationError("decision has to be Approve or RequestChanges")
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of a transition request
+func (t *TransitionRequest) Validate() error {
+	if _, ok := State_name[int32(t.State)]; !ok {
+		return decoder.NewValidationError("unknown scenario state")
+	}
+	if t.State == State_Approved {
+		return decoder.NewValidationError("scenarios can only be approved through a review")
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of a transition
+func (t *Transition) Validate() error {
+	if _, ok := State_name[int32(t.From)]; !ok {
+		return decoder.NewValidationError("unknown scenario state in transition")
+	}
+	if _, ok := State_name[int32(t.To)]; !ok {
+		return decoder.NewValidationError("unknown scenario state in transition")
+	}
+	if t.From == t.To {
+		return decoder.NewValidationError(fmt.Sprintf("transition from %s to the same state", t.From))
+	}
+	if t.To == State_Approved {
+		return decoder.NewValidationError("scenarios can only be approved through a review")
+	}
+	return nil
+}
+
+// CanTransition checks if a scenario can be moved between the states using the transitions.
+// When no transitions are provided, the DefaultTransitions are used.
+func CanTransition(transitions []*Transition, from State, to State) bool {
+	if len(transitions) == 0 {
+		transitions = DefaultTransitions
+	}
+	for _, t := range transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReview checks if the user is allowed to review the scenario
+func (s *Scenario) CanReview(user string) bool {
+	if len(s.Reviewers) == 0 {
+		return true
+	}
+	for _, r := range s.Reviewers {
+		if r == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate is used to check the integrity of a copy request
+func (c *CopyRequest) Validate() error {
+	if c.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if !c.OnCollision.IsValid() {
+		return decoder.NewValidationError("unknown collision strategy")
+	}
+	return nil
+}
+
+// InlineStepBlocks replaces the steps that reference a step block with the steps of the block.
+// The blocks are looked up by ID and the steps are renumbered when a block is inlined.
+func (s *Scenario) InlineStepBlocks(blocks map[string][]*Step) error {
+	steps := make([]*Step, 0, len(s.Steps))
+	expanded := false
+	for _, step := range s.Steps {
+		if step.StepBlockId == "" {
+			steps = append(steps, step)
+			continue
+		}
+		blockSteps, ok := blocks[step.StepBlockId]
+		if !ok {
+			return decoder.NewValidationError(fmt.Sprintf("step block '%s' could not be found", step.StepBlockId))
+		}
+		expanded = true
+		for _, bs := range blockSteps {
+			steps = append(steps, proto.Clone(bs).(*Step))
+		}
+	}
+	if expanded {
+		for i, step := range steps {
+			step.Position = int32(i + 1)
+		}
+	}
+	s.Steps = steps
+	return nil
+}
+
+// placeholder matches the reference to a parameter in the text of a step, ie. <email>
+var placeholder = regexp.MustCompile(`<[^<>]+>`)
+
+// Matcher returns a function that checks if a text is the name of the step or one of the lines of its action or expected outcome,
+// ie. the text of a Gherkin step. References to parameters match any value. The patterns are compiled once, when the matcher is created.
+func (s *Step) Matcher() func(text string) bool {
+	exact := map[string]bool{}
+	var patterns []*regexp.Regexp
+	candidates := append(strings.Split(s.Action, "\n"), strings.Split(s.ExpectedOutcome, "\n")...)
+	for _, candidate := range append(candidates, s.Name) {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		exact[candidate] = true
+		if !placeholder.MatchString(candidate) {
+			continue
+		}
+		parts := placeholder.Split(candidate, -1)
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		patterns = append(patterns, regexp.MustCompile("^"+strings.Join(parts, ".*")+"$"))
+	}
+	return func(text string) bool {
+		text = strings.TrimSpace(text)
+		if exact[text] {
+			return true
+		}
+		for _, pattern := range patterns {
+			if pattern.MatchString(text) {
+				return true
+			}
+		}
+		return false
+	}
+}
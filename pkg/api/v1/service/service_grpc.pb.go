@@ -0,0 +1,1070 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: service.proto
+
+package service
+
+import (
+	context "context"
+
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Invalidates the token of the call
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.AuthService/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.AuthService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility
+type AuthServiceServer interface {
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// Invalidates the token of the call
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthServiceServer struct {
+}
+
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.AuthService/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.AuthService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "service.scratchpost.curiouskitten.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthService_Logout_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+// ProjectServiceClient is the client API for ProjectService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProjectServiceClient interface {
+	Create(ctx context.Context, in *project.Project, opts ...grpc.CallOption) (*project.Project, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*project.Project, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ProjectList, error)
+	Update(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*project.Project, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type projectServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProjectServiceClient(cc grpc.ClientConnInterface) ProjectServiceClient {
+	return &projectServiceClient{cc}
+}
+
+func (c *projectServiceClient) Create(ctx context.Context, in *project.Project, opts ...grpc.CallOption) (*project.Project, error) {
+	out := new(project.Project)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ProjectService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*project.Project, error) {
+	out := new(project.Project)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ProjectService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ProjectList, error) {
+	out := new(ProjectList)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ProjectService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) Update(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*project.Project, error) {
+	out := new(project.Project)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ProjectService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ProjectService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProjectServiceServer is the server API for ProjectService service.
+// All implementations must embed UnimplementedProjectServiceServer
+// for forward compatibility
+type ProjectServiceServer interface {
+	Create(context.Context, *project.Project) (*project.Project, error)
+	Get(context.Context, *GetRequest) (*project.Project, error)
+	List(context.Context, *ListRequest) (*ProjectList, error)
+	Update(context.Context, *UpdateProjectRequest) (*project.Project, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedProjectServiceServer()
+}
+
+// UnimplementedProjectServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProjectServiceServer struct {
+}
+
+func (UnimplementedProjectServiceServer) Create(context.Context, *project.Project) (*project.Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedProjectServiceServer) Get(context.Context, *GetRequest) (*project.Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedProjectServiceServer) List(context.Context, *ListRequest) (*ProjectList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedProjectServiceServer) Update(context.Context, *UpdateProjectRequest) (*project.Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedProjectServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
+
+// UnsafeProjectServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProjectServiceServer will
+// result in compilation errors.
+type UnsafeProjectServiceServer interface {
+	mustEmbedUnimplementedProjectServiceServer()
+}
+
+func RegisterProjectServiceServer(s grpc.ServiceRegistrar, srv ProjectServiceServer) {
+	s.RegisterService(&ProjectService_ServiceDesc, srv)
+}
+
+func _ProjectService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(project.Project)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ProjectService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).Create(ctx, req.(*project.Project))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ProjectService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ProjectService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ProjectService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).Update(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ProjectService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProjectService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "service.scratchpost.curiouskitten.ProjectService",
+	HandlerType: (*ProjectServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ProjectService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ProjectService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ProjectService_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ProjectService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ProjectService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+// ScenarioServiceClient is the client API for ScenarioService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ScenarioServiceClient interface {
+	Create(ctx context.Context, in *scenario.Scenario, opts ...grpc.CallOption) (*scenario.Scenario, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*scenario.Scenario, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ScenarioList, error)
+	Update(ctx context.Context, in *UpdateScenarioRequest, opts ...grpc.CallOption) (*scenario.Scenario, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type scenarioServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScenarioServiceClient(cc grpc.ClientConnInterface) ScenarioServiceClient {
+	return &scenarioServiceClient{cc}
+}
+
+func (c *scenarioServiceClient) Create(ctx context.Context, in *scenario.Scenario, opts ...grpc.CallOption) (*scenario.Scenario, error) {
+	out := new(scenario.Scenario)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ScenarioService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scenarioServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*scenario.Scenario, error) {
+	out := new(scenario.Scenario)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ScenarioService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scenarioServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ScenarioList, error) {
+	out := new(ScenarioList)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ScenarioService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scenarioServiceClient) Update(ctx context.Context, in *UpdateScenarioRequest, opts ...grpc.CallOption) (*scenario.Scenario, error) {
+	out := new(scenario.Scenario)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ScenarioService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scenarioServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ScenarioService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScenarioServiceServer is the server API for ScenarioService service.
+// All implementations must embed UnimplementedScenarioServiceServer
+// for forward compatibility
+type ScenarioServiceServer interface {
+	Create(context.Context, *scenario.Scenario) (*scenario.Scenario, error)
+	Get(context.Context, *GetRequest) (*scenario.Scenario, error)
+	List(context.Context, *ListRequest) (*ScenarioList, error)
+	Update(context.Context, *UpdateScenarioRequest) (*scenario.Scenario, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedScenarioServiceServer()
+}
+
+// UnimplementedScenarioServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedScenarioServiceServer struct {
+}
+
+func (UnimplementedScenarioServiceServer) Create(context.Context, *scenario.Scenario) (*scenario.Scenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedScenarioServiceServer) Get(context.Context, *GetRequest) (*scenario.Scenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedScenarioServiceServer) List(context.Context, *ListRequest) (*ScenarioList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedScenarioServiceServer) Update(context.Context, *UpdateScenarioRequest) (*scenario.Scenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedScenarioServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedScenarioServiceServer) mustEmbedUnimplementedScenarioServiceServer() {}
+
+// UnsafeScenarioServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScenarioServiceServer will
+// result in compilation errors.
+type UnsafeScenarioServiceServer interface {
+	mustEmbedUnimplementedScenarioServiceServer()
+}
+
+func RegisterScenarioServiceServer(s grpc.ServiceRegistrar, srv ScenarioServiceServer) {
+	s.RegisterService(&ScenarioService_ServiceDesc, srv)
+}
+
+func _ScenarioService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(scenario.Scenario)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScenarioServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ScenarioService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScenarioServiceServer).Create(ctx, req.(*scenario.Scenario))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScenarioService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScenarioServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ScenarioService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScenarioServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScenarioService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScenarioServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ScenarioService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScenarioServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScenarioService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScenarioServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ScenarioService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScenarioServiceServer).Update(ctx, req.(*UpdateScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScenarioService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScenarioServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ScenarioService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScenarioServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScenarioService_ServiceDesc is the grpc.ServiceDesc for ScenarioService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScenarioService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "service.scratchpost.curiouskitten.ScenarioService",
+	HandlerType: (*ScenarioServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ScenarioService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ScenarioService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ScenarioService_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ScenarioService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ScenarioService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+// TestPlanServiceClient is the client API for TestPlanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TestPlanServiceClient interface {
+	Create(ctx context.Context, in *testplan.TestPlan, opts ...grpc.CallOption) (*testplan.TestPlan, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*testplan.TestPlan, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*TestPlanList, error)
+	Update(ctx context.Context, in *UpdateTestPlanRequest, opts ...grpc.CallOption) (*testplan.TestPlan, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Creates an execution for each scenario on each configuration of the matrix of the test plan
+	Run(ctx context.Context, in *RunTestPlanRequest, opts ...grpc.CallOption) (*execution.Run, error)
+}
+
+type testPlanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestPlanServiceClient(cc grpc.ClientConnInterface) TestPlanServiceClient {
+	return &testPlanServiceClient{cc}
+}
+
+func (c *testPlanServiceClient) Create(ctx context.Context, in *testplan.TestPlan, opts ...grpc.CallOption) (*testplan.TestPlan, error) {
+	out := new(testplan.TestPlan)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testPlanServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*testplan.TestPlan, error) {
+	out := new(testplan.TestPlan)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testPlanServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*TestPlanList, error) {
+	out := new(TestPlanList)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testPlanServiceClient) Update(ctx context.Context, in *UpdateTestPlanRequest, opts ...grpc.CallOption) (*testplan.TestPlan, error) {
+	out := new(testplan.TestPlan)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testPlanServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testPlanServiceClient) Run(ctx context.Context, in *RunTestPlanRequest, opts ...grpc.CallOption) (*execution.Run, error) {
+	out := new(execution.Run)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.TestPlanService/Run", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestPlanServiceServer is the server API for TestPlanService service.
+// All implementations must embed UnimplementedTestPlanServiceServer
+// for forward compatibility
+type TestPlanServiceServer interface {
+	Create(context.Context, *testplan.TestPlan) (*testplan.TestPlan, error)
+	Get(context.Context, *GetRequest) (*testplan.TestPlan, error)
+	List(context.Context, *ListRequest) (*TestPlanList, error)
+	Update(context.Context, *UpdateTestPlanRequest) (*testplan.TestPlan, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Creates an execution for each scenario on each configuration of the matrix of the test plan
+	Run(context.Context, *RunTestPlanRequest) (*execution.Run, error)
+	mustEmbedUnimplementedTestPlanServiceServer()
+}
+
+// UnimplementedTestPlanServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTestPlanServiceServer struct {
+}
+
+func (UnimplementedTestPlanServiceServer) Create(context.Context, *testplan.TestPlan) (*testplan.TestPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTestPlanServiceServer) Get(context.Context, *GetRequest) (*testplan.TestPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedTestPlanServiceServer) List(context.Context, *ListRequest) (*TestPlanList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedTestPlanServiceServer) Update(context.Context, *UpdateTestPlanRequest) (*testplan.TestPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedTestPlanServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTestPlanServiceServer) Run(context.Context, *RunTestPlanRequest) (*execution.Run, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedTestPlanServiceServer) mustEmbedUnimplementedTestPlanServiceServer() {}
+
+// UnsafeTestPlanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TestPlanServiceServer will
+// result in compilation errors.
+type UnsafeTestPlanServiceServer interface {
+	mustEmbedUnimplementedTestPlanServiceServer()
+}
+
+func RegisterTestPlanServiceServer(s grpc.ServiceRegistrar, srv TestPlanServiceServer) {
+	s.RegisterService(&TestPlanService_ServiceDesc, srv)
+}
+
+func _TestPlanService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(testplan.TestPlan)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).Create(ctx, req.(*testplan.TestPlan))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestPlanService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestPlanService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestPlanService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTestPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).Update(ctx, req.(*UpdateTestPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestPlanService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestPlanService_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunTestPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestPlanServiceServer).Run(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.TestPlanService/Run",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestPlanServiceServer).Run(ctx, req.(*RunTestPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TestPlanService_ServiceDesc is the grpc.ServiceDesc for TestPlanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TestPlanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "service.scratchpost.curiouskitten.TestPlanService",
+	HandlerType: (*TestPlanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TestPlanService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _TestPlanService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _TestPlanService_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TestPlanService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TestPlanService_Delete_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _TestPlanService_Run_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+// ExecutionServiceClient is the client API for ExecutionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExecutionServiceClient interface {
+	Create(ctx context.Context, in *execution.Execution, opts ...grpc.CallOption) (*execution.Execution, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*execution.Execution, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ExecutionList, error)
+	Update(ctx context.Context, in *UpdateExecutionRequest, opts ...grpc.CallOption) (*execution.Execution, error)
+}
+
+type executionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionServiceClient(cc grpc.ClientConnInterface) ExecutionServiceClient {
+	return &executionServiceClient{cc}
+}
+
+func (c *executionServiceClient) Create(ctx context.Context, in *execution.Execution, opts ...grpc.CallOption) (*execution.Execution, error) {
+	out := new(execution.Execution)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ExecutionService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*execution.Execution, error) {
+	out := new(execution.Execution)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ExecutionService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ExecutionList, error) {
+	out := new(ExecutionList)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ExecutionService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) Update(ctx context.Context, in *UpdateExecutionRequest, opts ...grpc.CallOption) (*execution.Execution, error) {
+	out := new(execution.Execution)
+	err := c.cc.Invoke(ctx, "/service.scratchpost.curiouskitten.ExecutionService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutionServiceServer is the server API for ExecutionService service.
+// All implementations must embed UnimplementedExecutionServiceServer
+// for forward compatibility
+type ExecutionServiceServer interface {
+	Create(context.Context, *execution.Execution) (*execution.Execution, error)
+	Get(context.Context, *GetRequest) (*execution.Execution, error)
+	List(context.Context, *ListRequest) (*ExecutionList, error)
+	Update(context.Context, *UpdateExecutionRequest) (*execution.Execution, error)
+	mustEmbedUnimplementedExecutionServiceServer()
+}
+
+// UnimplementedExecutionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExecutionServiceServer struct {
+}
+
+func (UnimplementedExecutionServiceServer) Create(context.Context, *execution.Execution) (*execution.Execution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedExecutionServiceServer) Get(context.Context, *GetRequest) (*execution.Execution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedExecutionServiceServer) List(context.Context, *ListRequest) (*ExecutionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedExecutionServiceServer) Update(context.Context, *UpdateExecutionRequest) (*execution.Execution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedExecutionServiceServer) mustEmbedUnimplementedExecutionServiceServer() {}
+
+// UnsafeExecutionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecutionServiceServer will
+// result in compilation errors.
+type UnsafeExecutionServiceServer interface {
+	mustEmbedUnimplementedExecutionServiceServer()
+}
+
+func RegisterExecutionServiceServer(s grpc.ServiceRegistrar, srv ExecutionServiceServer) {
+	s.RegisterService(&ExecutionService_ServiceDesc, srv)
+}
+
+func _ExecutionService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(execution.Execution)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ExecutionService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).Create(ctx, req.(*execution.Execution))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ExecutionService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ExecutionService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.scratchpost.curiouskitten.ExecutionService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).Update(ctx, req.(*UpdateExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExecutionService_ServiceDesc is the grpc.ServiceDesc for ExecutionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecutionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "service.scratchpost.curiouskitten.ExecutionService",
+	HandlerType: (*ExecutionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ExecutionService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ExecutionService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ExecutionService_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ExecutionService_Update_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
@@ -0,0 +1,25 @@
+package stepblock
+
+import "github.com/curious-kitten/scratch-post/internal/decoder"
+
+// Validate is used to check the integrity of the step block object
+func (s *StepBlock) Validate() error {
+	if s.Name == "" {
+		return decoder.NewValidationError("name is a mandatory parameter")
+	}
+	if s.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if len(s.Steps) == 0 {
+		return decoder.NewValidationError("a step block needs at least one step")
+	}
+	for _, step := range s.Steps {
+		if step.StepBlockId != "" {
+			return decoder.NewValidationError("a step block cannot reference another step block")
+		}
+		if err := step.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.6.1
+// source: stepblock.proto
+
+package stepblock
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A project scoped list of steps that can be shared between scenarios.
+// Scenarios reference a step block through a step that has the `stepBlockId` set.
+type StepBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identity *metadata.Identity `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// ID of the project that owns the step block. MANDATORY
+	ProjectId string `protobuf:"bytes,2,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	// Used for unique identification. It should be a brief description of what the steps achieve. MANDATORY
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Description is used to add detailed information
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// The steps that replace the referencing step when an execution is created. MANDATORY
+	Steps []*scenario.Step `protobuf:"bytes,5,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *StepBlock) Reset() {
+	*x = StepBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stepblock_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepBlock) ProtoMessage() {}
+
+func (x *StepBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_stepblock_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepBlock.ProtoReflect.Descriptor instead.
+func (*StepBlock) Descriptor() ([]byte, []int) {
+	return file_stepblock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StepBlock) GetIdentity() *metadata.Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *StepBlock) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *StepBlock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StepBlock) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StepBlock) GetSteps() []*scenario.Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+var File_stepblock_proto protoreflect.FileDescriptor
+
+var file_stepblock_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x73, 0x74, 0x65, 0x70, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x23, 0x73, 0x74, 0x65, 0x70, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x73, 0x63, 0x72,
+	0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73,
+	0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x1a, 0x17, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x17, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x2f, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72,
+	0x69, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x01, 0x0a, 0x09, 0x53, 0x74, 0x65,
+	0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x48, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x2e, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e,
+	0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x2e, 0x73,
+	0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x63, 0x75, 0x72, 0x69, 0x6f,
+	0x75, 0x73, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73,
+	0x74, 0x65, 0x70, 0x73, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x75, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x2d, 0x6b, 0x69, 0x74, 0x74, 0x65,
+	0x6e, 0x2f, 0x73, 0x63, 0x72, 0x61, 0x74, 0x63, 0x68, 0x2d, 0x70, 0x6f, 0x73, 0x74, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x65, 0x70, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_stepblock_proto_rawDescOnce sync.Once
+	file_stepblock_proto_rawDescData = file_stepblock_proto_rawDesc
+)
+
+func file_stepblock_proto_rawDescGZIP() []byte {
+	file_stepblock_proto_rawDescOnce.Do(func() {
+		file_stepblock_proto_rawDescData = protoimpl.X.CompressGZIP(file_stepblock_proto_rawDescData)
+	})
+	return file_stepblock_proto_rawDescData
+}
+
+var file_stepblock_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_stepblock_proto_goTypes = []interface{}{
+	(*StepBlock)(nil),         // 0: stepblock.scratchpost.curiouskitten.StepBlock
+	(*metadata.Identity)(nil), // 1: metadata.scratchpost.curiouskitten.Identity
+	(*scenario.Step)(nil),     // 2: scenario.scratchpost.curiouskitten.Step
+}
+var file_stepblock_proto_depIdxs = []int32{
+	1, // 0: stepblock.scratchpost.curiouskitten.StepBlock.identity:type_name -> metadata.scratchpost.curiouskitten.Identity
+	2, // 1: stepblock.scratchpost.curiouskitten.StepBlock.steps:type_name -> scenario.scratchpost.curiouskitten.Step
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_stepblock_proto_init() }
+func file_stepblock_proto_init() {
+	if File_stepblock_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_stepblock_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_stepblock_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_stepblock_proto_goTypes,
+		DependencyIndexes: file_stepblock_proto_depIdxs,
+		MessageInfos:      file_stepblock_proto_msgTypes,
+	}.Build()
+	File_stepblock_proto = out.File
+	file_stepblock_proto_rawDesc = nil
+	file_stepblock_proto_goTypes = nil
+	file_stepblock_proto_depIdxs = nil
+}
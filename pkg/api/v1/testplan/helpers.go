@@ -1,6 +1,9 @@
 package testplan
 
-import "github.com/curious-kitten/scratch-post/internal/decoder"
+import (
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+)
 
 // Validate checks the integrity of the TestPlan
 func (s *TestPlan) Validate() error {
@@ -12,3 +15,47 @@ func (s *TestPlan) Validate() error {
 	}
 	return nil
 }
+
+// Configurations returns the configurations of the matrix of the test plan. A test plan without a matrix has no configurations
+func (s *TestPlan) Configurations() []map[string]string {
+	return projectv1.Combinations(s.Matrix)
+}
+
+// ValidateConfiguration checks that a configuration is part of the matrix of the test plan.
+// Only an empty configuration is accepted when the test plan has no matrix
+func (s *TestPlan) ValidateConfiguration(configuration map[string]string) error {
+	if len(s.Matrix) == 0 {
+		if len(configuration) != 0 {
+			return decoder.NewValidationError("the test plan has no configuration matrix")
+		}
+		return nil
+	}
+	if len(configuration) != len(s.Matrix) {
+		return decoder.NewValidationError("the configuration has to set a value for every dimension of the test plan matrix")
+	}
+	for _, d := range s.Matrix {
+		value, ok := configuration[d.Name]
+		found := false
+		for _, v := range d.Values {
+			if ok && v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return decoder.NewValidationError("the configuration is not part of the test plan matrix")
+		}
+	}
+	return nil
+}
+
+// Validate is used to check the integrity of a copy request
+func (c *CopyRequest) Validate() error {
+	if c.ProjectId == "" {
+		return decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	if !c.OnCollision.IsValid() {
+		return decoder.NewValidationError("unknown collision strategy")
+	}
+	return nil
+}
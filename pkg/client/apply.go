@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+// ApplyResult tells what Apply did with an item
+type ApplyResult string
+
+const (
+	// Created is returned when the item did not exist
+	Created ApplyResult = "created"
+	// Updated is returned when the existing item was changed
+	Updated ApplyResult = "updated"
+	// Unchanged is returned when the existing item already matched
+	Unchanged ApplyResult = "unchanged"
+)
+
+// Apply creates the scenario or updates the existing one, so that the scenarios can be kept in files.
+// A scenario with an ID updates the scenario with the ID. Otherwise, it updates the scenario of the project with the same name, if there is one.
+// Scenarios that already match are not updated, since updating an approved scenario sends it back to review.
+func (s *Scenarios) Apply(ctx context.Context, scenario *scenariov1.Scenario) (*scenariov1.Scenario, ApplyResult, error) {
+	existing, err := s.existing(ctx, scenario)
+	if err != nil {
+		return nil, "", err
+	}
+	if existing == nil {
+		created, err := s.Create(ctx, scenario)
+		if err != nil {
+			return nil, "", err
+		}
+		return created, Created, nil
+	}
+	desired := proto.Clone(scenario).(*scenariov1.Scenario)
+	// the fields managed by the server are kept on update
+	desired.Identity = existing.Identity
+	desired.Reviews = existing.Reviews
+	desired.RequirementIds = existing.RequirementIds
+	desired.State = existing.State
+	if proto.Equal(desired, existing) {
+		return existing, Unchanged, nil
+	}
+	updated, err := s.Update(ctx, existing.Identity.GetId(), scenario)
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, Updated, nil
+}
+
+// existing returns the scenario that is replaced by the applied one, or nil when the applied one is new
+func (s *Scenarios) existing(ctx context.Context, scenario *scenariov1.Scenario) (*scenariov1.Scenario, error) {
+	if id := scenario.Identity.GetId(); id != "" {
+		return s.Get(ctx, id)
+	}
+	if scenario.ProjectId == "" || scenario.Name == "" {
+		return nil, fmt.Errorf("projectId and name are mandatory for a scenario without an ID")
+	}
+	found, err := s.List(ctx, &ListOptions{Filter: map[string][]string{"projectid": {scenario.ProjectId}, "name": {scenario.Name}}}).All()
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+	return nil, fmt.Errorf("there are %d scenarios named '%s' in project '%s', set the ID of the one to update", len(found), scenario.Name, scenario.ProjectId)
+}
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// authCookie is the cookie in which the server expects the auth token
+const authCookie = "auth-token"
+
+const (
+	// DefaultRetries is the number of times a request is retried when the server is unavailable
+	DefaultRetries = 2
+	// DefaultBackoff is the time waited before the first retry, it doubles with every retry
+	DefaultBackoff = 200 * time.Millisecond
+)
+
+// Client calls the REST API of a scratch-post server
+type Client struct {
+	server  string
+	http    *http.Client
+	token   string
+	retries int
+	backoff time.Duration
+
+	Projects   *Projects
+	Scenarios  *Scenarios
+	TestPlans  *TestPlans
+	Executions *Executions
+}
+
+// Option configures a client
+type Option func(c *Client)
+
+// WithHTTPClient makes the client send its requests with the HTTP client, ie. to configure timeouts or TLS
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithToken authenticates the requests with a token received from a previous login, instead of logging in
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times a request is retried when the server is unavailable and the time waited before the first retry.
+// Only the requests that can safely be repeated are retried: GET, PUT and DELETE.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client for the API at the server URL, including the root prefix, ie. http://localhost:9090/api/v1.
+// The items are managed through the default endpoints, see `scratch-post generate apiconfig`.
+func New(server string, options ...Option) *Client {
+	c := &Client{
+		server:  strings.TrimSuffix(server, "/"),
+		http:    http.DefaultClient,
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	c.Projects = &Projects{client: c}
+	c.Scenarios = &Scenarios{client: c}
+	c.TestPlans = &TestPlans{client: c}
+	c.Executions = &Executions{client: c}
+	return c
+}
+
+// Login authenticates the user on the server. The received token is sent with the following requests
+func (c *Client) Login(ctx context.Context, username string, password string) error {
+	credentials := map[string]string{"Username": username, "Password": password}
+	payload, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, "/login", payload, func(resp *http.Response) error {
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == authCookie {
+				c.token = cookie.Value
+				return nil
+			}
+		}
+		return fmt.Errorf("could not log in as '%s': the server did not send a token", username)
+	})
+}
+
+// Logout invalidates the token of the client
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/logout", nil, nil); err != nil {
+		return err
+	}
+	c.token = ""
+	return nil
+}
+
+// Token returns the token used to authenticate the requests, ie. to create another client WithToken
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Post sends the body as JSON to the path and decodes the response into result, if result is not nil
+func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	payload, err := marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path, payload, decode(result))
+}
+
+// Put sends the body as JSON to the path and decodes the response into result, if result is not nil
+func (c *Client) Put(ctx context.Context, path string, body interface{}, result interface{}) error {
+	payload, err := marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPut, path, payload, decode(result))
+}
+
+// Get retrieves the path and decodes the response into result
+func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, decode(result))
+}
+
+// Delete deletes the item at the path
+func (c *Client) Delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// Download retrieves the path and copies the response, ie. a file, to w
+func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
+	return c.do(ctx, http.MethodGet, path, nil, func(resp *http.Response) error {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	})
+}
+
+// decode returns a function which decodes a JSON response into result, if result is not nil
+func decode(result interface{}) func(resp *http.Response) error {
+	if result == nil {
+		return nil
+	}
+	return func(resp *http.Response) error {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return unmarshal(data, result)
+	}
+}
+
+// marshal encodes the value as JSON. The API types are encoded with protojson, as the server expects
+func marshal(value interface{}) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		return protojson.Marshal(message)
+	}
+	return json.Marshal(value)
+}
+
+// unmarshal decodes JSON into the value. The fields unknown to the API types are ignored, so that the client works with newer servers
+func unmarshal(data []byte, value interface{}) error {
+	if message, ok := value.(proto.Message); ok {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+	}
+	return json.Unmarshal(data, value)
+}
+
+// do sends the request, retrying it when the server is unavailable, and hands the response to read, if read is not nil
+func (c *Client) do(ctx context.Context, method string, path string, payload []byte, read func(resp *http.Response) error) error {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, payload)
+		retry := attempt < c.retries && retriable(method) && ctx.Err() == nil && (err != nil || unavailable(resp.StatusCode))
+		if !retry {
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode >= http.StatusBadRequest {
+				return newError(method, path, resp)
+			}
+			if read == nil {
+				return nil
+			}
+			return read(resp)
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.AddCookie(&http.Cookie{Name: authCookie, Value: c.token})
+	}
+	return c.http.Do(req)
+}
+
+// retriable checks if a request with the method can be sent again without side effects
+func retriable(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+// unavailable checks if the status tells that the server could not handle the request for now
+func unavailable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Error is returned when the server responds with an error. It holds the error body sent by the server
+type Error struct {
+	Method string `json:"-"`
+	Path   string `json:"-"`
+	// StatusCode is the HTTP status of the response
+	StatusCode int    `json:"-"`
+	Message    string `json:"error"`
+	Code       int    `json:"code"`
+}
+
+func newError(method string, path string, resp *http.Response) error {
+	e := &Error{}
+	if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
+		e = &Error{}
+	}
+	e.Method = method
+	e.Path = path
+	e.StatusCode = resp.StatusCode
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s returned %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s %s returned %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound checks if the server could not find the requested item
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsBadRequest checks if the server rejected the request, ie. because the item is invalid
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized checks if the server rejected the credentials or the token of the client
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+func hasStatus(err error, status int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == status
+}
@@ -0,0 +1,340 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/http/methods"
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/client"
+)
+
+// sendJSON encodes the value as the server does, with protojson for the API types
+func sendJSON(w http.ResponseWriter, value interface{}, code int) {
+	data, _ := mediatype.MarshalJSON(value)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+func sendScenarios(w http.ResponseWriter, scenarios []*scenariov1.Scenario) {
+	items := make([]interface{}, len(scenarios))
+	for i, scenario := range scenarios {
+		items[i] = scenario
+	}
+	sendJSON(w, &methods.ItemList{Count: len(items), Items: items}, http.StatusOK)
+}
+
+func readJSON(r *http.Request, message proto.Message) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, message)
+}
+
+func sendError(w http.ResponseWriter, message string, code int) {
+	sendJSON(w, map[string]interface{}{"error": message, "code": code}, code)
+}
+
+func TestLogin(t *testing.T) {
+	g := NewWithT(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		credentials := map[string]string{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&credentials)).To(Succeed())
+		if credentials["Password"] != "secret" {
+			sendError(w, "wrong password", http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "auth-token", Value: "token-of-" + credentials["Username"]})
+	})
+	mux.HandleFunc("/api/v1/logout", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("auth-token")
+		g.Expect(err).ShouldNot(HaveOccurred(), "token was not sent to log out")
+		g.Expect(cookie.Value).To(Equal("token-of-tester"))
+	})
+	mux.HandleFunc("/api/v1/projects/", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("auth-token")
+		if err != nil {
+			sendError(w, "not logged in", http.StatusUnauthorized)
+			return
+		}
+		sendJSON(w, &projectv1.Project{Identity: &metadatav1.Identity{Id: "shop"}, Name: cookie.Value}, http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ctx := context.Background()
+
+	c := client.New(server.URL + "/api/v1/")
+	_, err := c.Projects.Get(ctx, "shop")
+	g.Expect(client.IsUnauthorized(err)).To(BeTrue(), "request without token was not rejected")
+
+	err = c.Login(ctx, "tester", "wrong")
+	g.Expect(client.IsUnauthorized(err)).To(BeTrue(), "wrong password was not reported")
+	g.Expect(err.Error()).To(Equal("POST /login returned 401 Unauthorized: wrong password"), "error does not hold the message of the server")
+
+	g.Expect(c.Login(ctx, "tester", "secret")).To(Succeed())
+	g.Expect(c.Token()).To(Equal("token-of-tester"), "token was not kept")
+	project, err := c.Projects.Get(ctx, "shop")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(project.Name).To(Equal("token-of-tester"), "token was not sent")
+
+	project, err = client.New(server.URL+"/api/v1", client.WithToken("token-of-other")).Projects.Get(ctx, "shop")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(project.Name).To(Equal("token-of-other"), "provided token was not sent")
+
+	g.Expect(c.Logout(ctx)).To(Succeed())
+	g.Expect(c.Token()).To(BeEmpty(), "token was kept after logging out")
+}
+
+func TestError(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			sendError(w, "could not find requested item", http.StatusNotFound)
+		case http.MethodPost:
+			sendError(w, "name is a mandatory parameter", http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	c := client.New(server.URL, client.WithRetries(0, 0))
+
+	_, err := c.Scenarios.Get(ctx, "missing")
+	g.Expect(client.IsNotFound(err)).To(BeTrue(), "missing item is not a not found error")
+	e, ok := err.(*client.Error)
+	g.Expect(ok).To(BeTrue(), "error is not a client error")
+	g.Expect(e.StatusCode).To(Equal(http.StatusNotFound))
+	g.Expect(e.Code).To(Equal(http.StatusNotFound), "code of the error body was not decoded")
+	g.Expect(e.Message).To(Equal("could not find requested item"), "message of the error body was not decoded")
+	g.Expect(e.Path).To(Equal("/scenarios/missing"))
+
+	_, err = c.Scenarios.Create(ctx, &scenariov1.Scenario{})
+	g.Expect(client.IsBadRequest(err)).To(BeTrue(), "invalid item is not a bad request")
+
+	err = c.Scenarios.Delete(ctx, "login")
+	g.Expect(err).Should(HaveOccurred(), "error without body was not reported")
+	g.Expect(err.Error()).To(Equal("DELETE /scenarios/login returned 500 Internal Server Error"))
+}
+
+func TestRetries(t *testing.T) {
+	g := NewWithT(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		sendJSON(w, &projectv1.Project{Name: "shop"}, http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	project, err := client.New(server.URL, client.WithRetries(2, time.Millisecond)).Projects.Get(ctx, "shop")
+	g.Expect(err).ShouldNot(HaveOccurred(), "request was not retried")
+	g.Expect(project.Name).To(Equal("shop"))
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)), "request was not retried until it succeeded")
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = client.New(server.URL, client.WithRetries(1, time.Millisecond)).Projects.Get(ctx, "shop")
+	g.Expect(err).Should(HaveOccurred(), "request was retried more than requested")
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(2)))
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = client.New(server.URL, client.WithRetries(2, time.Millisecond)).Projects.Create(ctx, &projectv1.Project{Name: "shop"})
+	g.Expect(err).Should(HaveOccurred(), "unavailable server was not reported")
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)), "POST request was retried")
+
+	atomic.StoreInt32(&calls, 0)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.New(server.URL, client.WithRetries(2, time.Hour)).Projects.Get(cancelled, "shop")
+	g.Expect(err).Should(HaveOccurred(), "cancelled request succeeded")
+	g.Expect(atomic.LoadInt32(&calls)).To(Equal(int32(0)), "cancelled request was sent")
+}
+
+func TestCRUD(t *testing.T) {
+	g := NewWithT(t)
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.Method == http.MethodDelete {
+			return
+		}
+		execution := &executionv1.Execution{}
+		if r.Body != nil && r.Method != http.MethodGet {
+			g.Expect(readJSON(r, execution)).To(Succeed())
+		}
+		execution.Identity = &metadatav1.Identity{Id: "run1"}
+		sendJSON(w, execution, http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	c := client.New(server.URL)
+
+	created, err := c.Executions.Create(ctx, &executionv1.Execution{ScenarioId: "login", Status: executionv1.Status_Fail})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.Identity.Id).To(Equal("run1"), "created execution was not decoded")
+	g.Expect(created.Status).To(Equal(executionv1.Status_Fail), "execution was not sent")
+
+	updated, err := c.Executions.Update(ctx, "run1", &executionv1.Execution{Status: executionv1.Status_Pass})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.Status).To(Equal(executionv1.Status_Pass), "execution was not updated")
+
+	_, err = c.Scenarios.ByAutomationKey(ctx, "shop", "cart.TestAdd/empty cart")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(c.TestPlans.Delete(ctx, "smoke/1")).To(Succeed())
+
+	g.Expect(requests).To(Equal([]string{
+		"POST /executions",
+		"PUT /executions/run1",
+		"GET /scenarios/automation/find?key=cart.TestAdd%2Fempty+cart&projectId=shop",
+		"DELETE /testplans/smoke%2F1",
+	}), "requests did not match")
+}
+
+func TestList(t *testing.T) {
+	g := NewWithT(t)
+	ids := []string{"e", "a", "d", "c", "b"}
+	sort.Strings(ids)
+	queries := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query.Get("lastValue"))
+		g.Expect(r.URL.Path).To(Equal("/scenarios"))
+		g.Expect(query.Get("sortBy")).To(Equal("identity.id:asc"), "items are not sorted by ID")
+		g.Expect(query["projectid"]).To(Equal([]string{"shop"}), "filter was not sent")
+		count, _ := strconv.Atoi(query.Get("count"))
+		items := []*scenariov1.Scenario{}
+		for _, id := range ids {
+			if id > query.Get("lastValue") && len(items) < count {
+				items = append(items, &scenariov1.Scenario{Identity: &metadatav1.Identity{Id: id}, Name: "scenario " + id})
+			}
+		}
+		sendScenarios(w, items)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	iterator := client.New(server.URL).Scenarios.List(ctx, &client.ListOptions{Filter: map[string][]string{"projectid": {"shop"}}, PageSize: 2})
+	names := []string{}
+	for iterator.Next() {
+		names = append(names, iterator.Scenario().Name)
+	}
+	g.Expect(iterator.Err()).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(names).To(Equal([]string{"scenario a", "scenario b", "scenario c", "scenario d", "scenario e"}), "not all the pages were retrieved")
+	g.Expect(queries).To(Equal([]string{"", "b", "d"}), "pages do not start after the last item of the previous page")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, "database is down", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	scenarios, err := client.New(failing.URL).Scenarios.List(ctx, nil).All()
+	g.Expect(err).Should(HaveOccurred(), "error of a page was not reported")
+	g.Expect(scenarios).To(BeEmpty())
+}
+
+func TestDownload(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# Smoke\n"))
+	}))
+	defer server.Close()
+	out := &bytes.Buffer{}
+	g.Expect(client.New(server.URL).Download(context.Background(), "/testplans/smoke/report/markdown", out)).To(Succeed())
+	g.Expect(out.String()).To(Equal("# Smoke\n"), "file was not copied")
+}
+
+func TestApply(t *testing.T) {
+	g := NewWithT(t)
+	stored := map[string]*scenariov1.Scenario{
+		"login": {Identity: &metadatav1.Identity{Id: "login"}, ProjectId: "shop", Name: "Login", Description: "log in", State: scenariov1.State_Approved},
+		"cart1": {Identity: &metadatav1.Identity{Id: "cart1"}, ProjectId: "shop", Name: "Cart"},
+		"cart2": {Identity: &metadatav1.Identity{Id: "cart2"}, ProjectId: "shop", Name: "Cart"},
+	}
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scenarios":
+			items := []*scenariov1.Scenario{}
+			if r.URL.Query().Get("lastValue") == "" {
+				for _, id := range []string{"cart1", "cart2", "login"} {
+					if stored[id].Name == r.URL.Query().Get("name") && stored[id].ProjectId == r.URL.Query().Get("projectid") {
+						items = append(items, stored[id])
+					}
+				}
+			}
+			sendScenarios(w, items)
+		case r.Method == http.MethodGet:
+			scenario, ok := stored[r.URL.Path[len("/scenarios/"):]]
+			if !ok {
+				sendError(w, "could not find requested item", http.StatusNotFound)
+				return
+			}
+			sendJSON(w, scenario, http.StatusOK)
+		default:
+			scenario := &scenariov1.Scenario{}
+			g.Expect(readJSON(r, scenario)).To(Succeed())
+			scenario.Identity = &metadatav1.Identity{Id: "new"}
+			sendJSON(w, scenario, http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	c := client.New(server.URL)
+
+	applied, result, err := c.Scenarios.Apply(ctx, &scenariov1.Scenario{ProjectId: "shop", Name: "Login", Description: "log in"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result).To(Equal(client.Unchanged), "matching scenario was updated")
+	g.Expect(applied.Identity.Id).To(Equal("login"))
+
+	_, result, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{ProjectId: "shop", Name: "Login", Description: "log in with a password"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result).To(Equal(client.Updated), "scenario with the same name was not updated")
+
+	_, result, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{Identity: &metadatav1.Identity{Id: "cart2"}, ProjectId: "shop", Name: "Cart", Labels: []string{"smoke"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result).To(Equal(client.Updated), "scenario with the ID was not updated")
+
+	applied, result, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{ProjectId: "shop", Name: "Checkout"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result).To(Equal(client.Created), "new scenario was not created")
+	g.Expect(applied.Identity.Id).To(Equal("new"))
+
+	_, _, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{ProjectId: "shop", Name: "Cart"})
+	g.Expect(err).Should(HaveOccurred(), "ambiguous name was not reported")
+	_, _, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{Name: "Cart"})
+	g.Expect(err).Should(HaveOccurred(), "scenario without project was not reported")
+	_, _, err = c.Scenarios.Apply(ctx, &scenariov1.Scenario{Identity: &metadatav1.Identity{Id: "missing"}, ProjectId: "shop", Name: "Cart"})
+	g.Expect(client.IsNotFound(err)).To(BeTrue(), "missing scenario was not reported")
+
+	g.Expect(requests).To(Equal([]string{
+		"PUT /scenarios/login",
+		"PUT /scenarios/cart2",
+		"POST /scenarios",
+	}), "requests did not match")
+}
@@ -0,0 +1,428 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+)
+
+// DefaultPageSize is the number of items retrieved with every request of a list
+const DefaultPageSize = 100
+
+// ListOptions select the items of a list
+type ListOptions struct {
+	// Filter is matched against the fields of the items, keyed by lowercased field name, ie. `projectid`
+	Filter map[string][]string
+	// PageSize is the number of items retrieved with every request. Defaults to DefaultPageSize
+	PageSize int
+}
+
+// Projects is used to manage projects
+type Projects struct {
+	client *Client
+}
+
+// Create creates a project and returns it as stored by the server
+func (p *Projects) Create(ctx context.Context, project *projectv1.Project) (*projectv1.Project, error) {
+	created := &projectv1.Project{}
+	if err := p.client.Post(ctx, "/projects", project, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Get returns the project with the ID
+func (p *Projects) Get(ctx context.Context, id string) (*projectv1.Project, error) {
+	project := &projectv1.Project{}
+	if err := p.client.Get(ctx, "/projects/"+url.PathEscape(id), project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Update replaces the project with the ID
+func (p *Projects) Update(ctx context.Context, id string, project *projectv1.Project) (*projectv1.Project, error) {
+	updated := &projectv1.Project{}
+	if err := p.client.Put(ctx, "/projects/"+url.PathEscape(id), project, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete deletes the project with the ID
+func (p *Projects) Delete(ctx context.Context, id string) error {
+	return p.client.Delete(ctx, "/projects/"+url.PathEscape(id))
+}
+
+// List returns an iterator over the projects
+func (p *Projects) List(ctx context.Context, options *ListOptions) *ProjectIterator {
+	return &ProjectIterator{pages: newPager(ctx, p.client, "/projects", options)}
+}
+
+// ProjectIterator iterates over a list of projects, retrieving them page by page
+type ProjectIterator struct {
+	pages   *pager
+	current *projectv1.Project
+}
+
+// Next retrieves the next project, it returns false at the end of the list or when an error occurred
+func (i *ProjectIterator) Next() bool {
+	i.current = &projectv1.Project{}
+	return i.pages.next(i.current)
+}
+
+// Project returns the project retrieved by Next
+func (i *ProjectIterator) Project() *projectv1.Project {
+	return i.current
+}
+
+// Err returns the error that stopped the iteration
+func (i *ProjectIterator) Err() error {
+	return i.pages.err
+}
+
+// All returns the remaining projects of the list
+func (i *ProjectIterator) All() ([]*projectv1.Project, error) {
+	projects := []*projectv1.Project{}
+	for i.Next() {
+		projects = append(projects, i.Project())
+	}
+	return projects, i.Err()
+}
+
+// Scenarios is used to manage scenarios
+type Scenarios struct {
+	client *Client
+}
+
+// Create creates a scenario and returns it as stored by the server
+func (s *Scenarios) Create(ctx context.Context, scenario *scenariov1.Scenario) (*scenariov1.Scenario, error) {
+	created := &scenariov1.Scenario{}
+	if err := s.client.Post(ctx, "/scenarios", scenario, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Get returns the scenario with the ID
+func (s *Scenarios) Get(ctx context.Context, id string) (*scenariov1.Scenario, error) {
+	scenario := &scenariov1.Scenario{}
+	if err := s.client.Get(ctx, "/scenarios/"+url.PathEscape(id), scenario); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// Update replaces the scenario with the ID
+func (s *Scenarios) Update(ctx context.Context, id string, scenario *scenariov1.Scenario) (*scenariov1.Scenario, error) {
+	updated := &scenariov1.Scenario{}
+	if err := s.client.Put(ctx, "/scenarios/"+url.PathEscape(id), scenario, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete deletes the scenario with the ID
+func (s *Scenarios) Delete(ctx context.Context, id string) error {
+	return s.client.Delete(ctx, "/scenarios/"+url.PathEscape(id))
+}
+
+// ByAutomationKey returns the scenario of the project that has the automation key
+func (s *Scenarios) ByAutomationKey(ctx context.Context, projectID string, key string) (*scenariov1.Scenario, error) {
+	query := url.Values{"projectId": {projectID}, "key": {key}}
+	scenario := &scenariov1.Scenario{}
+	if err := s.client.Get(ctx, "/scenarios/automation/find?"+query.Encode(), scenario); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// List returns an iterator over the scenarios
+func (s *Scenarios) List(ctx context.Context, options *ListOptions) *ScenarioIterator {
+	return &ScenarioIterator{pages: newPager(ctx, s.client, "/scenarios", options)}
+}
+
+// ScenarioIterator iterates over a list of scenarios, retrieving them page by page
+type ScenarioIterator struct {
+	pages   *pager
+	current *scenariov1.Scenario
+}
+
+// Next retrieves the next scenario, it returns false at the end of the list or when an error occurred
+func (i *ScenarioIterator) Next() bool {
+	i.current = &scenariov1.Scenario{}
+	return i.pages.next(i.current)
+}
+
+// Scenario returns the scenario retrieved by Next
+func (i *ScenarioIterator) Scenario() *scenariov1.Scenario {
+	return i.current
+}
+
+// Err returns the error that stopped the iteration
+func (i *ScenarioIterator) Err() error {
+	return i.pages.err
+}
+
+// All returns the remaining scenarios of the list
+func (i *ScenarioIterator) All() ([]*scenariov1.Scenario, error) {
+	scenarios := []*scenariov1.Scenario{}
+	for i.Next() {
+		scenarios = append(scenarios, i.Scenario())
+	}
+	return scenarios, i.Err()
+}
+
+// TestPlans is used to manage test plans
+type TestPlans struct {
+	client *Client
+}
+
+// Create creates a test plan and returns it as stored by the server
+func (t *TestPlans) Create(ctx context.Context, testplan *testplanv1.TestPlan) (*testplanv1.TestPlan, error) {
+	created := &testplanv1.TestPlan{}
+	if err := t.client.Post(ctx, "/testplans", testplan, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Get returns the test plan with the ID
+func (t *TestPlans) Get(ctx context.Context, id string) (*testplanv1.TestPlan, error) {
+	testplan := &testplanv1.TestPlan{}
+	if err := t.client.Get(ctx, "/testplans/"+url.PathEscape(id), testplan); err != nil {
+		return nil, err
+	}
+	return testplan, nil
+}
+
+// Update replaces the test plan with the ID
+func (t *TestPlans) Update(ctx context.Context, id string, testplan *testplanv1.TestPlan) (*testplanv1.TestPlan, error) {
+	updated := &testplanv1.TestPlan{}
+	if err := t.client.Put(ctx, "/testplans/"+url.PathEscape(id), testplan, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete deletes the test plan with the ID
+func (t *TestPlans) Delete(ctx context.Context, id string) error {
+	return t.client.Delete(ctx, "/testplans/"+url.PathEscape(id))
+}
+
+// Run creates the executions of a run of the test plan with the ID
+func (t *TestPlans) Run(ctx context.Context, id string, request *executionv1.RunRequest) (*executionv1.Run, error) {
+	run := &executionv1.Run{}
+	if err := t.client.Post(ctx, "/testplans/"+url.PathEscape(id)+"/runs", request, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Summary counts the results of the executions of the test plan with the ID for every configuration
+func (t *TestPlans) Summary(ctx context.Context, id string) (*testplanv1.Summary, error) {
+	summary := &testplanv1.Summary{}
+	if err := t.client.Get(ctx, "/testplans/"+url.PathEscape(id)+"/summary", summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ReportResults creates executions in the test plan with the ID from the report of an automated test run.
+// The format is one of the formats accepted by the server, ie. junit or go-test.
+func (t *TestPlans) ReportResults(ctx context.Context, id string, format string, request *executionv1.ReportRequest) (*executionv1.Report, error) {
+	report := &executionv1.Report{}
+	if err := t.client.Post(ctx, "/testplans/"+url.PathEscape(id)+"/results/"+url.PathEscape(format), request, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// List returns an iterator over the test plans
+func (t *TestPlans) List(ctx context.Context, options *ListOptions) *TestPlanIterator {
+	return &TestPlanIterator{pages: newPager(ctx, t.client, "/testplans", options)}
+}
+
+// TestPlanIterator iterates over a list of test plans, retrieving them page by page
+type TestPlanIterator struct {
+	pages   *pager
+	current *testplanv1.TestPlan
+}
+
+// Next retrieves the next test plan, it returns false at the end of the list or when an error occurred
+func (i *TestPlanIterator) Next() bool {
+	i.current = &testplanv1.TestPlan{}
+	return i.pages.next(i.current)
+}
+
+// TestPlan returns the test plan retrieved by Next
+func (i *TestPlanIterator) TestPlan() *testplanv1.TestPlan {
+	return i.current
+}
+
+// Err returns the error that stopped the iteration
+func (i *TestPlanIterator) Err() error {
+	return i.pages.err
+}
+
+// All returns the remaining test plans of the list
+func (i *TestPlanIterator) All() ([]*testplanv1.TestPlan, error) {
+	testplans := []*testplanv1.TestPlan{}
+	for i.Next() {
+		testplans = append(testplans, i.TestPlan())
+	}
+	return testplans, i.Err()
+}
+
+// Executions is used to manage executions. Executions cannot be deleted
+type Executions struct {
+	client *Client
+}
+
+// Create creates an execution and returns it as stored by the server
+func (e *Executions) Create(ctx context.Context, execution *executionv1.Execution) (*executionv1.Execution, error) {
+	created := &executionv1.Execution{}
+	if err := e.client.Post(ctx, "/executions", execution, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Get returns the execution with the ID
+func (e *Executions) Get(ctx context.Context, id string) (*executionv1.Execution, error) {
+	execution := &executionv1.Execution{}
+	if err := e.client.Get(ctx, "/executions/"+url.PathEscape(id), execution); err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// Update replaces the execution with the ID
+func (e *Executions) Update(ctx context.Context, id string, execution *executionv1.Execution) (*executionv1.Execution, error) {
+	updated := &executionv1.Execution{}
+	if err := e.client.Put(ctx, "/executions/"+url.PathEscape(id), execution, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// List returns an iterator over the executions
+func (e *Executions) List(ctx context.Context, options *ListOptions) *ExecutionIterator {
+	return &ExecutionIterator{pages: newPager(ctx, e.client, "/executions", options)}
+}
+
+// ExecutionIterator iterates over a list of executions, retrieving them page by page
+type ExecutionIterator struct {
+	pages   *pager
+	current *executionv1.Execution
+}
+
+// Next retrieves the next execution, it returns false at the end of the list or when an error occurred
+func (i *ExecutionIterator) Next() bool {
+	i.current = &executionv1.Execution{}
+	return i.pages.next(i.current)
+}
+
+// Execution returns the execution retrieved by Next
+func (i *ExecutionIterator) Execution() *executionv1.Execution {
+	return i.current
+}
+
+// Err returns the error that stopped the iteration
+func (i *ExecutionIterator) Err() error {
+	return i.pages.err
+}
+
+// All returns the remaining executions of the list
+func (i *ExecutionIterator) All() ([]*executionv1.Execution, error) {
+	executions := []*executionv1.Execution{}
+	for i.Next() {
+		executions = append(executions, i.Execution())
+	}
+	return executions, i.Err()
+}
+
+// pager retrieves the pages of a list. The items are sorted by ID and a page starts after the last ID of the previous one
+type pager struct {
+	ctx      context.Context
+	client   *Client
+	path     string
+	filter   map[string][]string
+	pageSize int
+	items    []json.RawMessage
+	lastID   string
+	done     bool
+	err      error
+}
+
+func newPager(ctx context.Context, client *Client, path string, options *ListOptions) *pager {
+	p := &pager{ctx: ctx, client: client, path: path, pageSize: DefaultPageSize}
+	if options != nil {
+		p.filter = options.Filter
+		if options.PageSize > 0 {
+			p.pageSize = options.PageSize
+		}
+	}
+	return p
+}
+
+// next decodes the next item of the list into item, retrieving the next page when needed
+func (p *pager) next(item interface{}) bool {
+	for len(p.items) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.err = p.fetch()
+	}
+	raw := p.items[0]
+	p.items = p.items[1:]
+	if err := unmarshal(raw, item); err != nil {
+		p.err = err
+		return false
+	}
+	return true
+}
+
+func (p *pager) fetch() error {
+	query := url.Values{}
+	for key, values := range p.filter {
+		query[key] = values
+	}
+	query.Set("sortBy", "identity.id:asc")
+	query.Set("count", strconv.Itoa(p.pageSize))
+	if p.lastID != "" {
+		query.Set("lastValue", p.lastID)
+	}
+	page := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if err := p.client.Get(p.ctx, p.path+"?"+query.Encode(), &page); err != nil {
+		return err
+	}
+	if len(page.Items) < p.pageSize {
+		p.done = true
+	}
+	if len(page.Items) != 0 {
+		last := struct {
+			Identity struct {
+				ID string `json:"id"`
+			} `json:"identity"`
+		}{}
+		if err := json.Unmarshal(page.Items[len(page.Items)-1], &last); err != nil {
+			return err
+		}
+		if last.Identity.ID == "" {
+			// without an ID the next page cannot be requested
+			p.done = true
+		}
+		p.lastID = last.Identity.ID
+	}
+	p.items = page.Items
+	return nil
+}
@@ -0,0 +1,126 @@
+package cucumber
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/gherkin"
+)
+
+type feature struct {
+	URI      string    `json:"uri"`
+	Name     string    `json:"name"`
+	Elements []element `json:"elements"`
+}
+
+type element struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Before []step `json:"before"`
+	Steps  []step `json:"steps"`
+	After  []step `json:"after"`
+}
+
+type step struct {
+	Name   string `json:"name"`
+	Result result `json:"result"`
+}
+
+type result struct {
+	Status       string  `json:"status"`
+	Duration     float64 `json:"duration"`
+	ErrorMessage string  `json:"error_message"`
+}
+
+// statuses maps the Cucumber statuses to execution statuses. Undefined, pending and ambiguous steps were not run
+var statuses = map[string]executionv1.Status{
+	"passed":    executionv1.Status_Pass,
+	"failed":    executionv1.Status_Fail,
+	"skipped":   executionv1.Status_Skipped,
+	"undefined": executionv1.Status_Skipped,
+	"pending":   executionv1.Status_Skipped,
+	"ambiguous": executionv1.Status_Skipped,
+}
+
+// Parse reads the scenarios of a Cucumber JSON report. Every scenario, or example of a scenario outline, is a result.
+//
+// The automation key of a scenario is `uri:name`, see gherkin.AutomationKey, which is the automation key set on the scenarios
+// imported from the feature file.
+// The results of the Gherkin steps are kept as step results. A scenario fails when one of its steps or hooks failed,
+// is skipped when one of its steps was not run and passes otherwise.
+func Parse(content io.Reader) ([]*executionv1.TestResult, error) {
+	features := []feature{}
+	if err := json.NewDecoder(content).Decode(&features); err != nil {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid Cucumber report: %s", err))
+	}
+	results := []*executionv1.TestResult{}
+	for _, f := range features {
+		// some implementations report the background as a separate element before each scenario
+		var background []step
+		for _, e := range f.Elements {
+			if e.Type == "background" {
+				background = e.Steps
+				continue
+			}
+			converted, err := convert(f.URI, e, background)
+			if err != nil {
+				return nil, err
+			}
+			background = nil
+			results = append(results, converted)
+		}
+	}
+	return results, nil
+}
+
+func convert(uri string, e element, background []step) (*executionv1.TestResult, error) {
+	converted := &executionv1.TestResult{
+		Key:    gherkin.AutomationKey(uri, e.Name),
+		Name:   e.Name,
+		Status: executionv1.Status_Pass,
+	}
+	var failures []string
+	record := func(s step, gherkin bool) error {
+		status, ok := statuses[s.Result.Status]
+		if !ok {
+			return decoder.NewValidationError(fmt.Sprintf("invalid Cucumber report: unknown status '%s' of step '%s' in scenario '%s'", s.Result.Status, s.Name, e.Name))
+		}
+		// durations are reported in nanoseconds
+		converted.Duration += s.Result.Duration / 1e9
+		message := strings.TrimSpace(s.Result.ErrorMessage)
+		switch {
+		case status == executionv1.Status_Fail:
+			converted.Status = executionv1.Status_Fail
+			if message != "" {
+				failures = append(failures, message)
+			}
+		case status == executionv1.Status_Skipped && converted.Status == executionv1.Status_Pass:
+			converted.Status = executionv1.Status_Skipped
+		}
+		if gherkin {
+			converted.Steps = append(converted.Steps, &executionv1.StepResult{Text: s.Name, Status: status, ActualResult: message})
+		}
+		return nil
+	}
+	for _, s := range e.Before {
+		if err := record(s, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range append(append([]step{}, background...), e.Steps...) {
+		if err := record(s, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range e.After {
+		if err := record(s, false); err != nil {
+			return nil, err
+		}
+	}
+	converted.ActualResult = strings.Join(failures, "\n")
+	return converted, nil
+}
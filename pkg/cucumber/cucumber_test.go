@@ -0,0 +1,118 @@
+package cucumber_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/cucumber"
+	"github.com/curious-kitten/scratch-post/pkg/gherkin"
+)
+
+const report = `[
+  {
+    "uri": "features/login.feature",
+    "name": "Login",
+    "elements": [
+      {
+        "type": "background",
+        "name": "",
+        "steps": [
+          {"keyword": "Given ", "name": "the login page is open", "result": {"status": "passed", "duration": 1000000000}}
+        ]
+      },
+      {
+        "type": "scenario",
+        "name": "Successful login",
+        "steps": [
+          {"keyword": "When ", "name": "the user logs in as john", "result": {"status": "passed", "duration": 500000000}},
+          {"keyword": "Then ", "name": "the dashboard is shown", "result": {"status": "failed", "duration": 500000000, "error_message": "dashboard not found"}}
+        ]
+      },
+      {
+        "type": "scenario",
+        "name": "Logout",
+        "before": [
+          {"result": {"status": "passed"}}
+        ],
+        "steps": [
+          {"keyword": "When ", "name": "the user logs out", "result": {"status": "undefined"}}
+        ]
+      },
+      {
+        "type": "scenario",
+        "name": "Remember me",
+        "steps": [
+          {"keyword": "When ", "name": "the user logs in", "result": {"status": "passed"}}
+        ],
+        "after": [
+          {"result": {"status": "failed", "error_message": "screenshot failed"}}
+        ]
+      }
+    ]
+  }
+]`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	results, err := cucumber.Parse(strings.NewReader(report))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(results).To(HaveLen(3), "every scenario is not a result")
+
+	login := results[0]
+	g.Expect(login.Key).To(Equal("features/login.feature:Successful login"), "key is not the uri and the scenario name")
+	g.Expect(login.Name).To(Equal("Successful login"))
+	g.Expect(login.Status).To(Equal(execution.Status_Fail), "scenario with a failed step did not fail")
+	g.Expect(login.Duration).To(Equal(2.0), "duration is not the sum of the steps in seconds")
+	g.Expect(login.ActualResult).To(Equal("dashboard not found"))
+	g.Expect(login.Steps).To(HaveLen(3), "background steps were not included")
+	g.Expect(login.Steps[0].Text).To(Equal("the login page is open"))
+	g.Expect(login.Steps[0].Status).To(Equal(execution.Status_Pass))
+	g.Expect(login.Steps[2].Status).To(Equal(execution.Status_Fail))
+	g.Expect(login.Steps[2].ActualResult).To(Equal("dashboard not found"))
+
+	g.Expect(results[1].Status).To(Equal(execution.Status_Skipped), "scenario with an undefined step was not skipped")
+	g.Expect(results[1].Steps).To(HaveLen(1), "hooks are step results or the background was used twice")
+
+	g.Expect(results[2].Status).To(Equal(execution.Status_Fail), "scenario with a failed hook did not fail")
+	g.Expect(results[2].ActualResult).To(Equal("screenshot failed"))
+	g.Expect(results[2].Steps).To(HaveLen(1))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := cucumber.Parse(strings.NewReader(`{"uri":`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid report is not a validation error")
+
+	_, err = cucumber.Parse(strings.NewReader(`[{"uri":"a.feature","elements":[{"type":"scenario","name":"a","steps":[{"name":"b","result":{"status":"unknown"}}]}]}]`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "unknown status is not a validation error")
+}
+
+const loginFeature = `Feature: Login
+  Background:
+    Given the login page is open
+
+  Scenario: Successful login
+    When the user logs in as john
+    Then the dashboard is shown
+`
+
+func TestParse_ImportedFeature(t *testing.T) {
+	g := NewWithT(t)
+	scenarios, err := gherkin.Parse("features/login.feature", strings.NewReader(loginFeature))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	results, err := cucumber.Parse(strings.NewReader(report))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(scenarios[0].AutomationKeys).To(ContainElement(results[0].Key), "result does not match the imported scenario")
+
+	e := &execution.Execution{}
+	g.Expect(e.PopulateSteps(scenarios[0].Steps, nil)).To(Succeed())
+	e.Record(results[0])
+	g.Expect(e.Status).To(Equal(execution.Status_Fail))
+	g.Expect(e.Steps).To(HaveLen(1), "the background is not a prerequisite")
+	g.Expect(e.Steps[0].Status).To(Equal(execution.Status_Fail), "step results were not recorded on the imported step")
+	g.Expect(e.Steps[0].ActualResult).To(Equal("dashboard not found"))
+}
@@ -1,21 +1,48 @@
 package executions
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
 	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
 	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/spreadsheet"
 )
 
 //go:generate mockgen -source ./executions.go -destination mocks/executions.go
 
+const (
+	// IssueFilterKey is the filter used to find executions by the link of their issues
+	IssueFilterKey = "issues.link"
+	// StepIssueFilterKey is the filter used to find executions by the link of the issues of their steps
+	StepIssueFilterKey = "steps.issues.link"
+	// ReleaseFilterKey is the filter used to find the executions of a release
+	ReleaseFilterKey = "releaseid"
+	// ProjectFilterKey is the filter used to find the executions of a project
+	ProjectFilterKey = "projectid"
+	// TestPlanFilterKey is the filter used to find the executions of a test plan
+	TestPlanFilterKey = "testplanid"
+	// ScenarioFilterKey is the filter used to find the executions of a scenario
+	ScenarioFilterKey = "scenarioid"
+)
+
 type getItem func(ctx context.Context, id string) (interface{}, error)
+type getStepBlocks func(ctx context.Context, projectID string, ids []string) (map[string][]*scenariov1.Step, error)
+type releaseChecker func(ctx context.Context, projectID string, id string) error
+type automatedScenarioFinder func(ctx context.Context, projectID string, key string) (*scenariov1.Scenario, error)
+type automatedScenarioCreator func(ctx context.Context, user string, projectID string, keys []string) ([]*scenariov1.Scenario, error)
+type resultParser func(content io.Reader) ([]*executionv1.TestResult, error)
 
 // Adder is used to add items to the store
 type Adder interface {
@@ -45,45 +72,224 @@ type ReaderUpdater interface {
 	Updater
 }
 
-// New returns a function used to create an execution
-func New(meta MetaHandler, collection Adder, getProject getItem, getScenario getItem, getTestPlan getItem) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+// New returns a function used to create an execution.
+// Only approved scenarios can be executed. An execution without a release is attached to the release of its test plan.
+// Steps of the scenario that reference a step block are replaced with the current steps of the block.
+// The configuration of the execution has to be part of the matrix of the test plan.
+func New(meta MetaHandler, collection Adder, getProject getItem, getScenario getItem, getTestPlan getItem, getStepBlocks getStepBlocks, inProjectRelease releaseChecker) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 		execution := &executionv1.Execution{}
 		if err := decoder.Decode(execution, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, execution.ProjectId); err != nil {
+		fields, err := customFields(ctx, getProject, execution.ProjectId)
+		if err != nil {
 			return nil, err
 		}
-		_, err := getTestPlan(ctx, execution.TestPlanId)
-		if err != nil {
+		if execution.CustomFields, err = customfieldv1.Apply(fields, execution.CustomFields); err != nil {
 			return nil, err
 		}
-		raw, err := getScenario(ctx, execution.ScenarioId)
+		testplan, err := testPlan(ctx, getTestPlan, execution.TestPlanId)
 		if err != nil {
 			return nil, err
 		}
-
-		scenario, ok := raw.(*scenariov1.Scenario)
-		if !ok {
-			return nil, fmt.Errorf("invalid DB entry for scenario %s", execution.ScenarioId)
+		if err := testplan.ValidateConfiguration(execution.Configuration); err != nil {
+			return nil, err
+		}
+		if err := prepare(ctx, meta, author, execution, testplan, getScenario, getStepBlocks, inProjectRelease); err != nil {
+			return nil, err
+		}
+		if err := collection.AddOne(ctx, execution); err != nil {
+			return nil, err
 		}
 
-		identity, err := meta.NewMeta(author, "execution")
+		return execution, nil
+	}
+}
+
+// Run returns a function used to start a run of a test plan. An execution is created for each scenario on each configuration of the test plan.
+// The executions are only stored when all of them could be created.
+func Run(meta MetaHandler, collection Adder, getProject getItem, getScenario getItem, getTestPlan getItem, getStepBlocks getStepBlocks, inProjectRelease releaseChecker) func(ctx context.Context, author string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, id string, data io.Reader) (interface{}, error) {
+		request := &executionv1.RunRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		testplan, err := testPlan(ctx, getTestPlan, id)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := customFields(ctx, getProject, testplan.ProjectId)
+		if err != nil {
+			return nil, err
+		}
+		values, err := customfieldv1.Apply(fields, request.CustomFields)
 		if err != nil {
 			return nil, err
 		}
-		execution.Identity = identity
+		configurations := testplan.Configurations()
+		if len(configurations) == 0 {
+			configurations = []map[string]string{nil}
+		}
+		run := &executionv1.Run{}
+		for _, scenarioID := range request.ScenarioIds {
+			for _, configuration := range configurations {
+				execution := &executionv1.Execution{
+					ProjectId:     testplan.ProjectId,
+					ScenarioId:    scenarioID,
+					TestPlanId:    id,
+					ReleaseId:     request.ReleaseId,
+					Configuration: configuration,
+				}
+				for name, value := range values {
+					if execution.CustomFields == nil {
+						execution.CustomFields = map[string]*customfieldv1.Value{}
+					}
+					execution.CustomFields[name] = proto.Clone(value).(*customfieldv1.Value)
+				}
+				if err := prepare(ctx, meta, author, execution, testplan, getScenario, getStepBlocks, inProjectRelease); err != nil {
+					return nil, err
+				}
+				run.Executions = append(run.Executions, execution)
+			}
+		}
+		for _, execution := range run.Executions {
+			if err := collection.AddOne(ctx, execution); err != nil {
+				return nil, err
+			}
+		}
+		return run, nil
+	}
+}
 
-		execution.PopulateSteps(scenario.Steps)
-		execution.Status = executionv1.Status_Pending
-		fmt.Println(execution.Identity)
-		if err := collection.AddOne(ctx, execution); err != nil {
+// prepare attaches the execution to a release, populates its steps from the approved scenario and gives it an identity
+func prepare(ctx context.Context, meta MetaHandler, author string, execution *executionv1.Execution, testplan *testplanv1.TestPlan, getScenario getItem, getStepBlocks getStepBlocks, inProjectRelease releaseChecker) error {
+	raw, err := getScenario(ctx, execution.ScenarioId)
+	if err != nil {
+		return err
+	}
+
+	scenario, ok := raw.(*scenariov1.Scenario)
+	if !ok {
+		return fmt.Errorf("invalid DB entry for scenario %s", execution.ScenarioId)
+	}
+	if scenario.State != scenariov1.State_Approved {
+		return decoder.NewValidationError(fmt.Sprintf("scenario '%s' has to be %s before it is executed", execution.ScenarioId, scenariov1.State_Approved))
+	}
+	return populate(ctx, meta, author, execution, testplan, scenario, getStepBlocks, inProjectRelease)
+}
+
+// populate checks that the scenario and the test plan are part of the project of the execution, attaches it to a release, populates its steps from the scenario and gives it an identity
+func populate(ctx context.Context, meta MetaHandler, author string, execution *executionv1.Execution, testplan *testplanv1.TestPlan, scenario *scenariov1.Scenario, getStepBlocks getStepBlocks, inProjectRelease releaseChecker) error {
+	if execution.ProjectId != testplan.ProjectId {
+		return decoder.NewValidationError(fmt.Sprintf("test plan '%s' is not part of project '%s'", testplan.GetIdentity().GetId(), execution.ProjectId))
+	}
+	if scenario.ProjectId != testplan.ProjectId {
+		return decoder.NewValidationError(fmt.Sprintf("scenario '%s' is not part of project '%s' of the test plan", execution.ScenarioId, testplan.ProjectId))
+	}
+	if execution.ReleaseId == "" {
+		execution.ReleaseId = testplan.ReleaseId
+	}
+	if err := inProjectRelease(ctx, execution.ProjectId, execution.ReleaseId); err != nil {
+		return err
+	}
+	blocks, err := getStepBlocks(ctx, scenario.ProjectId, scenario.StepBlockIDs())
+	if err != nil {
+		return err
+	}
+
+	identity, err := meta.NewMeta(author, "execution")
+	if err != nil {
+		return err
+	}
+	execution.Identity = identity
+
+	if err := execution.PopulateSteps(scenario.Steps, blocks); err != nil {
+		return err
+	}
+	execution.Status = executionv1.Status_Pending
+	return nil
+}
+
+// Report returns a function used to create the executions of a test plan from a report of automated test results, read with parse.
+// The results are matched with the scenarios of the project through their automation key. Only the results of Approved scenarios
+// are recorded, the others are reported as not approved. Unmatched results create a Draft scenario when requested, otherwise they are
+// reported as unmatched. The scenarios and the executions are only stored when all the executions could be created.
+func Report(meta MetaHandler, collection Adder, getTestPlan getItem, findAutomated automatedScenarioFinder, createAutomated automatedScenarioCreator, getStepBlocks getStepBlocks, inProjectRelease releaseChecker, parse resultParser) func(ctx context.Context, author string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, id string, data io.Reader) (interface{}, error) {
+		request := &executionv1.ReportRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		testplan, err := testPlan(ctx, getTestPlan, id)
+		if err != nil {
 			return nil, err
 		}
+		if err := testplan.ValidateConfiguration(request.Configuration); err != nil {
+			return nil, err
+		}
+		results, err := parse(strings.NewReader(request.Content))
+		if err != nil {
+			return nil, err
+		}
+		report := &executionv1.Report{}
+		missing := []string{}
+		for _, result := range results {
+			scenario, err := findAutomated(ctx, testplan.ProjectId, result.Key)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case scenario == nil && request.CreateScenarios:
+				missing = appendUnique(missing, result.Key)
+				continue
+			case scenario == nil:
+				report.Unmatched = appendUnique(report.Unmatched, result.Key)
+				continue
+			case scenario.State != scenariov1.State_Approved:
+				report.NotApproved = appendUnique(report.NotApproved, result.Key)
+				continue
+			}
+			execution := &executionv1.Execution{
+				ProjectId:     testplan.ProjectId,
+				ScenarioId:    scenario.Identity.Id,
+				TestPlanId:    id,
+				ReleaseId:     request.ReleaseId,
+				Configuration: request.Configuration,
+			}
+			if err := populate(ctx, meta, author, execution, testplan, scenario, getStepBlocks, inProjectRelease); err != nil {
+				return nil, err
+			}
+			execution.Record(result)
+			report.Executions = append(report.Executions, execution)
+		}
+		if len(missing) != 0 {
+			// the created scenarios have to be reviewed before their results are recorded
+			created, err := createAutomated(ctx, author, testplan.ProjectId, missing)
+			if err != nil {
+				return nil, err
+			}
+			for _, scenario := range created {
+				report.CreatedScenarios = append(report.CreatedScenarios, scenario.Identity.Id)
+			}
+			report.NotApproved = append(report.NotApproved, missing...)
+		}
+		for _, execution := range report.Executions {
+			if err := collection.AddOne(ctx, execution); err != nil {
+				return nil, err
+			}
+		}
+		return report, nil
+	}
+}
 
-		return execution, nil
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
 	}
+	return append(values, value)
 }
 
 // List returns a function used to return the executions
@@ -103,6 +309,58 @@ func List(collection Getter) func(ctx context.Context, filter map[string][]strin
 	}
 }
 
+// ExportCSV returns a function used to export executions as a CSV file, see spreadsheet.WriteExecutions for the columns.
+// The filter contains the projectId, testPlanId or releaseId of the executions and optionally their scenarioId.
+// Every custom field set on one of the executions gets a column.
+func ExportCSV(collection Getter) func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+	return func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+		query := map[string][]string{}
+		for key, values := range filter {
+			switch key {
+			case "projectId":
+				query[ProjectFilterKey] = values
+			case "testPlanId":
+				query[TestPlanFilterKey] = values
+			case "releaseId":
+				query[ReleaseFilterKey] = values
+			case "scenarioId":
+				query[ScenarioFilterKey] = values
+			default:
+				return "", nil, decoder.NewValidationError(fmt.Sprintf("executions cannot be exported by '%s'", key))
+			}
+		}
+		if len(query[ProjectFilterKey]) == 0 && len(query[TestPlanFilterKey]) == 0 && len(query[ReleaseFilterKey]) == 0 {
+			return "", nil, decoder.NewValidationError("one of projectId, testPlanId or releaseId is mandatory")
+		}
+		items, err := List(collection)(ctx, query, "", false, 0, "")
+		if err != nil {
+			return "", nil, err
+		}
+		executions := make([]*executionv1.Execution, 0, len(items))
+		seen := map[string]bool{}
+		fields := []string{}
+		for _, item := range items {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return "", nil, fmt.Errorf("invalid data structure in DB")
+			}
+			for field := range execution.CustomFields {
+				if !seen[field] {
+					seen[field] = true
+					fields = append(fields, field)
+				}
+			}
+			executions = append(executions, execution)
+		}
+		sort.Strings(fields)
+		file := &bytes.Buffer{}
+		if err := spreadsheet.WriteExecutions(file, executions, fields); err != nil {
+			return "", nil, err
+		}
+		return "executions.csv", file.Bytes(), nil
+	}
+}
+
 // Get returns a function to retrieve a execution based on the passed ID
 func Get(collectiom Getter) func(ctx context.Context, id string) (interface{}, error) {
 	return func(ctx context.Context, id string) (interface{}, error) {
@@ -121,7 +379,8 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject getItem, getS
 		if err := decoder.Decode(execution, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, execution.ProjectId); err != nil {
+		fields, err := customFields(ctx, getProject, execution.ProjectId)
+		if err != nil {
 			return nil, err
 		}
 		if _, err := getScenario(ctx, execution.ScenarioId); err != nil {
@@ -142,6 +401,10 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject getItem, getS
 
 		meta.UpdateMeta(user, foundExecution.Identity)
 		foundExecution.Status = execution.Status
+		foundExecution.ActualResult = execution.ActualResult
+		if foundExecution.CustomFields, err = customfieldv1.Apply(fields, execution.CustomFields); err != nil {
+			return nil, err
+		}
 
 		for _, v := range execution.Steps {
 			found := false
@@ -150,6 +413,10 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject getItem, getS
 					found = true
 					step.Status = v.Status
 					step.ActualResult = v.ActualResult
+					// attachments are kept when the request only reports the status or the result of the step
+					if len(v.Attachments) != 0 {
+						step.Attachments = v.Attachments
+					}
 					if v.Status == executionv1.Status_Fail {
 						foundExecution.Status = executionv1.Status_Fail
 					}
@@ -166,3 +433,102 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject getItem, getS
 		return foundExecution, nil
 	}
 }
+
+// AddIssue returns a function used to link an issue to an execution and to its step at the given position
+func AddIssue(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, id string, position int32, issue *metadatav1.LinkedIssue) (*executionv1.Execution, error) {
+	return func(ctx context.Context, user string, id string, position int32, issue *metadatav1.LinkedIssue) (*executionv1.Execution, error) {
+		rawExecution, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		execution, ok := rawExecution.(*executionv1.Execution)
+		if !ok {
+			return nil, fmt.Errorf("invalid data sructure in DB")
+		}
+		step := execution.Step(position)
+		if step == nil {
+			return nil, decoder.NewValidationError(fmt.Sprintf("execution '%s' has no step at position %d", id, position))
+		}
+		step.Issues = append(step.Issues, issue)
+		execution.Issues = append(execution.Issues, proto.Clone(issue).(*metadatav1.LinkedIssue))
+		meta.UpdateMeta(user, execution.Identity)
+		if err := collection.Update(ctx, id, execution); err != nil {
+			return nil, err
+		}
+		return execution, nil
+	}
+}
+
+func testPlan(ctx context.Context, getTestPlan getItem, id string) (*testplanv1.TestPlan, error) {
+	raw, err := getTestPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	testplan, ok := raw.(*testplanv1.TestPlan)
+	if !ok {
+		return nil, fmt.Errorf("invalid DB entry for test plan %s", id)
+	}
+	return testplan, nil
+}
+
+// customFields returns the custom fields the project defines for executions
+func customFields(ctx context.Context, getProject getItem, projectID string) ([]*customfieldv1.Definition, error) {
+	raw, err := getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	project, ok := raw.(*projectv1.Project)
+	if !ok {
+		return nil, fmt.Errorf("invalid DB entry for project %s", projectID)
+	}
+	return project.ExecutionFields, nil
+}
+
+// SetIssueStates returns a function used to change the state of the issues linked to executions and to their steps.
+// The states are mapped by issue link and the number of changed executions is returned
+func SetIssueStates(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, states map[string]string) (int, error) {
+	return func(ctx context.Context, user string, states map[string]string) (int, error) {
+		if len(states) == 0 {
+			return 0, nil
+		}
+		links := make([]string, 0, len(states))
+		for link := range states {
+			links = append(links, link)
+		}
+		found := []interface{}{}
+		for _, key := range []string{IssueFilterKey, StepIssueFilterKey} {
+			items, err := List(collection)(ctx, map[string][]string{key: links}, "", false, 0, "")
+			if err != nil {
+				return 0, err
+			}
+			found = append(found, items...)
+		}
+		changed := 0
+		seen := map[string]bool{}
+		for _, item := range found {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return changed, fmt.Errorf("invalid data structure in DB")
+			}
+			if seen[execution.Identity.Id] {
+				continue
+			}
+			seen[execution.Identity.Id] = true
+			updated := metadatav1.SetIssueStates(execution.Issues, states)
+			for _, step := range execution.Steps {
+				if metadatav1.SetIssueStates(step.Issues, states) {
+					updated = true
+				}
+			}
+			if !updated {
+				continue
+			}
+			meta.UpdateMeta(user, execution.Identity)
+			if err := collection.Update(ctx, execution.Identity.Id, execution); err != nil {
+				return changed, err
+			}
+			changed++
+		}
+		return changed, nil
+	}
+}
@@ -3,6 +3,7 @@ package executions_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -13,9 +14,12 @@ import (
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/test/matchers"
 	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	customfield "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
 	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
 	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
 	"github.com/curious-kitten/scratch-post/pkg/executions"
 	mockExecutions "github.com/curious-kitten/scratch-post/pkg/executions/mocks"
 )
@@ -62,10 +66,15 @@ func goodGetItem(ctx context.Context, id string) (interface{}, error) {
 	return nil, nil
 }
 
+func getProject(ctx context.Context, id string) (interface{}, error) {
+	return &project.Project{Name: "test project"}, nil
+}
+
 func getScenario(ctx context.Context, id string) (interface{}, error) {
 	return &scenario.Scenario{
 		Name:      "test scenario",
 		ProjectId: "zzxxxccvv",
+		State:     scenario.State_Approved,
 		Steps: []*scenario.Step{
 			{
 				Position: 1,
@@ -79,10 +88,33 @@ func getScenario(ctx context.Context, id string) (interface{}, error) {
 	}, nil
 }
 
+func goodGetStepBlocks(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+	return map[string][]*scenario.Step{}, nil
+}
+
 func errorGetItem(ctx context.Context, id string) (interface{}, error) {
 	return nil, fmt.Errorf("an error")
 }
 
+func getTestPlan(ctx context.Context, id string) (interface{}, error) {
+	return &testplan.TestPlan{Name: "test plan", ProjectId: "zzxxxccvv", ReleaseId: "release"}, nil
+}
+
+func getMatrixTestPlan(ctx context.Context, id string) (interface{}, error) {
+	return &testplan.TestPlan{
+		Name:      "test plan",
+		ProjectId: "zzxxxccvv",
+		Matrix: []*project.Dimension{
+			{Name: "browser", Values: []string{"chrome", "firefox"}},
+			{Name: "os", Values: []string{"linux"}},
+		},
+	}, nil
+}
+
+func goodReleaseCheck(ctx context.Context, projectID string, id string) error {
+	return nil
+}
+
 func noItem(ctx context.Context, id string) (interface{}, error) {
 	return nil, mongo.ErrNoDocuments
 }
@@ -138,7 +170,7 @@ func TestNew_Create(t *testing.T) {
 		AddOne(ctx, matchers.OfType(&execution.Execution{})).
 		Return(nil)
 
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, getScenario, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	createdExecution, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 	expectedExecution := &execution.Execution{
@@ -148,8 +180,293 @@ func TestNew_Create(t *testing.T) {
 		TestPlanId: testExecution.TestPlanId,
 		Status:     execution.Status_Pending,
 		Steps:      testExecution.Steps,
+		ReleaseId:  "release",
 	}
-	g.Expect(createdExecution).To(Equal(expectedExecution), "executions did not match")
+	g.Expect(createdExecution).To(matchers.EqualProto(expectedExecution), "executions did not match")
+}
+
+func TestNew_ReleaseNotInProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	releaseCheck := func(ctx context.Context, projectID string, id string) error {
+		g.Expect(id).To(Equal("release"), "release of the test plan was not checked")
+		return decoder.NewValidationError("not in project")
+	}
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, goodGetStepBlocks, releaseCheck)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release of another project does not return a validation error")
+}
+
+func TestNew_ConfigurationNotInMatrix(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getMatrixTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	request := &execution.Execution{
+		ProjectId:     testExecution.ProjectId,
+		ScenarioId:    testExecution.ScenarioId,
+		TestPlanId:    testExecution.TestPlanId,
+		Configuration: map[string]string{"browser": "safari", "os": "linux"},
+	}
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "configuration outside of the matrix does not return a validation error")
+	request.Configuration = map[string]string{"browser": "chrome"}
+	_, err = creator(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "partial configuration does not return a validation error")
+}
+
+func TestRun(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "execution").
+		Return(&identity, nil).
+		Times(4)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&execution.Execution{})).
+		Return(nil).
+		Times(4)
+	runner := executions.Run(mockMetaHandler, mockAdder, getProject, getScenario, getMatrixTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	raw, err := runner(ctx, "tester", "plan", transformers.ToReadCloser(&execution.RunRequest{ScenarioIds: []string{"first", "second"}}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	run := raw.(*execution.Run)
+	g.Expect(run.Executions).To(HaveLen(4), "an execution was not created for each scenario and configuration")
+	g.Expect(run.Executions[1].ScenarioId).To(Equal("first"), "scenario was not set")
+	g.Expect(run.Executions[1].TestPlanId).To(Equal("plan"), "test plan was not set")
+	g.Expect(run.Executions[1].ProjectId).To(Equal("zzxxxccvv"), "project of the test plan was not set")
+	g.Expect(run.Executions[1].Configuration).To(Equal(map[string]string{"browser": "firefox", "os": "linux"}), "configuration was not set")
+	g.Expect(run.Executions[1].Steps).To(HaveLen(2), "steps were not populated")
+	g.Expect(run.Executions[2].ScenarioId).To(Equal("second"), "scenario was not set")
+}
+
+func TestRun_ScenarioNotApproved(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getDraftScenario := func(ctx context.Context, id string) (interface{}, error) {
+		return &scenario.Scenario{Name: "test scenario", ProjectId: "zzxxxccvv", State: scenario.State_Draft}, nil
+	}
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	runner := executions.Run(mockMetaHandler, mockAdder, getProject, getDraftScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	_, err := runner(ctx, "tester", "plan", transformers.ToReadCloser(&execution.RunRequest{ScenarioIds: []string{"first"}}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "draft scenario does not return a validation error")
+}
+
+func TestRun_ScenarioOfOtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getOtherScenario := func(ctx context.Context, id string) (interface{}, error) {
+		return &scenario.Scenario{Name: "test scenario", ProjectId: "other", State: scenario.State_Approved}, nil
+	}
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	runner := executions.Run(mockMetaHandler, mockAdder, getProject, getOtherScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	_, err := runner(ctx, "tester", "plan", transformers.ToReadCloser(&execution.RunRequest{ScenarioIds: []string{"first"}}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "scenario of another project does not return a validation error")
+}
+
+func TestNew_TestPlanOfOtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	otherProject := &execution.Execution{ProjectId: "other", ScenarioId: testExecution.ScenarioId, TestPlanId: testExecution.TestPlanId}
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(otherProject))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "test plan of another project does not return a validation error")
+}
+
+func parseResults(content io.Reader) ([]*execution.TestResult, error) {
+	return []*execution.TestResult{
+		{Key: "shop.TestLogin", Status: execution.Status_Pass, Duration: 1.5},
+		{Key: "shop.TestCart", Status: execution.Status_Fail, ActualResult: "cart is empty"},
+		{Key: "shop.TestCheckout", Status: execution.Status_Skipped},
+		{Key: "shop.TestLogout", Status: execution.Status_Pass},
+		{Key: "shop.TestCheckout", Status: execution.Status_Pass},
+	}, nil
+}
+
+// findAutomated finds an Approved scenario for the login and cart tests and a Draft one for the logout test
+func findAutomated(ctx context.Context, projectID string, key string) (*scenario.Scenario, error) {
+	state := scenario.State_Approved
+	switch key {
+	case "shop.TestCheckout":
+		return nil, nil
+	case "shop.TestLogout":
+		state = scenario.State_Draft
+	}
+	return &scenario.Scenario{
+		Identity:  &metadata.Identity{Id: key},
+		ProjectId: projectID,
+		State:     state,
+		Steps:     []*scenario.Step{{Position: 1, Name: "test"}},
+	}, nil
+}
+
+func createAutomated(created *[]string) func(ctx context.Context, user string, projectID string, keys []string) ([]*scenario.Scenario, error) {
+	return func(ctx context.Context, user string, projectID string, keys []string) ([]*scenario.Scenario, error) {
+		*created = append(*created, keys...)
+		scenarios := []*scenario.Scenario{}
+		for _, key := range keys {
+			scenarios = append(scenarios, &scenario.Scenario{Identity: &metadata.Identity{Id: "created " + key}, ProjectId: projectID, State: scenario.State_Draft})
+		}
+		return scenarios, nil
+	}
+}
+
+func TestReport(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "execution").
+		DoAndReturn(func(author string, objType string) (*metadata.Identity, error) {
+			return &metadata.Identity{Id: "execution"}, nil
+		}).
+		Times(2)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&execution.Execution{})).
+		Return(nil).
+		Times(2)
+	created := []string{}
+	report := executions.Report(mockMetaHandler, mockAdder, getMatrixTestPlan, findAutomated, createAutomated(&created), goodGetStepBlocks, goodReleaseCheck, parseResults)
+	request := &execution.ReportRequest{Content: "<testsuites/>", Configuration: map[string]string{"browser": "chrome", "os": "linux"}}
+	raw, err := report(ctx, "tester", "plan", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*execution.Report)
+	g.Expect(result.Unmatched).To(Equal([]string{"shop.TestCheckout"}), "unmatched test was not reported once")
+	g.Expect(result.NotApproved).To(Equal([]string{"shop.TestLogout"}), "result of a Draft scenario was not reported")
+	g.Expect(created).To(BeEmpty(), "scenario was created without being requested")
+	g.Expect(result.Executions).To(HaveLen(2), "an execution was not created for each matched test")
+	passed := result.Executions[0]
+	g.Expect(passed.ScenarioId).To(Equal("shop.TestLogin"), "scenario was not set")
+	g.Expect(passed.TestPlanId).To(Equal("plan"), "test plan was not set")
+	g.Expect(passed.Configuration).To(Equal(request.Configuration), "configuration was not set")
+	g.Expect(passed.Status).To(Equal(execution.Status_Pass), "status was not set")
+	g.Expect(passed.Duration).To(Equal(1.5), "duration was not set")
+	g.Expect(passed.Steps[0].Status).To(Equal(execution.Status_Pass), "steps of a passed test did not pass")
+	failed := result.Executions[1]
+	g.Expect(failed.Status).To(Equal(execution.Status_Fail), "status was not set")
+	g.Expect(failed.ActualResult).To(Equal("cart is empty"), "failure message was not set")
+	g.Expect(failed.Steps[0].Status).To(Equal(execution.Status_Pending), "step of a failed test was changed")
+}
+
+func TestReport_CreateScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "execution").
+		Return(&identity, nil).
+		Times(2)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&execution.Execution{})).
+		Return(nil).
+		Times(2)
+	created := []string{}
+	report := executions.Report(mockMetaHandler, mockAdder, getTestPlan, findAutomated, createAutomated(&created), goodGetStepBlocks, goodReleaseCheck, parseResults)
+	raw, err := report(ctx, "tester", "plan", transformers.ToReadCloser(&execution.ReportRequest{Content: "<testsuites/>", CreateScenarios: true}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*execution.Report)
+	g.Expect(created).To(Equal([]string{"shop.TestCheckout"}), "scenario was not created once")
+	g.Expect(result.CreatedScenarios).To(Equal([]string{"created shop.TestCheckout"}), "created scenario was not reported")
+	g.Expect(result.Unmatched).To(BeEmpty(), "test with a created scenario is unmatched")
+	g.Expect(result.NotApproved).To(Equal([]string{"shop.TestLogout", "shop.TestCheckout"}), "results of Draft scenarios were not reported")
+	g.Expect(result.Executions).To(HaveLen(2), "results of Draft scenarios were recorded")
+	g.Expect(result.Executions[1].ReleaseId).To(Equal("release"), "release of the test plan was not set")
+}
+
+func TestReport_StepResults(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "execution").
+		Return(&identity, nil).
+		Times(1)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&execution.Execution{})).
+		Return(nil).
+		Times(1)
+	findLogin := func(ctx context.Context, projectID string, key string) (*scenario.Scenario, error) {
+		return &scenario.Scenario{
+			Identity:  &metadata.Identity{Id: "login"},
+			ProjectId: projectID,
+			State:     scenario.State_Approved,
+			Steps: []*scenario.Step{
+				{Position: 1, Name: "log in", Action: "the user logs in as <email>", ExpectedOutcome: "the dashboard is shown"},
+				{Position: 2, Name: "log out", Action: "the user logs out"},
+				{Position: 3, Name: "close", Action: "the user closes the browser"},
+			},
+		}, nil
+	}
+	parse := func(content io.Reader) ([]*execution.TestResult, error) {
+		return []*execution.TestResult{{
+			Key:    "features/login.feature:Login",
+			Status: execution.Status_Fail,
+			Steps: []*execution.StepResult{
+				{Text: "the user logs in as john@example.com", Status: execution.Status_Pass},
+				{Text: "the dashboard is shown", Status: execution.Status_Fail, ActualResult: "dashboard not found"},
+				{Text: "the user logs out", Status: execution.Status_Skipped},
+			},
+		}}, nil
+	}
+	report := executions.Report(mockMetaHandler, mockAdder, getTestPlan, findLogin, createAutomated(&[]string{}), goodGetStepBlocks, goodReleaseCheck, parse)
+	raw, err := report(ctx, "tester", "plan", transformers.ToReadCloser(&execution.ReportRequest{Content: "[]"}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	steps := raw.(*execution.Report).Executions[0].Steps
+	g.Expect(steps[0].Status).To(Equal(execution.Status_Fail), "step did not get the most severe status of its results")
+	g.Expect(steps[0].ActualResult).To(Equal("dashboard not found"), "failure message was not set on the step")
+	g.Expect(steps[1].Status).To(Equal(execution.Status_Skipped), "step was not skipped")
+	g.Expect(steps[2].Status).To(Equal(execution.Status_Pending), "step without results was changed")
+}
+
+func TestReport_ConfigurationNotInMatrix(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	report := executions.Report(mockMetaHandler, mockAdder, getMatrixTestPlan, findAutomated, createAutomated(&[]string{}), goodGetStepBlocks, goodReleaseCheck, parseResults)
+	request := &execution.ReportRequest{Content: "<testsuites/>", Configuration: map[string]string{"browser": "safari", "os": "linux"}}
+	_, err := report(ctx, "tester", "plan", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "configuration outside of the matrix is not a validation error")
 }
 
 func TestNew_ProjectNotFound(t *testing.T) {
@@ -159,7 +476,7 @@ func TestNew_ProjectNotFound(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, noItem, goodGetItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, noItem, goodGetItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 }
@@ -171,7 +488,7 @@ func TestNew_ExecutorNotFound(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, goodGetItem, noItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, goodGetItem, noItem, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 }
@@ -183,7 +500,7 @@ func TestNew_ScenarioNotFound(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, noItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, noItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 }
@@ -195,7 +512,7 @@ func TestNew_ProjectError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, errorGetItem, goodGetItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, errorGetItem, goodGetItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "error type was missing")
@@ -208,7 +525,7 @@ func TestNew_ExecutorError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, goodGetItem, errorGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, goodGetItem, errorGetItem, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "error type was missing")
@@ -221,7 +538,7 @@ func TestNew_ScenarioError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, errorGetItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, errorGetItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "error type was missing")
@@ -234,7 +551,7 @@ func TestNew_MarshallError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, errorGetItem, goodGetItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, errorGetItem, goodGetItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(struct{ SomeField string }{SomeField: "test"}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -247,7 +564,7 @@ func TestNew_ValidationError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, goodGetItem, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, goodGetItem, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(&execution.Execution{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -264,7 +581,7 @@ func TestNew_AddMetaError(t *testing.T) {
 		NewMeta("tester", "execution").
 		Return(nil, fmt.Errorf("identity error"))
 	mockAdder := mockExecutions.NewMockAdder(ctrl)
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, getScenario, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
@@ -285,11 +602,90 @@ func TestNew_AddToCollectionError(t *testing.T) {
 		AddOne(ctx, matchers.OfType(&execution.Execution{})).
 		Return(fmt.Errorf("expected error"))
 
-	creator := executions.New(mockMetaHandler, mockAdder, goodGetItem, getScenario, goodGetItem)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+}
+
+func TestNew_StepBlockExpansion(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "execution").
+		Return(&identity, nil)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&execution.Execution{})).
+		Return(nil)
+	getScenarioWithBlock := func(ctx context.Context, id string) (interface{}, error) {
+		return &scenario.Scenario{
+			Name:      "test scenario",
+			ProjectId: "zzxxxccvv",
+			State:     scenario.State_Approved,
+			Steps: []*scenario.Step{
+				{Position: 1, StepBlockId: "login"},
+				{Position: 2, Name: "test"},
+			},
+		}, nil
+	}
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		g.Expect(ids).To(Equal([]string{"login"}), "referenced step blocks did not match")
+		return map[string][]*scenario.Step{
+			"login": {
+				{Position: 1, Name: "open login page"},
+				{Position: 2, Name: "submit credentials"},
+			},
+		}, nil
+	}
+
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenarioWithBlock, getTestPlan, getStepBlocks, goodReleaseCheck)
+	created, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	createdExecution := created.(*execution.Execution)
+	g.Expect(createdExecution.Steps).To(HaveLen(3), "step block was not expanded")
+	expected := []struct {
+		name        string
+		stepBlockID string
+	}{
+		{name: "open login page", stepBlockID: "login"},
+		{name: "submit credentials", stepBlockID: "login"},
+		{name: "test"},
+	}
+	for i, v := range expected {
+		g.Expect(createdExecution.Steps[i].Definition.Name).To(Equal(v.name), "step name did not match")
+		g.Expect(createdExecution.Steps[i].Definition.Position).To(Equal(int32(i+1)), "step position was not renumbered")
+		g.Expect(createdExecution.Steps[i].StepBlockId).To(Equal(v.stepBlockID), "step block ID did not match")
+	}
+}
+
+func TestNew_StepBlockError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return nil, mongo.ErrNoDocuments
+	}
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getScenario, getTestPlan, getStepBlocks, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
 
+func TestExecution_PopulateStepsMissingBlock(t *testing.T) {
+	g := NewWithT(t)
+	e := &execution.Execution{}
+	err := e.PopulateSteps([]*scenario.Step{{Position: 1, StepBlockId: "missing"}}, map[string][]*scenario.Step{})
+	g.Expect(err).Should(HaveOccurred(), "missing step block did not return an error")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "missing step block error is not a validation error")
+}
+
 func TestList(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -322,6 +718,34 @@ func TestList_RetrieveError(t *testing.T) {
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
 
+func TestExportCSV(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockExecutions.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]execution.Execution{}), map[string][]string{executions.TestPlanFilterKey: {"plan"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]execution.Execution, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items,
+				execution.Execution{Identity: &metadata.Identity{Id: "first"}, CustomFields: map[string]*customfield.Value{"owner": {Values: []string{"john"}}}},
+				execution.Execution{Identity: &metadata.Identity{Id: "second"}, CustomFields: map[string]*customfield.Value{"area": {Values: []string{"cart"}}}},
+			)
+		})
+	export := executions.ExportCSV(mockGetter)
+	name, content, err := export(ctx, map[string][]string{"testPlanId": {"plan"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(name).To(Equal("executions.csv"))
+	g.Expect(string(content)).To(ContainSubstring(",customFields.area,customFields.owner\n"), "custom fields of the executions are not columns")
+	g.Expect(string(content)).To(ContainSubstring("\nsecond,"), "there is not a row per execution")
+
+	_, _, err = export(ctx, map[string][]string{"scenarioId": {"scenario"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "export of all executions is not a validation error")
+	_, _, err = export(ctx, map[string][]string{"testPlanId": {"plan"}, "status": {"1"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "unknown filter is not a validation error")
+}
+
 func TestGet(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -371,11 +795,54 @@ func TestUpdate(t *testing.T) {
 		Update(ctx, identity.Id, matchers.OfType(&execution.Execution{}))
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, goodGetItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 }
 
+func TestUpdate_KeepsAttachments(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	screenshot := &execution.Attachment{Name: "login.png", Link: "https://files.example.com/login.png"}
+	log := &execution.Attachment{Name: "login.log", Link: "https://files.example.com/login.log"}
+	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&execution.Execution{})).
+		Do(func(ctx context.Context, id string, e *execution.Execution) {
+			e.Steps = []*execution.StepExecution{
+				{Definition: &scenario.Step{Position: 1, Name: "test"}, Attachments: []*execution.Attachment{screenshot}},
+				{Definition: &scenario.Step{Position: 2, Name: "test"}, Attachments: []*execution.Attachment{screenshot}},
+			}
+			e.Identity = &identity
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&execution.Execution{}))
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	request := &execution.Execution{
+		ProjectId:  testExecution.ProjectId,
+		ScenarioId: testExecution.ScenarioId,
+		TestPlanId: testExecution.TestPlanId,
+		Steps: []*execution.StepExecution{
+			{Definition: &scenario.Step{Position: 1, Name: "test"}, Status: execution.Status_Pass, ActualResult: "logged in"},
+			{Definition: &scenario.Step{Position: 2, Name: "test"}, Status: execution.Status_Pass, Attachments: []*execution.Attachment{log}},
+		},
+	}
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, goodGetItem)
+	updated, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	steps := updated.(*execution.Execution).Steps
+	g.Expect(steps[0].ActualResult).To(Equal("logged in"), "actual result was not updated")
+	g.Expect(steps[0].Attachments).To(HaveLen(1), "attachments were removed by an update without attachments")
+	g.Expect(steps[0].Attachments[0].Name).To(Equal(screenshot.Name), "attachments were changed by an update without attachments")
+	g.Expect(steps[1].Attachments).To(HaveLen(1), "attachments were not replaced")
+	g.Expect(steps[1].Attachments[0].Name).To(Equal(log.Name), "attachments were not replaced")
+}
+
 func TestUpdate_ValidationError(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -383,7 +850,7 @@ func TestUpdate_ValidationError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, goodGetItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(execution.Execution{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -408,7 +875,7 @@ func TestUpdate_InvalidScenario(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, noItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, noItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -420,7 +887,7 @@ func TestUpdate_InvalidTestPlan(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, noItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, noItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -445,7 +912,7 @@ func TestUpdate_ScenarioError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, errorGetItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, errorGetItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "project not found error is not a validation error")
@@ -458,7 +925,7 @@ func TestUpdate_TestPlanError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, goodGetItem, errorGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, errorGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "project not found error is not a validation error")
@@ -475,7 +942,7 @@ func TestUpdate_GetError(t *testing.T) {
 		Get(ctx, identity.Id, matchers.OfType(&execution.Execution{})).
 		Return(fmt.Errorf("error during get"))
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, goodGetItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -499,7 +966,133 @@ func TestUpdate_UpdateError(t *testing.T) {
 		Return(fmt.Errorf("update error"))
 	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := executions.Update(mockMetaHandler, mockReaderUpdater, goodGetItem, goodGetItem, goodGetItem)
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProject, goodGetItem, goodGetItem)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testExecution))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
+
+func TestUpdate_CustomFieldError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getProjectWithFields := func(ctx context.Context, id string) (interface{}, error) {
+		return &project.Project{
+			Name:            "test project",
+			ExecutionFields: []*customfield.Definition{{Name: "platform", Type: customfield.FieldType_Enum, Options: []string{"linux"}}},
+		}, nil
+	}
+	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&execution.Execution{})).
+		Do(func(ctx context.Context, id string, e *execution.Execution) {
+			e.Steps = testExecution.Steps
+			e.Identity = &identity
+		})
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := executions.Update(mockMetaHandler, mockReaderUpdater, getProjectWithFields, goodGetItem, goodGetItem)
+	withFields := &execution.Execution{
+		ProjectId:    testExecution.ProjectId,
+		ScenarioId:   testExecution.ScenarioId,
+		TestPlanId:   testExecution.TestPlanId,
+		CustomFields: map[string]*customfield.Value{"platform": {Values: []string{"windows"}}},
+	}
+	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(withFields))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid custom field value is not a validation error")
+}
+
+func TestNew_ScenarioNotApproved(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getDraftScenario := func(ctx context.Context, id string) (interface{}, error) {
+		return &scenario.Scenario{Name: "test scenario", ProjectId: "zzxxxccvv", State: scenario.State_InReview}, nil
+	}
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockAdder := mockExecutions.NewMockAdder(ctrl)
+	creator := executions.New(mockMetaHandler, mockAdder, getProject, getDraftScenario, getTestPlan, goodGetStepBlocks, goodReleaseCheck)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testExecution))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "executing a scenario that is not approved is not a validation error")
+}
+
+func TestSetIssueStates(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	states := map[string]string{"https://tracker/1": "Done"}
+	// the first execution has the issue on both the execution and a step, the second one is up to date
+	stored := []execution.Execution{
+		{
+			Identity: &metadata.Identity{Id: "with issue"},
+			Issues:   []*metadata.LinkedIssue{{Link: "https://tracker/1", State: "Open"}},
+			Steps:    []*execution.StepExecution{{Issues: []*metadata.LinkedIssue{{Link: "https://tracker/1", State: "Open"}}}},
+		},
+		{
+			Identity: &metadata.Identity{Id: "up to date"},
+			Steps:    []*execution.StepExecution{{Issues: []*metadata.LinkedIssue{{Link: "https://tracker/1", State: "Done"}}}},
+		},
+	}
+	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]execution.Execution{}), map[string][]string{executions.IssueFilterKey: {"https://tracker/1"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]execution.Execution, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, stored[:1]...)
+		})
+	mockReaderUpdater.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]execution.Execution{}), map[string][]string{executions.StepIssueFilterKey: {"https://tracker/1"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]execution.Execution, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, stored...)
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, "with issue", matchers.OfType(&execution.Execution{})).
+		Do(func(ctx context.Context, id string, e *execution.Execution) {
+			g.Expect(e.Issues[0].State).To(Equal("Done"), "state of the execution issue was not changed")
+			g.Expect(e.Steps[0].Issues[0].State).To(Equal("Done"), "state of the step issue was not changed")
+		})
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("issue-tracker", matchers.OfType(&metadata.Identity{}))
+
+	changed, err := executions.SetIssueStates(mockMetaHandler, mockReaderUpdater)(ctx, "issue-tracker", states)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(changed).To(Equal(1), "wrong number of changed executions")
+}
+
+func TestAddIssue(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockExecutions.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&execution.Execution{})).
+		Do(func(ctx context.Context, id string, e *execution.Execution) {
+			e.Identity = &identity
+			e.Steps = []*execution.StepExecution{{Definition: &scenario.Step{Position: 1, Name: "login"}, Status: execution.Status_Fail}}
+		}).
+		Times(2)
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&execution.Execution{}))
+	mockMetaHandler := mockExecutions.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+
+	issue := &metadata.LinkedIssue{Link: "https://tracker/31", IssueType: metadata.IssueType_DEFECT}
+	addIssue := executions.AddIssue(mockMetaHandler, mockReaderUpdater)
+	updated, err := addIssue(ctx, "tester", identity.Id, 1, issue)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.Issues).To(HaveLen(1), "issue was not linked to the execution")
+	g.Expect(updated.Steps[0].Issues).To(HaveLen(1), "issue was not linked to the step")
+
+	_, err = addIssue(ctx, "tester", identity.Id, 2, issue)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error for a missing step")
+}
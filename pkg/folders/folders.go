@@ -0,0 +1,327 @@
+package folders
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	folderv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+//go:generate mockgen -source ./folders.go -destination mocks/folders.go
+
+const (
+	// ProjectFilterKey is the filter used to find the folders of a project
+	ProjectFilterKey = "projectid"
+	// ParentFilterKey is the filter used to find the subfolders of a folder
+	ParentFilterKey = "parentid"
+	// ScenarioFilterKey is the filter used to find the scenarios in a folder
+	ScenarioFilterKey = "folderid"
+)
+
+type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type scenarioLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type scenarioMover func(ctx context.Context, user string, projectID string, folderID string, ids []string) ([]interface{}, error)
+
+// MetaHandler handles metadata information
+type MetaHandler interface {
+	NewMeta(author string, objType string) (*metadatav1.Identity, error)
+	UpdateMeta(author string, identity *metadatav1.Identity)
+}
+
+// Adder is used to add items to the store
+type Adder interface {
+	AddOne(ctx context.Context, item interface{}) error
+}
+
+// Getter is used to retrieve items from the store
+type Getter interface {
+	Get(ctx context.Context, id string, item interface{}) error
+	GetAll(ctx context.Context, items interface{}, filterMap map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) error
+}
+
+// Deleter deletes an entry from the collection
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+// Updater is used to replace information into the Data Base
+type Updater interface {
+	Update(ctx context.Context, id string, item interface{}) error
+}
+
+// ReaderAdder is used to read and add objects in the Data Base
+type ReaderAdder interface {
+	Getter
+	Adder
+}
+
+// ReaderUpdater is used to read and update objects in the Data Base
+type ReaderUpdater interface {
+	Getter
+	Updater
+}
+
+// ReaderDeleter is used to read and delete objects in the Data Base
+type ReaderDeleter interface {
+	Getter
+	Deleter
+}
+
+// New returns a function used to create a folder
+func New(meta MetaHandler, collection ReaderAdder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+		folder := &folderv1.Folder{}
+		if err := decoder.Decode(folder, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, folder.ProjectId); err != nil {
+			return nil, err
+		}
+		if err := InProject(collection)(ctx, folder.ProjectId, folder.ParentId); err != nil {
+			return nil, err
+		}
+		identity, err := meta.NewMeta(author, "folder")
+		if err != nil {
+			return nil, err
+		}
+		folder.Identity = identity
+		if err := collection.AddOne(ctx, folder); err != nil {
+			return nil, err
+		}
+		return folder, nil
+	}
+}
+
+// List returns a function used to return the folders
+func List(collection Getter) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		folders := []folderv1.Folder{}
+		err := collection.GetAll(ctx, &folders, filter, sortBy, reverse, count, previousLastValue)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(folders))
+		for i := range folders {
+			items[i] = proto.Clone(&folders[i]).(*folderv1.Folder)
+		}
+		return items, nil
+	}
+}
+
+// Get returns a function to retrieve a folder based on the passed ID
+func Get(collection Getter) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		folder := &folderv1.Folder{}
+		if err := collection.Get(ctx, id, folder); err != nil {
+			return nil, err
+		}
+		return folder, nil
+	}
+}
+
+// Delete returns a function to delete a folder based on the passed ID.
+// Only folders that do not contain other folders or scenarios can be deleted.
+func Delete(collection ReaderDeleter, listScenarios scenarioLister) func(ctx context.Context, id string) error {
+	return func(ctx context.Context, id string) error {
+		subfolders, err := List(collection)(ctx, map[string][]string{ParentFilterKey: {id}}, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(subfolders) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("folder '%s' contains other folders", id))
+		}
+		scenarios, err := listScenarios(ctx, map[string][]string{ScenarioFilterKey: {id}}, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(scenarios) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("folder '%s' contains scenarios", id))
+		}
+		if err := collection.Delete(ctx, id); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// Update is used to replace a folder with the provided folder.
+// It is used to rename a folder or to move it by changing the parent.
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		folder := &folderv1.Folder{}
+		if err := decoder.Decode(folder, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, folder.ProjectId); err != nil {
+			return nil, err
+		}
+		foundFolder, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		var f *folderv1.Folder
+		var ok bool
+		if f, ok = foundFolder.(*folderv1.Folder); !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		if f.ProjectId != folder.ProjectId {
+			return nil, decoder.NewValidationError("a folder cannot be moved to another project")
+		}
+		if folder.ParentId != "" {
+			if err := InProject(collection)(ctx, folder.ProjectId, folder.ParentId); err != nil {
+				return nil, err
+			}
+			descendants, err := Descendants(collection)(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range descendants {
+				if d == folder.ParentId {
+					return nil, decoder.NewValidationError("a folder cannot be moved inside itself or one of its subfolders")
+				}
+			}
+		}
+		folder.Identity = f.Identity
+		meta.UpdateMeta(user, folder.Identity)
+		if err := collection.Update(ctx, id, folder); err != nil {
+			return nil, err
+		}
+		return folder, nil
+	}
+}
+
+// InProject returns a function used to check that a folder is part of a project.
+// An empty folder ID represents the root of the project.
+func InProject(collection Getter) func(ctx context.Context, projectID string, id string) error {
+	return func(ctx context.Context, projectID string, id string) error {
+		if id == "" {
+			return nil
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return err
+		}
+		folder, ok := raw.(*folderv1.Folder)
+		if !ok {
+			return fmt.Errorf("invalid DB entry for folder %s", id)
+		}
+		if folder.ProjectId != projectID {
+			return decoder.NewValidationError(fmt.Sprintf("folder '%s' is not part of project '%s'", id, projectID))
+		}
+		return nil
+	}
+}
+
+// Descendants returns a function used to retrieve the IDs of a folder and all its subfolders
+func Descendants(collection Getter) func(ctx context.Context, id string) ([]string, error) {
+	return func(ctx context.Context, id string) ([]string, error) {
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		folder, ok := raw.(*folderv1.Folder)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for folder %s", id)
+		}
+		projectFolders, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {folder.ProjectId}}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		children := map[string][]string{}
+		for _, item := range projectFolders {
+			f := item.(*folderv1.Folder)
+			children[f.ParentId] = append(children[f.ParentId], f.Identity.Id)
+		}
+		ids := []string{id}
+		for i := 0; i < len(ids); i++ {
+			ids = append(ids, children[ids[i]]...)
+		}
+		return ids, nil
+	}
+}
+
+// Paths returns a function used to retrieve the path of all the folders of a project, ie. `parent/child`, by folder ID
+func Paths(collection Getter) func(ctx context.Context, projectID string) (map[string]string, error) {
+	return func(ctx context.Context, projectID string) (map[string]string, error) {
+		projectFolders, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		byID := map[string]*folderv1.Folder{}
+		for _, item := range projectFolders {
+			f, ok := item.(*folderv1.Folder)
+			if !ok {
+				return nil, fmt.Errorf("invalid data structure in DB")
+			}
+			byID[f.Identity.Id] = f
+		}
+		paths := map[string]string{}
+		for id, f := range byID {
+			path := f.Name
+			// the number of parents is bounded in case the DB contains a cycle
+			for parent, depth := byID[f.ParentId], 0; parent != nil && depth < len(byID); parent, depth = byID[parent.ParentId], depth+1 {
+				path = parent.Name + "/" + path
+			}
+			paths[id] = path
+		}
+		return paths, nil
+	}
+}
+
+// Scenarios returns a function used to retrieve the scenarios in a folder and its subfolders
+func Scenarios(collection Getter, listScenarios scenarioLister) func(ctx context.Context, id string) ([]interface{}, error) {
+	return func(ctx context.Context, id string) ([]interface{}, error) {
+		ids, err := Descendants(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return listScenarios(ctx, map[string][]string{ScenarioFilterKey: ids}, "", false, 0, "")
+	}
+}
+
+// Summary returns a function used to count the scenarios in a folder and its subfolders
+func Summary(collection Getter, listScenarios scenarioLister) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		items, err := Scenarios(collection, listScenarios)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		summary := &folderv1.FolderSummary{FolderId: id}
+		for _, item := range items {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for scenario")
+			}
+			summary.Scenarios++
+			if scenario.Automated {
+				summary.AutomatedScenarios++
+			}
+		}
+		return summary, nil
+	}
+}
+
+// MoveScenarios returns a function used to move multiple scenarios to a folder. The moved scenarios are returned
+func MoveScenarios(collection Getter, moveScenarios scenarioMover) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &folderv1.MoveScenariosRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		folder, ok := raw.(*folderv1.Folder)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for folder %s", id)
+		}
+		return moveScenarios(ctx, user, folder.ProjectId, id, request.ScenarioIds)
+	}
+}
@@ -0,0 +1,351 @@
+package folders_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/test/matchers"
+	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	folder "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/folders"
+	mockFolders "github.com/curious-kitten/scratch-post/pkg/folders/mocks"
+)
+
+var (
+	identity = metadata.Identity{
+		Id:           "aabbccddee",
+		Type:         "folder",
+		Version:      1,
+		CreatedBy:    "author",
+		UpdatedBy:    "author",
+		CreationTime: time.Now().Unix(),
+		UpdateTime:   time.Now().Unix(),
+	}
+
+	testFolder = &folder.Folder{
+		Name:      "smoke tests",
+		ProjectId: "zzxxxccvv",
+	}
+
+	// root -> child -> grandchild, other is a second root folder
+	projectFolders = []folder.Folder{
+		{Identity: &metadata.Identity{Id: "root"}, ProjectId: "zzxxxccvv", Name: "root"},
+		{Identity: &metadata.Identity{Id: "child"}, ProjectId: "zzxxxccvv", Name: "child", ParentId: "root"},
+		{Identity: &metadata.Identity{Id: "grandchild"}, ProjectId: "zzxxxccvv", Name: "grandchild", ParentId: "child"},
+		{Identity: &metadata.Identity{Id: "other"}, ProjectId: "zzxxxccvv", Name: "other"},
+	}
+)
+
+func goodGetProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, nil
+}
+
+func noProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, mongo.ErrNoDocuments
+}
+
+func noScenarios(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return []interface{}{}, nil
+}
+
+func findFolder(id string) *folder.Folder {
+	for i := range projectFolders {
+		if projectFolders[i].Identity.Id == id {
+			return &projectFolders[i]
+		}
+	}
+	return nil
+}
+
+type folderRecorder interface {
+	Get(ctx, id, item interface{}) *gomock.Call
+	GetAll(ctx, items, filterMap, sortBy, reverse, count, previousLastValue interface{}) *gomock.Call
+}
+
+// expectFolderTree makes the store return the folders in projectFolders
+func expectFolderTree(ctx context.Context, recorder folderRecorder) {
+	recorder.
+		Get(ctx, gomock.Any(), matchers.OfType(&folder.Folder{})).
+		DoAndReturn(func(ctx context.Context, id string, f *folder.Folder) error {
+			found := findFolder(id)
+			if found == nil {
+				return mongo.ErrNoDocuments
+			}
+			f.Identity = found.Identity
+			f.ProjectId = found.ProjectId
+			f.Name = found.Name
+			f.ParentId = found.ParentId
+			return nil
+		}).
+		AnyTimes()
+	recorder.
+		GetAll(ctx, matchers.OfType(&[]folder.Folder{}), map[string][]string{folders.ProjectFilterKey: {"zzxxxccvv"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]folder.Folder, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, projectFolders...)
+		}).
+		AnyTimes()
+}
+
+func TestFolder_Validate(t *testing.T) {
+	g := NewWithT(t)
+	f := &folder.Folder{}
+	err := f.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with empty folder")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty folder error is not a validation error")
+	f.Name = "Test Name"
+	err = f.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with folder that only has a name")
+	f.ProjectId = "aabbccdd"
+	err = f.Validate()
+	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred when minimun requirements have been met")
+}
+
+func TestNew_Create(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "folder").
+		Return(&identity, nil)
+	mockReaderAdder := mockFolders.NewMockReaderAdder(ctrl)
+	mockReaderAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&folder.Folder{})).
+		Return(nil)
+
+	creator := folders.New(mockMetaHandler, mockReaderAdder, goodGetProject)
+	created, err := creator(ctx, "tester", transformers.ToReadCloser(testFolder))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.(*folder.Folder).Identity).To(Equal(&identity), "identity was not set")
+}
+
+func TestNew_ProjectNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	mockReaderAdder := mockFolders.NewMockReaderAdder(ctrl)
+	creator := folders.New(mockMetaHandler, mockReaderAdder, noProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testFolder))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+}
+
+func TestNew_ParentInOtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	mockReaderAdder := mockFolders.NewMockReaderAdder(ctrl)
+	mockReaderAdder.
+		EXPECT().
+		Get(ctx, "parent", matchers.OfType(&folder.Folder{})).
+		Do(func(ctx context.Context, id string, f *folder.Folder) {
+			f.ProjectId = "other project"
+		})
+	creator := folders.New(mockMetaHandler, mockReaderAdder, goodGetProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(&folder.Folder{Name: "child", ProjectId: "zzxxxccvv", ParentId: "parent"}))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "parent from another project is not a validation error")
+}
+
+func TestDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockFolders.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]folder.Folder{}), map[string][]string{folders.ParentFilterKey: {identity.Id}}, "", false, 1, "").
+		Return(nil)
+	mockReaderDeleter.
+		EXPECT().
+		Delete(ctx, identity.Id).
+		Return(nil)
+	err := folders.Delete(mockReaderDeleter, noScenarios)(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+}
+
+func TestDelete_ContainsFolders(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockFolders.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]folder.Folder{}), map[string][]string{folders.ParentFilterKey: {identity.Id}}, "", false, 1, "").
+		Do(func(ctx context.Context, items *[]folder.Folder, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, folder.Folder{Name: "child"})
+		})
+	err := folders.Delete(mockReaderDeleter, noScenarios)(ctx, identity.Id)
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "deleting a folder with subfolders is not a validation error")
+}
+
+func TestDelete_ContainsScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockFolders.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]folder.Folder{}), map[string][]string{folders.ParentFilterKey: {identity.Id}}, "", false, 1, "").
+		Return(nil)
+	withScenario := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		return []interface{}{&scenario.Scenario{}}, nil
+	}
+	err := folders.Delete(mockReaderDeleter, withScenario)(ctx, identity.Id)
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "deleting a folder with scenarios is not a validation error")
+}
+
+func TestUpdate_Rename(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockFolders.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&folder.Folder{})).
+		Do(func(ctx context.Context, id string, f *folder.Folder) {
+			f.Identity = &identity
+			f.ProjectId = testFolder.ProjectId
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&folder.Folder{}))
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := folders.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updated, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testFolder))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*folder.Folder).Name).To(Equal(testFolder.Name), "folder was not renamed")
+}
+
+func TestUpdate_MoveInsideDescendant(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockFolders.NewMockReaderUpdater(ctrl)
+	expectFolderTree(ctx, mockReaderUpdater.EXPECT())
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	updater := folders.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	_, err := updater(ctx, "tester", "root", transformers.ToReadCloser(&folder.Folder{Name: "root", ProjectId: "zzxxxccvv", ParentId: "grandchild"}))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "moving a folder inside a descendant is not a validation error")
+}
+
+func TestUpdate_Move(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockFolders.NewMockReaderUpdater(ctrl)
+	expectFolderTree(ctx, mockReaderUpdater.EXPECT())
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, "child", matchers.OfType(&folder.Folder{}))
+	mockMetaHandler := mockFolders.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := folders.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updated, err := updater(ctx, "tester", "child", transformers.ToReadCloser(&folder.Folder{Name: "child", ProjectId: "zzxxxccvv", ParentId: "other"}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*folder.Folder).ParentId).To(Equal("other"), "folder was not moved")
+}
+
+func TestDescendants(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockFolders.NewMockGetter(ctrl)
+	expectFolderTree(ctx, mockGetter.EXPECT())
+	ids, err := folders.Descendants(mockGetter)(ctx, "root")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(ids).To(Equal([]string{"root", "child", "grandchild"}), "descendants did not match")
+}
+
+func TestPaths(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockFolders.NewMockGetter(ctrl)
+	expectFolderTree(ctx, mockGetter.EXPECT())
+	paths, err := folders.Paths(mockGetter)(ctx, "zzxxxccvv")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(paths).To(Equal(map[string]string{
+		"root":       "root",
+		"child":      "root/child",
+		"grandchild": "root/child/grandchild",
+		"other":      "other",
+	}), "paths did not match")
+}
+
+func TestSummary(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockFolders.NewMockGetter(ctrl)
+	expectFolderTree(ctx, mockGetter.EXPECT())
+	listScenarios := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{folders.ScenarioFilterKey: {"child", "grandchild"}}), "scenarios were not filtered by folder")
+		return []interface{}{
+			&scenario.Scenario{Automated: true},
+			&scenario.Scenario{},
+		}, nil
+	}
+	summary, err := folders.Summary(mockGetter, listScenarios)(ctx, "child")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(summary).To(Equal(&folder.FolderSummary{FolderId: "child", Scenarios: 2, AutomatedScenarios: 1}), "summary did not match")
+}
+
+func TestMoveScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockFolders.NewMockGetter(ctrl)
+	expectFolderTree(ctx, mockGetter.EXPECT())
+	mover := func(ctx context.Context, user string, projectID string, folderID string, ids []string) ([]interface{}, error) {
+		g.Expect(projectID).To(Equal("zzxxxccvv"), "project did not match")
+		g.Expect(folderID).To(Equal("child"), "folder did not match")
+		return []interface{}{&scenario.Scenario{FolderId: folderID}}, nil
+	}
+	moved, err := folders.MoveScenarios(mockGetter, mover)(ctx, "tester", "child", transformers.ToReadCloser(&folder.MoveScenariosRequest{ScenarioIds: []string{"scenario"}}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(moved).To(HaveLen(1), "moved scenarios were not returned")
+}
+
+func TestMoveScenarios_ValidationError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockFolders.NewMockGetter(ctrl)
+	mover := func(ctx context.Context, user string, projectID string, folderID string, ids []string) ([]interface{}, error) {
+		return nil, fmt.Errorf("should not be called")
+	}
+	_, err := folders.MoveScenarios(mockGetter, mover)(ctx, "tester", "child", transformers.ToReadCloser(&folder.MoveScenariosRequest{}))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty move request is not a validation error")
+}
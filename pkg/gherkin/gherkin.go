@@ -0,0 +1,450 @@
+package gherkin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+// IDTagPrefix is the prefix of the tag that holds the ID of an exported scenario, ie. `@scratch-post-id:4c658344000b9c5`
+const IDTagPrefix = "scratch-post-id:"
+
+// AutomationKey returns the automation key of the scenario with the name of a feature file, ie. `features/login.feature:Successful login`.
+// It is the key of the results of the scenario in a Cucumber report
+func AutomationKey(featurePath string, name string) string {
+	return featurePath + ":" + name
+}
+
+// StepNamePrefix is the prefix of the comment that holds the name of the step started by the next When or Then, ie. `# Step: login`
+const StepNamePrefix = "Step:"
+
+type stepKind int
+
+const (
+	noKind stepKind = iota
+	givenKind
+	whenKind
+	thenKind
+)
+
+var stepKeywords = map[string]stepKind{
+	"Given": givenKind,
+	"When":  whenKind,
+	"Then":  thenKind,
+	"And":   noKind,
+	"But":   noKind,
+	"*":     noKind,
+}
+
+// step is a Gherkin step together with its doc string or data table
+type step struct {
+	kind stepKind
+	text []string
+	// name given by a step name comment
+	name string
+	// the step uses And, But or *
+	continued bool
+}
+
+type examples struct {
+	line   int
+	tags   []string
+	header []string
+	rows   [][]string
+}
+
+type scenario struct {
+	line        int
+	name        string
+	tags        []string
+	description []string
+	outline     bool
+	background  []*step
+	steps       []*step
+	examples    []*examples
+}
+
+type parser struct {
+	path        string
+	featureTags []string
+	ruleTags    []string
+	background  []*step
+	scenarios   []*scenario
+	// steps that are being filled, either the background or the current scenario steps
+	steps *[]*step
+	// kind of the last step, used by And, But and *
+	lastKind stepKind
+	// name of the next step, read from a step name comment
+	stepName string
+	current  *scenario
+	examples *examples
+	// section that receives the free text lines
+	section string
+	tags    []string
+	// doc string that is being read
+	docString      *step
+	docDelimiter   string
+	docIndentation int
+}
+
+// Parse reads the scenarios of a Gherkin feature file. Only the English keywords are supported.
+//
+// The scenarios are mapped to scratch-post scenarios:
+//   - the Given steps, including the ones of the Background, are the prerequisites
+//   - each When step starts a step, its text is the action. The And, But and * steps that follow it are part of the action
+//   - the Then steps are the expected outcome of the step they follow
+//   - a comment with the StepNamePrefix before a step is the name of the step, otherwise the first line of the step is the name
+//   - the tags of the feature, rule, scenario and examples are labels
+//   - the Examples of a Scenario Outline are the parameters of the scenario
+//
+// The feature path is set on all the scenarios and, when it is not empty, the automation key of each scenario is set, see AutomationKey.
+// The scenarios tagged with an ID tag, see IDTagPrefix, have an identity which only contains the ID.
+func Parse(path string, content io.Reader) ([]*scenariov1.Scenario, error) {
+	p := &parser{path: path}
+	reader := bufio.NewScanner(content)
+	reader.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for reader.Scan() {
+		lineNumber++
+		if err := p.parseLine(lineNumber, reader.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+	if p.docString != nil {
+		return nil, p.errorf(lineNumber, "doc string is not closed")
+	}
+	return p.build()
+}
+
+func (p *parser) errorf(line int, format string, args ...interface{}) error {
+	return decoder.NewValidationError(fmt.Sprintf("%s:%d: %s", p.path, line, fmt.Sprintf(format, args...)))
+}
+
+func (p *parser) parseLine(number int, raw string) error {
+	line := strings.TrimSpace(raw)
+	if p.docString != nil {
+		if line == p.docDelimiter {
+			p.docString = nil
+			return nil
+		}
+		p.docString.text = append(p.docString.text, dedent(raw, p.docIndentation))
+		return nil
+	}
+	switch {
+	case line == "":
+		return nil
+	case strings.HasPrefix(line, "#"):
+		language := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if strings.HasPrefix(language, StepNamePrefix) {
+			p.stepName = strings.TrimSpace(strings.TrimPrefix(language, StepNamePrefix))
+			return nil
+		}
+		if strings.HasPrefix(language, "language:") && strings.TrimSpace(strings.TrimPrefix(language, "language:")) != "en" {
+			return p.errorf(number, "only the English keywords are supported")
+		}
+		return nil
+	case strings.HasPrefix(line, "@"):
+		for _, tag := range strings.Fields(line) {
+			if strings.HasPrefix(tag, "#") {
+				break
+			}
+			p.tags = append(p.tags, strings.TrimPrefix(tag, "@"))
+		}
+		return nil
+	case strings.HasPrefix(line, `"""`) || strings.HasPrefix(line, "```"):
+		return p.startDocString(number, raw, line)
+	case strings.HasPrefix(line, "|"):
+		return p.tableRow(number, line)
+	}
+
+	if keyword, name, ok := section(line); ok {
+		return p.startSection(number, keyword, name)
+	}
+	if keyword, text, ok := stepLine(line); ok {
+		return p.addStep(number, keyword, text)
+	}
+	switch p.section {
+	case "scenario":
+		p.current.description = append(p.current.description, line)
+	case "feature", "rule", "background", "examples":
+		// descriptions of features, rules, backgrounds and examples are not imported
+	default:
+		return p.errorf(number, "unexpected line '%s'", line)
+	}
+	return nil
+}
+
+func (p *parser) startSection(number int, keyword string, name string) error {
+	tags := p.tags
+	p.tags = nil
+	p.lastKind = noKind
+	switch keyword {
+	case "Feature":
+		if p.section != "" {
+			return p.errorf(number, "a file can only have one Feature")
+		}
+		p.featureTags = tags
+	case "Rule":
+		if p.section == "" {
+			return p.errorf(number, "Rule has to be part of a Feature")
+		}
+		p.ruleTags = tags
+		p.background = nil
+		p.current = nil
+	case "Background":
+		if p.section == "" || p.current != nil {
+			return p.errorf(number, "Background has to come before the scenarios")
+		}
+		p.background = []*step{}
+		p.steps = &p.background
+	case "Scenario", "Example", "Scenario Outline", "Scenario Template":
+		if p.section == "" {
+			return p.errorf(number, "%s has to be part of a Feature", keyword)
+		}
+		labels := append(append(append([]string{}, p.featureTags...), p.ruleTags...), tags...)
+		p.current = &scenario{
+			line:       number,
+			name:       name,
+			tags:       labels,
+			outline:    keyword == "Scenario Outline" || keyword == "Scenario Template",
+			background: p.background,
+		}
+		p.scenarios = append(p.scenarios, p.current)
+		p.steps = &p.current.steps
+		keyword = "Scenario"
+	case "Examples", "Scenarios":
+		if p.current == nil || !p.current.outline {
+			return p.errorf(number, "%s have to be part of a Scenario Outline", keyword)
+		}
+		p.examples = &examples{line: number, tags: tags}
+		p.current.examples = append(p.current.examples, p.examples)
+		keyword = "Examples"
+	}
+	p.section = strings.ToLower(keyword)
+	return nil
+}
+
+func (p *parser) addStep(number int, keyword string, text string) error {
+	if p.section != "background" && p.section != "scenario" {
+		return p.errorf(number, "step '%s %s' has to be part of a Background or a Scenario", keyword, text)
+	}
+	kind := stepKeywords[keyword]
+	if kind == noKind {
+		kind = p.lastKind
+	}
+	if kind == noKind {
+		kind = whenKind
+	}
+	p.lastKind = kind
+	*p.steps = append(*p.steps, &step{kind: kind, text: []string{text}, name: p.stepName, continued: stepKeywords[keyword] == noKind})
+	p.stepName = ""
+	return nil
+}
+
+func (p *parser) startDocString(number int, raw string, line string) error {
+	last, err := p.lastStep(number, "doc string")
+	if err != nil {
+		return err
+	}
+	p.docString = last
+	p.docDelimiter = line[:3]
+	p.docIndentation = len(raw) - len(strings.TrimLeft(raw, " \t"))
+	return nil
+}
+
+func (p *parser) tableRow(number int, line string) error {
+	if p.section == "examples" {
+		cells := cells(line)
+		if p.examples.header == nil {
+			p.examples.header = cells
+			return nil
+		}
+		if len(cells) != len(p.examples.header) {
+			return p.errorf(number, "row has %d cells instead of %d", len(cells), len(p.examples.header))
+		}
+		p.examples.rows = append(p.examples.rows, cells)
+		return nil
+	}
+	last, err := p.lastStep(number, "data table")
+	if err != nil {
+		return err
+	}
+	last.text = append(last.text, row(cells(line)))
+	return nil
+}
+
+func (p *parser) lastStep(number int, what string) (*step, error) {
+	if (p.section != "background" && p.section != "scenario") || len(*p.steps) == 0 {
+		return nil, p.errorf(number, "%s has to follow a step", what)
+	}
+	return (*p.steps)[len(*p.steps)-1], nil
+}
+
+// stepName returns the name of the scratch-post step started by the Gherkin step
+func (st *step) stepName() string {
+	if st.name != "" {
+		return st.name
+	}
+	return st.text[0]
+}
+
+func (p *parser) build() ([]*scenariov1.Scenario, error) {
+	result := make([]*scenariov1.Scenario, 0, len(p.scenarios))
+	names := map[string]bool{}
+	for _, s := range p.scenarios {
+		if s.name == "" {
+			return nil, p.errorf(s.line, "scenario has no name")
+		}
+		if names[s.name] {
+			return nil, p.errorf(s.line, "scenario '%s' is defined more than once", s.name)
+		}
+		names[s.name] = true
+		converted := &scenariov1.Scenario{
+			Name:        s.name,
+			Description: strings.Join(s.description, "\n"),
+			FeaturePath: p.path,
+		}
+		if p.path != "" {
+			converted.AutomationKeys = []string{AutomationKey(p.path, s.name)}
+		}
+		var prerequisites []string
+		var current *scenariov1.Step
+		for _, st := range append(append([]*step{}, s.background...), s.steps...) {
+			text := strings.Join(st.text, "\n")
+			switch st.kind {
+			case givenKind:
+				prerequisites = append(prerequisites, text)
+			case whenKind:
+				if current == nil || current.ExpectedOutcome != "" || !st.continued {
+					current = &scenariov1.Step{Position: int32(len(converted.Steps) + 1), Name: st.stepName()}
+					converted.Steps = append(converted.Steps, current)
+					current.Action = text
+					continue
+				}
+				current.Action = join(current.Action, text)
+			case thenKind:
+				if current == nil {
+					current = &scenariov1.Step{Position: int32(len(converted.Steps) + 1), Name: st.stepName()}
+					converted.Steps = append(converted.Steps, current)
+				}
+				current.ExpectedOutcome = join(current.ExpectedOutcome, text)
+			}
+		}
+		converted.Prerequisites = strings.Join(prerequisites, "\n")
+
+		labels := s.tags
+		if s.outline && len(s.examples) != 0 {
+			converted.Parameters = &scenariov1.Parameters{Names: s.examples[0].header}
+			for _, e := range s.examples {
+				if strings.Join(e.header, "|") != strings.Join(s.examples[0].header, "|") {
+					return nil, p.errorf(e.line, "all the examples of scenario '%s' have to use the same parameters", s.name)
+				}
+				for _, row := range e.rows {
+					converted.Parameters.Rows = append(converted.Parameters.Rows, &scenariov1.ParameterRow{Values: row})
+				}
+				labels = append(labels, e.tags...)
+			}
+		}
+		for _, label := range unique(labels) {
+			if strings.HasPrefix(label, IDTagPrefix) {
+				converted.Identity = &metadatav1.Identity{Id: strings.TrimPrefix(label, IDTagPrefix)}
+				continue
+			}
+			converted.Labels = append(converted.Labels, label)
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// section returns the keyword and the name of a line that starts a section, ie. `Feature: Login`
+func section(line string) (string, string, bool) {
+	for _, keyword := range []string{"Feature", "Rule", "Background", "Scenario Outline", "Scenario Template", "Scenario", "Example", "Examples", "Scenarios"} {
+		if strings.HasPrefix(line, keyword+":") {
+			return keyword, strings.TrimSpace(strings.TrimPrefix(line, keyword+":")), true
+		}
+	}
+	return "", "", false
+}
+
+// stepLine returns the keyword and the text of a step line, ie. `Given the user is logged in`
+func stepLine(line string) (string, string, bool) {
+	for keyword := range stepKeywords {
+		if strings.HasPrefix(line, keyword+" ") {
+			return keyword, strings.TrimSpace(strings.TrimPrefix(line, keyword)), true
+		}
+	}
+	return "", "", false
+}
+
+// cells returns the trimmed cells of a table row. Escaped pipes are kept in the cell
+func cells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	var result []string
+	var cell strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			if r != '|' && r != '\\' {
+				cell.WriteRune('\\')
+			}
+			cell.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '|':
+			result = append(result, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteRune(r)
+		}
+	}
+	return append(result, strings.TrimSpace(cell.String()))
+}
+
+// row formats the cells as a table row, escaping the pipes in the cells
+func row(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(c, `\`, `\\`), "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// dedent removes up to indentation leading whitespace characters from the line
+func dedent(line string, indentation int) string {
+	for i := 0; i < indentation && len(line) > 0 && (line[0] == ' ' || line[0] == '\t'); i++ {
+		line = line[1:]
+	}
+	return line
+}
+
+func join(first string, second string) string {
+	if first == "" {
+		return second
+	}
+	return first + "\n" + second
+}
+
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package gherkin_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/gherkin"
+)
+
+const feature = `# language: en
+@web @login
+Feature: Login
+  Users log in with their email
+
+  Background:
+    Given the login page is open
+
+  @smoke
+  Scenario: Successful login
+    A registered user can log in
+    Given the user is registered
+    When the user enters valid credentials
+    And the user submits the form
+    Then the dashboard is shown
+    But no error is shown
+    When the user logs out
+    Then the login page is shown
+
+  Scenario Outline: Failed login
+    When the user enters "<email>" and "<password>"
+      """
+      {"remember": true}
+      """
+    Then the error <error> is shown
+
+    @regression
+    Examples: invalid emails
+      | email   | password | error         |
+      | a@b     | secret   | invalid email |
+
+    Examples: wrong passwords
+      | email   | password | error          |
+      | a@b.com | wrong    | wrong password |
+`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	scenarios, err := gherkin.Parse("features/login.feature", strings.NewReader(feature))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(scenarios).To(HaveLen(2), "scenarios were not parsed")
+
+	login := scenarios[0]
+	g.Expect(login.Name).To(Equal("Successful login"))
+	g.Expect(login.Description).To(Equal("A registered user can log in"))
+	g.Expect(login.FeaturePath).To(Equal("features/login.feature"))
+	g.Expect(login.AutomationKeys).To(Equal([]string{"features/login.feature:Successful login"}), "automation key of the Cucumber results was not set")
+	g.Expect(login.Prerequisites).To(Equal("the login page is open\nthe user is registered"), "background and given steps are not the prerequisites")
+	g.Expect(login.Labels).To(Equal([]string{"web", "login", "smoke"}), "tags are not the labels")
+	g.Expect(login.Steps).To(HaveLen(2))
+	g.Expect(login.Steps[0].Position).To(Equal(int32(1)))
+	g.Expect(login.Steps[0].Name).To(Equal("the user enters valid credentials"))
+	g.Expect(login.Steps[0].Action).To(Equal("the user enters valid credentials\nthe user submits the form"))
+	g.Expect(login.Steps[0].ExpectedOutcome).To(Equal("the dashboard is shown\nno error is shown"))
+	g.Expect(login.Steps[1].Position).To(Equal(int32(2)))
+	g.Expect(login.Steps[1].Action).To(Equal("the user logs out"))
+	g.Expect(login.Steps[1].ExpectedOutcome).To(Equal("the login page is shown"))
+	g.Expect(login.Parameters).To(BeNil())
+
+	outline := scenarios[1]
+	g.Expect(outline.Steps).To(HaveLen(1))
+	g.Expect(outline.Steps[0].Action).To(Equal("the user enters \"<email>\" and \"<password>\"\n{\"remember\": true}"), "doc string was not added to the step")
+	g.Expect(outline.Labels).To(Equal([]string{"web", "login", "regression"}), "examples tags are not labels")
+	g.Expect(outline.Parameters.Names).To(Equal([]string{"email", "password", "error"}))
+	g.Expect(outline.Parameters.Rows).To(HaveLen(2), "rows of all the examples were not kept")
+	g.Expect(outline.Parameters.Rows[1].Values).To(Equal([]string{"a@b.com", "wrong", "wrong password"}))
+}
+
+func TestParse_DataTableAndRules(t *testing.T) {
+	g := NewWithT(t)
+	content := `Feature: Cart
+  Rule: Guests
+    Background:
+      Given the user is a guest
+
+    Example: Add to cart
+      When the user adds the products
+        | name  | count |
+        | apple | 2     |
+      Then the cart contains 2 products
+
+  Rule: Members
+    Scenario: Checkout
+      Then the checkout is available
+`
+	scenarios, err := gherkin.Parse("cart.feature", strings.NewReader(content))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(scenarios).To(HaveLen(2))
+	g.Expect(scenarios[0].Prerequisites).To(Equal("the user is a guest"))
+	g.Expect(scenarios[0].Steps[0].Action).To(Equal("the user adds the products\n| name | count |\n| apple | 2 |"), "data table was not added to the step")
+	g.Expect(scenarios[1].Prerequisites).To(BeEmpty(), "background of another rule was used")
+	g.Expect(scenarios[1].Steps).To(Equal([]*scenario.Step{{Position: 1, Name: "the checkout is available", ExpectedOutcome: "the checkout is available"}}))
+}
+
+func TestParse_Errors(t *testing.T) {
+	g := NewWithT(t)
+	for name, content := range map[string]string{
+		"language":           "# language: fr\nFonctionnalité: Connexion",
+		"no feature":         "Scenario: Login\n  When the user logs in",
+		"duplicate scenario": "Feature: Login\nScenario: Login\nScenario: Login",
+		"examples row":       "Feature: Login\nScenario Outline: Login\n  When <a>\n  Examples:\n  | a | b |\n  | 1 |",
+		"examples headers":   "Feature: Login\nScenario Outline: Login\n  When <a>\n  Examples:\n  | a |\n  | 1 |\n  Examples:\n  | b |\n  | 2 |",
+		"doc string":         "Feature: Login\nScenario: Login\n  When the user logs in\n  \"\"\"\n  text",
+		"examples":           "Feature: Login\nScenario: Login\n  Examples:",
+		"scenario name":      "Feature: Login\nScenario:\n  When the user logs in",
+	} {
+		_, err := gherkin.Parse("login.feature", strings.NewReader(content))
+		g.Expect(err).Should(HaveOccurred(), "no error for %s", name)
+		g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "%s error is not a validation error", name)
+		g.Expect(err.Error()).To(HavePrefix("login.feature:"), "%s error does not contain the location", name)
+	}
+}
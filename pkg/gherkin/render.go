@@ -0,0 +1,111 @@
+package gherkin
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+// Render writes the scenarios as a Gherkin feature file. It is the reverse of Parse:
+//   - the labels are tags and the ID of the scenario is an ID tag, see IDTagPrefix
+//   - the prerequisites are a Background when all the scenarios share them, otherwise they are Given steps
+//   - the action of a step is a When step and the expected outcome is a Then step. The name of the step is a step name comment,
+//     see StepNamePrefix, when it is not the first line of the action
+//   - the parameters are the Examples of a Scenario Outline
+//
+// The steps of the step blocks are not rendered, they have to be inlined beforehand.
+func Render(w io.Writer, feature string, scenarios []*scenariov1.Scenario) error {
+	out := bufio.NewWriter(w)
+	out.WriteString("Feature: " + feature + "\n")
+
+	background := sharedPrerequisites(scenarios)
+	if background != "" {
+		out.WriteString("\n  Background:\n")
+		writeSteps(out, "Given", background)
+	}
+	for _, s := range scenarios {
+		out.WriteString("\n")
+		tags := []string{}
+		if s.Identity != nil && s.Identity.Id != "" {
+			tags = append(tags, "@"+IDTagPrefix+s.Identity.Id)
+		}
+		for _, label := range s.Labels {
+			tags = append(tags, "@"+strings.ReplaceAll(label, " ", "_"))
+		}
+		if len(tags) != 0 {
+			out.WriteString("  " + strings.Join(tags, " ") + "\n")
+		}
+		outline := s.Parameters != nil && len(s.Parameters.Names) != 0
+		if outline {
+			out.WriteString("  Scenario Outline: " + s.Name + "\n")
+		} else {
+			out.WriteString("  Scenario: " + s.Name + "\n")
+		}
+		for _, line := range lines(s.Description) {
+			out.WriteString("    " + line + "\n")
+		}
+		if background == "" {
+			writeSteps(out, "Given", s.Prerequisites)
+		}
+		for _, step := range s.Steps {
+			action := step.Action
+			if action == "" {
+				action = step.Name
+			}
+			if name := strings.Join(lines(step.Name), " "); name != "" && lines(action)[0] != name {
+				out.WriteString("    # " + StepNamePrefix + " " + name + "\n")
+			}
+			writeSteps(out, "When", action)
+			writeSteps(out, "Then", step.ExpectedOutcome)
+		}
+		if outline {
+			out.WriteString("\n    Examples:\n")
+			out.WriteString("      " + row(s.Parameters.Names) + "\n")
+			for _, r := range s.Parameters.Rows {
+				out.WriteString("      " + row(r.Values) + "\n")
+			}
+		}
+	}
+	return out.Flush()
+}
+
+// writeSteps writes each line of the text as a step, the first one with the keyword and the others with And.
+// Lines of data tables are written as they are
+func writeSteps(out *bufio.Writer, keyword string, text string) {
+	for i, line := range lines(text) {
+		switch {
+		case i != 0 && strings.HasPrefix(line, "|"):
+			out.WriteString("      " + line + "\n")
+		case i == 0:
+			out.WriteString("    " + keyword + " " + line + "\n")
+		default:
+			out.WriteString("    And " + line + "\n")
+		}
+	}
+}
+
+// sharedPrerequisites returns the prerequisites when they are the same for all the scenarios
+func sharedPrerequisites(scenarios []*scenariov1.Scenario) string {
+	if len(scenarios) < 2 {
+		return ""
+	}
+	for _, s := range scenarios[1:] {
+		if s.Prerequisites != scenarios[0].Prerequisites {
+			return ""
+		}
+	}
+	return scenarios[0].Prerequisites
+}
+
+// lines returns the non empty lines of the text
+func lines(text string) []string {
+	var result []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package gherkin_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/gherkin"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+	scenarios := []*scenario.Scenario{
+		{
+			Identity:      &metadata.Identity{Id: "login"},
+			Name:          "Successful login",
+			Description:   "A registered user can log in",
+			Prerequisites: "the login page is open",
+			Labels:        []string{"smoke", "high priority"},
+			Steps: []*scenario.Step{
+				{Position: 1, Name: "login", Action: "the user enters valid credentials\nthe user submits the form", ExpectedOutcome: "the dashboard is shown"},
+			},
+		},
+		{
+			Identity:      &metadata.Identity{Id: "failed"},
+			Name:          "Failed login",
+			Prerequisites: "the login page is open",
+			Steps: []*scenario.Step{
+				{Position: 1, Name: "login", Action: "the user enters <email>\n| remember | true |", ExpectedOutcome: "the error <error> is shown"},
+			},
+			Parameters: &scenario.Parameters{
+				Names: []string{"email", "error"},
+				Rows:  []*scenario.ParameterRow{{Values: []string{"a@b", "invalid | email"}}},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := gherkin.Render(out, "Login", scenarios)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(out.String()).To(Equal(`Feature: Login
+
+  Background:
+    Given the login page is open
+
+  @scratch-post-id:login @smoke @high_priority
+  Scenario: Successful login
+    A registered user can log in
+    # Step: login
+    When the user enters valid credentials
+    And the user submits the form
+    Then the dashboard is shown
+
+  @scratch-post-id:failed
+  Scenario Outline: Failed login
+    # Step: login
+    When the user enters <email>
+      | remember | true |
+    Then the error <error> is shown
+
+    Examples:
+      | email | error |
+      | a@b | invalid \| email |
+`), "feature file did not match")
+
+	parsed, err := gherkin.Parse("login.feature", out)
+	g.Expect(err).ShouldNot(HaveOccurred(), "rendered feature file could not be parsed")
+	g.Expect(parsed).To(HaveLen(2))
+	g.Expect(parsed[0].Identity.Id).To(Equal("login"), "ID tag was not read")
+	g.Expect(parsed[0].Labels).To(Equal([]string{"smoke", "high_priority"}), "ID tag is a label")
+	g.Expect(parsed[0].Prerequisites).To(Equal("the login page is open"))
+	g.Expect(proto.Equal(parsed[1].Parameters, scenarios[1].Parameters)).To(BeTrue(), "parameters did not survive the round trip")
+	g.Expect(parsed[1].Steps[0].Action).To(Equal(scenarios[1].Steps[0].Action), "data table did not survive the round trip")
+}
+
+func TestRender_Prerequisites(t *testing.T) {
+	g := NewWithT(t)
+	scenarios := []*scenario.Scenario{
+		{Name: "first", Prerequisites: "the user is logged in"},
+		{Name: "second", Prerequisites: "the user is logged out\nthe cart is empty"},
+	}
+	out := &bytes.Buffer{}
+	err := gherkin.Render(out, "Cart", scenarios)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(out.String()).ToNot(ContainSubstring("Background:"), "different prerequisites were rendered as a background")
+	parsed, err := gherkin.Parse("cart.feature", out)
+	g.Expect(err).ShouldNot(HaveOccurred(), "rendered feature file could not be parsed")
+	g.Expect(parsed[1].Prerequisites).To(Equal("the user is logged out\nthe cart is empty"), "prerequisites did not survive the round trip")
+}
+
+func TestRender_RoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	scenarios := []*scenario.Scenario{
+		{
+			Identity:      &metadata.Identity{Id: "checkout"},
+			Name:          "Checkout",
+			Prerequisites: "the cart is not empty",
+			Labels:        []string{"smoke"},
+			Steps: []*scenario.Step{
+				{Position: 1, Name: "open the cart", Action: "the user opens the cart"},
+				{Position: 2, Name: "the user pays", Action: "the user pays\nthe user confirms"},
+				{Position: 3, Name: "confirmation", Action: "the user waits", ExpectedOutcome: "the order is confirmed\nan email is sent"},
+				{Position: 4, Name: "the user logs out", Action: "the user logs out"},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	g.Expect(gherkin.Render(out, "Shop", scenarios)).To(Succeed(), "unexpected error occurred")
+	parsed, err := gherkin.Parse("shop.feature", out)
+	g.Expect(err).ShouldNot(HaveOccurred(), "rendered feature file could not be parsed")
+	g.Expect(parsed).To(HaveLen(1))
+	parsed[0].FeaturePath = ""
+	parsed[0].AutomationKeys = nil
+	g.Expect(proto.Equal(parsed[0], scenarios[0])).To(BeTrue(), "scenario did not survive the round trip: %v", parsed[0])
+}
@@ -0,0 +1,106 @@
+package gotest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+)
+
+// event is a line of the output of `go test -json`, see `go doc test2json`
+type event struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// test is the aggregation of the events of a test
+type test struct {
+	result *executionv1.TestResult
+	output strings.Builder
+	done   bool
+}
+
+// Parse reads the event stream produced by `go test -json`. Every test, including subtests, is a result.
+//
+// The automation key of a test is `package.Test`, ie. `github.com/org/shop/cart.TestAdd/empty_cart` for a subtest.
+// The output of the test is kept and the lines it logged are the actual result of failed tests.
+// Tests that did not finish, ie. because the package timed out or panicked, fail.
+// Lines that are not JSON objects, ie. build errors, are ignored.
+func Parse(content io.Reader) ([]*executionv1.TestResult, error) {
+	tests := map[string]*test{}
+	var order []*test
+	reader := bufio.NewScanner(content)
+	reader.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	lineNumber := 0
+	for reader.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(reader.Bytes())
+		if !bytes.HasPrefix(line, []byte("{")) {
+			continue
+		}
+		e := &event{}
+		if err := json.Unmarshal(line, e); err != nil {
+			return nil, decoder.NewValidationError(fmt.Sprintf("invalid go test event on line %d: %s", lineNumber, err))
+		}
+		if e.Test == "" {
+			continue
+		}
+		key := e.Package + "." + e.Test
+		t, ok := tests[key]
+		if !ok {
+			t = &test{result: &executionv1.TestResult{Key: key, Name: e.Test, Status: executionv1.Status_Pending}}
+			tests[key] = t
+			order = append(order, t)
+		}
+		switch e.Action {
+		case "output":
+			t.output.WriteString(e.Output)
+		case "pass", "fail", "skip":
+			t.done = true
+			t.result.Duration = e.Elapsed
+			t.result.Status = map[string]executionv1.Status{
+				"pass": executionv1.Status_Pass,
+				"fail": executionv1.Status_Fail,
+				"skip": executionv1.Status_Skipped,
+			}[e.Action]
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*executionv1.TestResult, 0, len(order))
+	for _, t := range order {
+		t.result.Output = t.output.String()
+		switch {
+		case !t.done:
+			t.result.Status = executionv1.Status_Fail
+			t.result.ActualResult = "test did not finish"
+		case t.result.Status == executionv1.Status_Fail:
+			t.result.ActualResult = logged(t.result.Output)
+		}
+		results = append(results, t.result)
+	}
+	return results, nil
+}
+
+// logged returns the lines of the output which are not the ones go test prints when a test starts or ends
+func logged(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- ") {
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,61 @@
+package gotest_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/gotest"
+)
+
+const events = `# github.com/org/shop/build
+{"Action":"start","Package":"github.com/org/shop/cart"}
+{"Action":"run","Package":"github.com/org/shop/cart","Test":"TestAdd"}
+{"Action":"output","Package":"github.com/org/shop/cart","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"run","Package":"github.com/org/shop/cart","Test":"TestAdd/empty_cart"}
+{"Action":"output","Package":"github.com/org/shop/cart","Test":"TestAdd/empty_cart","Output":"=== RUN   TestAdd/empty_cart\n"}
+{"Action":"output","Package":"github.com/org/shop/cart","Test":"TestAdd/empty_cart","Output":"    cart_test.go:12: expected 1 product, got 0\n"}
+{"Action":"output","Package":"github.com/org/shop/cart","Test":"TestAdd/empty_cart","Output":"--- FAIL: TestAdd/empty_cart (0.01s)\n"}
+{"Action":"fail","Package":"github.com/org/shop/cart","Test":"TestAdd/empty_cart","Elapsed":0.01}
+{"Action":"run","Package":"github.com/org/shop/cart","Test":"TestAdd/full_cart"}
+{"Action":"skip","Package":"github.com/org/shop/cart","Test":"TestAdd/full_cart","Elapsed":0}
+{"Action":"fail","Package":"github.com/org/shop/cart","Test":"TestAdd","Elapsed":0.02}
+{"Action":"run","Package":"github.com/org/shop/cart","Test":"TestRemove"}
+{"Action":"pass","Package":"github.com/org/shop/cart","Test":"TestRemove","Elapsed":1.5}
+{"Action":"run","Package":"github.com/org/shop/cart","Test":"TestCheckout"}
+{"Action":"output","Package":"github.com/org/shop/cart","Test":"TestCheckout","Output":"panic: test timed out after 10m0s\n"}
+{"Action":"fail","Package":"github.com/org/shop/cart","Elapsed":600}
+`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	results, err := gotest.Parse(strings.NewReader(events))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(results).To(HaveLen(5), "every test and subtest is not a result")
+
+	g.Expect(results[0].Key).To(Equal("github.com/org/shop/cart.TestAdd"), "key is not the package and the test name")
+	g.Expect(results[0].Status).To(Equal(execution.Status_Fail))
+
+	subtest := results[1]
+	g.Expect(subtest.Key).To(Equal("github.com/org/shop/cart.TestAdd/empty_cart"), "key of the subtest did not match")
+	g.Expect(subtest.Name).To(Equal("TestAdd/empty_cart"))
+	g.Expect(subtest.Status).To(Equal(execution.Status_Fail), "failed subtest did not fail")
+	g.Expect(subtest.Duration).To(Equal(0.01), "duration was not set")
+	g.Expect(subtest.ActualResult).To(Equal("cart_test.go:12: expected 1 product, got 0"), "logged lines are not the actual result")
+	g.Expect(subtest.Output).To(ContainSubstring("--- FAIL: TestAdd/empty_cart"), "output was not kept")
+
+	g.Expect(results[2].Status).To(Equal(execution.Status_Skipped), "skipped subtest was not skipped")
+	g.Expect(results[3].Status).To(Equal(execution.Status_Pass), "passed test did not pass")
+	g.Expect(results[3].Duration).To(Equal(1.5))
+	g.Expect(results[4].Status).To(Equal(execution.Status_Fail), "test that did not finish did not fail")
+	g.Expect(results[4].ActualResult).To(Equal("test did not finish"))
+}
+
+func TestParse_InvalidEvent(t *testing.T) {
+	g := NewWithT(t)
+	_, err := gotest.Parse(strings.NewReader(`{"Action":"run",`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid event is not a validation error")
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/http/response"
+	"github.com/curious-kitten/scratch-post/internal/logger"
+)
+
+// Validate checks that the request has a query
+func (r *Request) Validate() error {
+	if r.Query == "" {
+		return decoder.NewValidationError("query not provided")
+	}
+	return nil
+}
+
+// Register attaches the GraphQL endpoint to the router. The query is read from the body of POST requests, or from the
+// `query`, `operationName` and `variables` parameters of GET requests. It returns the registered routes
+func Register(ctx context.Context, schema *Schema, r *mux.Router, log logger.Logger) (get *mux.Route, post *mux.Route) {
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		if r.Method == http.MethodGet {
+			request.Query = r.URL.Query().Get("query")
+			request.OperationName = r.URL.Query().Get("operationName")
+			if variables := r.URL.Query().Get("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+					response.Send(w, r, &Response{Errors: []*Error{{Message: "invalid variables: " + err.Error()}}}, http.StatusBadRequest)
+					return
+				}
+			}
+			if err := request.Validate(); err != nil {
+				response.Send(w, r, &Response{Errors: []*Error{{Message: err.Error()}}}, http.StatusBadRequest)
+				return
+			}
+		} else if err := decoder.Decode(request, decoder.WithContentType(r.Body, r.Header.Get("Content-Type"))); err != nil {
+			response.Send(w, r, &Response{Errors: []*Error{{Message: err.Error()}}}, http.StatusBadRequest)
+			return
+		}
+		toctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		result := schema.Execute(toctx, request)
+		if result.Data == nil {
+			// the query could not be executed
+			response.Send(w, r, result, http.StatusBadRequest)
+			return
+		}
+		response.Send(w, r, result, http.StatusOK)
+	}
+	add := func(method string) *mux.Route {
+		route := r.HandleFunc("", handle).Methods(method)
+		path, _ := route.GetPathTemplate()
+		log.Infow("added endpoint", "path", path, "method", method)
+		return route
+	}
+	return add(http.MethodGet), add(http.MethodPost)
+}
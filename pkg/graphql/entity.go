@@ -0,0 +1,356 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// filterType is the input type of the filters of the lists
+const filterType = "Filter"
+
+// identityKey holds the ID of the items in the store
+const identityKey = "identity.id"
+
+// ListFunc lists the items of a store collection, as the List functions of the packages
+type ListFunc func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+
+type entity struct {
+	name string
+	list ListFunc
+}
+
+// listArguments are the arguments of the lists, named as the query parameters of the REST collections
+var listArguments = []Argument{
+	{Name: "filter", Type: "[" + filterType + "!]", Description: "items to keep. The values of a field are alternatives, the fields have to match together"},
+	{Name: "sortBy", Type: "String", Description: "field to sort by, as `field:asc` or `field:desc`"},
+	{Name: "count", Type: "Int", Description: "maximum number of items"},
+	{Name: "lastValue", Type: "String", Description: "value of the sort field of the last item of the previous page"},
+}
+
+// Entity adds the object type of the items of a store collection, with the queries of an item by ID and of a list of items,
+// ie. `project(id: ID!)` and `projects(filter, sortBy, count, lastValue)`. The name of the type is returned
+func (s *Schema) Entity(item proto.Message, single string, plural string, list ListFunc) string {
+	name := s.message(item.ProtoReflect().Descriptor())
+	e := &entity{name: name, list: list}
+	s.entities[name] = e
+	s.Field(Query, single, Field{
+		Description: fmt.Sprintf("The %s with the ID", name),
+		Type:        name,
+		Arguments:   []Argument{{Name: "id", Type: "ID!"}},
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			items, err := e.load(ctx, []string{args["id"].(string)})
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{items[args["id"].(string)]}, nil
+		},
+	})
+	s.Field(Query, plural, Field{
+		Description: fmt.Sprintf("The %s items", name),
+		Type:        "[" + name + "!]",
+		Arguments:   listArguments,
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			filter, sortBy, reverse, count, lastValue := listOptions(args)
+			items, err := list(ctx, filter, sortBy, reverse, count, lastValue)
+			if err != nil {
+				return nil, err
+			}
+			e.remember(ctx, items)
+			return []interface{}{items}, nil
+		},
+	})
+	return name
+}
+
+// Reference adds a field to an object type which returns the entities whose IDs are held by the key, ie. the project of a test plan
+// from its `projectId`. A key holding several IDs returns a list. The entities of all the objects of the response are read at once
+func (s *Schema) Reference(objectName string, field string, key string, entityName string) {
+	e := s.entity(entityName)
+	path := strings.Split(key, ".")
+	typ := entityName
+	if s.isList(objectName, path) {
+		typ = "[" + entityName + "!]"
+	}
+	s.Field(objectName, field, Field{
+		Description: fmt.Sprintf("The %s of `%s`", entityName, key),
+		Type:        typ,
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			ids := [][]string{}
+			all := []string{}
+			for _, parent := range parents {
+				parentIDs, err := keys(parent, path)
+				if err != nil {
+					return nil, err
+				}
+				ids = append(ids, parentIDs)
+				all = append(all, parentIDs...)
+			}
+			items, err := e.load(ctx, all)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, len(parents))
+			for i := range parents {
+				found := []interface{}{}
+				for _, id := range ids[i] {
+					if item, ok := items[id]; ok {
+						found = append(found, item)
+					}
+				}
+				switch {
+				case typ != entityName:
+					values[i] = found
+				case len(found) > 0:
+					values[i] = found[0]
+				}
+			}
+			return values, nil
+		},
+	})
+}
+
+// Collection adds a field to an object type which lists the entities whose key holds the ID of the object, ie. the executions of
+// a test plan from their `testPlanId`. The field has the arguments of the lists, applied to the items of each object.
+// The entities of all the objects of the response are read at once, unless a count is set: the store then reads the count of items of each object
+func (s *Schema) Collection(objectName string, field string, entityName string, key string) {
+	e := s.entity(entityName)
+	path := strings.Split(key, ".")
+	s.Field(objectName, field, Field{
+		Description: fmt.Sprintf("The %s items whose `%s` is the ID of the %s", entityName, key, objectName),
+		Type:        "[" + entityName + "!]",
+		Arguments:   listArguments,
+		Resolve: func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+			ids := make([]string, len(parents))
+			for i, parent := range parents {
+				id, err := keys(parent, []string{"identity", "id"})
+				if err != nil {
+					return nil, err
+				}
+				if len(id) == 1 {
+					ids[i] = id[0]
+				}
+			}
+			filter, sortBy, reverse, count, lastValue := listOptions(args)
+			byID := map[string][]interface{}{}
+			if count > 0 {
+				// the count applies to each object, so the items of each object are read on their own, with the count
+				for _, id := range ids {
+					if _, ok := byID[id]; ok || id == "" {
+						continue
+					}
+					objectFilter := map[string][]string{}
+					for field, values := range filter {
+						objectFilter[field] = values
+					}
+					objectFilter[strings.ToLower(key)] = []string{id}
+					items, err := e.list(ctx, objectFilter, sortBy, reverse, count, lastValue)
+					if err != nil {
+						return nil, err
+					}
+					e.remember(ctx, items)
+					byID[id] = items
+				}
+			} else {
+				filter[strings.ToLower(key)] = ids
+				items, err := e.list(ctx, filter, sortBy, reverse, 0, lastValue)
+				if err != nil {
+					return nil, err
+				}
+				e.remember(ctx, items)
+				for _, item := range items {
+					itemKeys, err := keys(item, path)
+					if err != nil {
+						return nil, err
+					}
+					for _, id := range itemKeys {
+						byID[id] = append(byID[id], item)
+					}
+				}
+			}
+			values := make([]interface{}, len(parents))
+			for i, id := range ids {
+				found := byID[id]
+				if found == nil {
+					found = []interface{}{}
+				}
+				values[i] = found
+			}
+			return values, nil
+		},
+	})
+}
+
+func (s *Schema) entity(name string) *entity {
+	e, ok := s.entities[name]
+	if !ok {
+		panic(fmt.Sprintf("graphql: entity %q does not exist", name))
+	}
+	return e
+}
+
+// isList checks if the path of an object type goes through a list
+func (s *Schema) isList(objectName string, path []string) bool {
+	descriptor, ok := s.descriptors[objectName]
+	if !ok {
+		panic(fmt.Sprintf("graphql: type %q is not a protobuf message", objectName))
+	}
+	for _, name := range path {
+		field := descriptor.Fields().ByJSONName(name)
+		if field == nil {
+			panic(fmt.Sprintf("graphql: %q has no field %q", objectName, name))
+		}
+		if field.IsList() {
+			return true
+		}
+		descriptor = field.Message()
+		if descriptor == nil {
+			return false
+		}
+	}
+	return false
+}
+
+// load returns the entities with the IDs, keyed by ID. The entities which were already read by the request are not read again
+func (e *entity) load(ctx context.Context, ids []string) (map[string]interface{}, error) {
+	c := cacheOf(ctx)
+	found, missing := c.get(e.name, ids)
+	if len(missing) == 0 {
+		return found, nil
+	}
+	items, err := e.list(ctx, map[string][]string{identityKey: missing}, "", false, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for id, item := range c.add(e.name, items) {
+		found[id] = item
+	}
+	return found, nil
+}
+
+func (e *entity) remember(ctx context.Context, items []interface{}) {
+	cacheOf(ctx).add(e.name, items)
+}
+
+// listOptions returns the arguments of a list as they are passed to the List functions
+func listOptions(args map[string]interface{}) (map[string][]string, string, bool, int, string) {
+	filter := map[string][]string{}
+	if filters, ok := args["filter"].([]interface{}); ok {
+		for _, f := range filters {
+			f := f.(map[string]interface{})
+			field := f["field"].(string)
+			for _, v := range f["values"].([]interface{}) {
+				filter[field] = append(filter[field], v.(string))
+			}
+		}
+	}
+	sortBy, reverse := "", false
+	if sorting, ok := args["sortBy"].(string); ok && sorting != "" {
+		sortValues := strings.Split(sorting, ":")
+		sortBy = sortValues[0]
+		if len(sortValues) == 2 && strings.ToLower(sortValues[1]) == "desc" {
+			reverse = true
+		}
+	}
+	count, _ := args["count"].(int)
+	lastValue, _ := args["lastValue"].(string)
+	return filter, sortBy, reverse, count, lastValue
+}
+
+// keys returns the strings held by the path of JSON names of a message, ie. `steps.stepBlockId`
+func keys(item interface{}, path []string) ([]string, error) {
+	message, ok := item.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a protobuf message", item)
+	}
+	return messageKeys(message.ProtoReflect(), path), nil
+}
+
+func messageKeys(m protoreflect.Message, path []string) []string {
+	field := m.Descriptor().Fields().ByJSONName(path[0])
+	if field == nil {
+		return nil
+	}
+	values := []protoreflect.Value{}
+	if field.IsList() {
+		list := m.Get(field).List()
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, list.Get(i))
+		}
+	} else if m.Has(field) {
+		values = append(values, m.Get(field))
+	}
+	found := []string{}
+	for _, v := range values {
+		switch {
+		case len(path) > 1 && field.Message() != nil:
+			found = append(found, messageKeys(v.Message(), path[1:])...)
+		case len(path) == 1 && field.Kind() == protoreflect.StringKind && v.String() != "":
+			found = append(found, v.String())
+		}
+	}
+	return found
+}
+
+type cacheKey struct{}
+
+// cache keeps the entities read by a request, keyed by type and ID
+type cache struct {
+	mu    sync.Mutex
+	items map[string]map[string]interface{}
+}
+
+func withCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheKey{}, &cache{items: map[string]map[string]interface{}{}})
+}
+
+func cacheOf(ctx context.Context) *cache {
+	if c, ok := ctx.Value(cacheKey{}).(*cache); ok {
+		return c
+	}
+	return &cache{items: map[string]map[string]interface{}{}}
+}
+
+// get returns the cached entities and the IDs which are not cached
+func (c *cache) get(name string, ids []string) (map[string]interface{}, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := map[string]interface{}{}
+	missing := []string{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if item, ok := c.items[name][id]; ok {
+			found[id] = item
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing
+}
+
+// add caches the entities and returns them keyed by ID
+func (c *cache) add(name string, items []interface{}) map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items[name] == nil {
+		c.items[name] = map[string]interface{}{}
+	}
+	added := map[string]interface{}{}
+	for _, item := range items {
+		id, err := keys(item, []string{"identity", "id"})
+		if err != nil || len(id) != 1 {
+			continue
+		}
+		c.items[name][id[0]] = item
+		added[id[0]] = item
+	}
+	return added
+}
@@ -0,0 +1,636 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Location of an error in the query
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error of a query. Errors of the fields have the path of the field in the data
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Request is a GraphQL query, as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Response holds the data of a query and the errors. Data is missing when the query could not be executed
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Execute runs a query. Mutations and subscriptions are not supported
+func (s *Schema) Execute(ctx context.Context, request *Request) *Response {
+	doc, err := parse(request.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	op, err := doc.operation(request.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	e := &executor{schema: s, fragments: doc.fragments, declared: map[string]bool{}}
+	for _, v := range op.variables {
+		e.declared[v.name] = true
+	}
+	if e.variables, err = s.variables(op, request.Variables); err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	if err := e.validate(Query, op.selections, 1); err != nil {
+		return &Response{Errors: []*Error{asError(err)}}
+	}
+	data := e.selectionSet(withCache(ctx), Query, []interface{}{nil}, [][]interface{}{{}}, op.selections)
+	return &Response{Data: data[0], Errors: e.errors}
+}
+
+func asError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	var found *operation
+	for _, op := range d.operations {
+		switch {
+		case name == "" && found != nil:
+			return nil, &Error{Message: "the document has several operations, the operationName has to be set"}
+		case name == "" || op.name == name:
+			found = op
+		}
+	}
+	if found == nil {
+		return nil, &Error{Message: fmt.Sprintf("operation %q does not exist", name)}
+	}
+	if found.kind != "query" {
+		return nil, &Error{Message: fmt.Sprintf("%s operations are not supported", found.kind), Locations: []Location{found.location}}
+	}
+	return found, nil
+}
+
+// variables coerces the variables of the request to the types declared by the operation
+func (s *Schema) variables(op *operation, values map[string]interface{}) (map[string]interface{}, error) {
+	coerced := map[string]interface{}{}
+	for _, v := range op.variables {
+		if !s.isInput(v.typ.named()) {
+			return nil, &Error{Message: fmt.Sprintf("variable $%s: type %s is not an input type", v.name, v.typ)}
+		}
+		value, ok := values[v.name]
+		if !ok && v.fallback != nil {
+			var err error
+			if value, err = literal(v.fallback, nil); err != nil {
+				return nil, err
+			}
+			ok = true
+		}
+		if !ok {
+			if v.typ.nonNull {
+				return nil, &Error{Message: fmt.Sprintf("variable $%s of type %s is not set", v.name, v.typ)}
+			}
+			continue
+		}
+		c, err := s.coerce(v.typ, value)
+		if err != nil {
+			return nil, &Error{Message: fmt.Sprintf("variable $%s: %s", v.name, err.Error())}
+		}
+		coerced[v.name] = c
+	}
+	return coerced, nil
+}
+
+func (s *Schema) isInput(name string) bool {
+	_, scalar := s.scalars[name]
+	_, enum := s.enums[name]
+	_, in := s.inputs[name]
+	return scalar || enum || in
+}
+
+// literal returns the value of the query. Variables which are not set are nil
+func literal(v *value, variables map[string]interface{}) (interface{}, error) {
+	switch v.kind {
+	case variableValue:
+		return variables[v.raw], nil
+	case intValue:
+		return strconv.ParseInt(v.raw, 10, 64)
+	case floatValue:
+		return strconv.ParseFloat(v.raw, 64)
+	case booleanValue:
+		return v.raw == "true", nil
+	case nullValue:
+		return nil, nil
+	case listValue:
+		list := make([]interface{}, len(v.list))
+		for i, item := range v.list {
+			var err error
+			if list[i], err = literal(item, variables); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case objectValue:
+		object := map[string]interface{}{}
+		for _, field := range v.fields {
+			var err error
+			if object[field.name], err = literal(field.value, variables); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	}
+	// strings and enums
+	return v.raw, nil
+}
+
+// coerce checks that the value has the type and converts it to the Go type passed to the resolvers:
+// int, float64, string, bool, []interface{} and map[string]interface{}
+func (s *Schema) coerce(t *typeRef, v interface{}) (interface{}, error) {
+	if v == nil {
+		if t.nonNull {
+			return nil, fmt.Errorf("expected a value of type %s, found null", t)
+		}
+		return nil, nil
+	}
+	if t.elem != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			// a single value is a list of one item
+			list = []interface{}{v}
+		}
+		coerced := make([]interface{}, len(list))
+		for i, item := range list {
+			var err error
+			if coerced[i], err = s.coerce(t.elem, item); err != nil {
+				return nil, err
+			}
+		}
+		return coerced, nil
+	}
+	switch t.name {
+	case "Int":
+		if n, ok := number(v); ok && n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int(n), nil
+		}
+	case "Float":
+		if n, ok := number(v); ok {
+			return n, nil
+		}
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "ID":
+		switch id := v.(type) {
+		case string:
+			return id, nil
+		case int64:
+			return strconv.FormatInt(id, 10), nil
+		}
+		if n, ok := number(v); ok && n == math.Trunc(n) {
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		}
+	case Map:
+		if m, ok := v.(map[string]interface{}); ok {
+			return m, nil
+		}
+	default:
+		if values, ok := s.enums[t.name]; ok {
+			for _, name := range values {
+				if v == name {
+					return name, nil
+				}
+			}
+			return nil, fmt.Errorf("%v is not a value of %s", v, t.name)
+		}
+		if in, ok := s.inputs[t.name]; ok {
+			return s.coerceInput(in, v)
+		}
+	}
+	return nil, fmt.Errorf("expected a value of type %s, found %v", t, v)
+}
+
+func (s *Schema) coerceInput(in *input, v interface{}) (interface{}, error) {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a value of type %s, found %v", in.name, v)
+	}
+	coerced := map[string]interface{}{}
+	known := map[string]bool{}
+	for _, field := range in.fields {
+		known[field.Name] = true
+		value, ok := object[field.Name]
+		if !ok {
+			value = field.Default
+		}
+		c, err := s.coerce(field.typ, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q of %s: %s", field.Name, in.name, err.Error())
+		}
+		if c != nil {
+			coerced[field.Name] = c
+		}
+	}
+	for name := range object {
+		if !known[name] {
+			return nil, fmt.Errorf("%s has no field %q", in.name, name)
+		}
+	}
+	return coerced, nil
+}
+
+// number converts the numbers of the query, int64 and float64, and of the JSON variables, float64
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+const (
+	// maxDepth is the deepest nesting of objects a query can select
+	maxDepth = 10
+	// maxFields is the number of fields a query can select, once its fragments are expanded
+	maxFields = 500
+)
+
+type executor struct {
+	schema    *Schema
+	fragments map[string]*fragment
+	variables map[string]interface{}
+	declared  map[string]bool
+	errors    []*Error
+	// fields is the number of fields validated, for maxFields
+	fields int
+}
+
+// collected is a field of the response, merging the selections with the same name or alias
+type collected struct {
+	key        string
+	selection  *selection
+	selections []*selection
+}
+
+// collect returns the fields selected on an object type, in order, following the fragments and the `@skip` and `@include` directives.
+// A fragment is spread once in a selection set, the visited fragments are skipped. The cycles are rejected by the parser
+func (e *executor) collect(objectName string, selections []*selection, fields []*collected, visited map[string]bool) ([]*collected, error) {
+	for _, s := range selections {
+		include, err := e.include(s)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			continue
+		}
+		switch {
+		case s.spread != "":
+			f, ok := e.fragments[s.spread]
+			if !ok {
+				return nil, &Error{Message: fmt.Sprintf("fragment %q does not exist", s.spread), Locations: []Location{s.location}}
+			}
+			if visited[s.spread] {
+				continue
+			}
+			if err := e.typeCondition(f.typeCondition, s.location); err != nil {
+				return nil, err
+			}
+			if f.typeCondition != objectName {
+				continue
+			}
+			visited[s.spread] = true
+			if fields, err = e.collect(objectName, f.selections, fields, visited); err != nil {
+				return nil, err
+			}
+		case s.inline:
+			if s.typeCondition != "" {
+				if err := e.typeCondition(s.typeCondition, s.location); err != nil {
+					return nil, err
+				}
+				if s.typeCondition != objectName {
+					continue
+				}
+			}
+			if fields, err = e.collect(objectName, s.selections, fields, visited); err != nil {
+				return nil, err
+			}
+		default:
+			key := s.name
+			if s.alias != "" {
+				key = s.alias
+			}
+			merged := false
+			for _, field := range fields {
+				if field.key == key {
+					field.selections = append(field.selections, s.selections...)
+					merged = true
+				}
+			}
+			if !merged {
+				fields = append(fields, &collected{key: key, selection: s, selections: append([]*selection{}, s.selections...)})
+			}
+		}
+	}
+	return fields, nil
+}
+
+func (e *executor) typeCondition(name string, location Location) error {
+	if _, ok := e.schema.objects[name]; !ok {
+		return &Error{Message: fmt.Sprintf("type %q does not exist", name), Locations: []Location{location}}
+	}
+	return nil
+}
+
+// include applies the `@skip(if: Boolean!)` and `@include(if: Boolean!)` directives
+func (e *executor) include(s *selection) (bool, error) {
+	for _, d := range s.directives {
+		if d.name != "skip" && d.name != "include" {
+			return false, &Error{Message: fmt.Sprintf("directive @%s is not supported", d.name), Locations: []Location{s.location}}
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			return false, &Error{Message: fmt.Sprintf("directive @%s has a single argument: if", d.name), Locations: []Location{s.location}}
+		}
+		v, err := literal(d.arguments[0].value, e.variables)
+		if err != nil {
+			return false, err
+		}
+		condition, ok := v.(bool)
+		if !ok {
+			return false, &Error{Message: fmt.Sprintf("the argument of @%s has to be a Boolean", d.name), Locations: []Location{s.location}}
+		}
+		if condition == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// arguments returns the coerced arguments of a field
+func (e *executor) arguments(field *Field, s *selection) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for _, a := range s.arguments {
+		known := false
+		for _, declared := range field.Arguments {
+			known = known || declared.Name == a.name
+		}
+		if !known {
+			return nil, &Error{Message: fmt.Sprintf("field %q has no argument %q", s.name, a.name), Locations: []Location{s.location}}
+		}
+	}
+	for _, declared := range field.Arguments {
+		var v interface{} = declared.Default
+		for _, a := range s.arguments {
+			if a.name != declared.Name {
+				continue
+			}
+			if a.value.kind == variableValue {
+				if !e.declared[a.value.raw] {
+					return nil, &Error{Message: fmt.Sprintf("variable $%s is not defined", a.value.raw), Locations: []Location{s.location}}
+				}
+				if _, ok := e.variables[a.value.raw]; !ok {
+					// variables which are not set are as missing arguments
+					continue
+				}
+			}
+			var err error
+			if v, err = literal(a.value, e.variables); err != nil {
+				return nil, err
+			}
+		}
+		coerced, err := e.schema.coerce(declared.typ, v)
+		if err != nil {
+			return nil, &Error{Message: fmt.Sprintf("argument %q of %q: %s", declared.Name, s.name, err.Error()), Locations: []Location{s.location}}
+		}
+		if coerced != nil {
+			args[declared.Name] = coerced
+		}
+	}
+	return args, nil
+}
+
+// validate checks the selections against the schema before anything is resolved. The depth is the one of the object,
+// the fields of the query are 1 deep
+func (e *executor) validate(objectName string, selections []*selection, depth int) error {
+	fields, err := e.collect(objectName, selections, nil, map[string]bool{})
+	if err != nil {
+		return err
+	}
+	o := e.schema.objects[objectName]
+	for _, f := range fields {
+		if depth > maxDepth {
+			return &Error{Message: fmt.Sprintf("the query is nested deeper than %d levels", maxDepth), Locations: []Location{f.selection.location}}
+		}
+		e.fields++
+		if e.fields > maxFields {
+			return &Error{Message: fmt.Sprintf("the query selects more than %d fields", maxFields), Locations: []Location{f.selection.location}}
+		}
+		if f.selection.name == "__typename" {
+			if len(f.selections) > 0 {
+				return &Error{Message: "__typename has no fields", Locations: []Location{f.selection.location}}
+			}
+			continue
+		}
+		field, ok := o.byName[f.selection.name]
+		if !ok {
+			return &Error{Message: fmt.Sprintf("type %s has no field %q", objectName, f.selection.name), Locations: []Location{f.selection.location}}
+		}
+		if _, err := e.arguments(field, f.selection); err != nil {
+			return err
+		}
+		named := field.typ.named()
+		if _, ok := e.schema.objects[named]; ok {
+			if len(f.selections) == 0 {
+				return &Error{Message: fmt.Sprintf("field %q of type %s needs a selection of its fields", f.key, named), Locations: []Location{f.selection.location}}
+			}
+			if err := e.validate(named, f.selections, depth+1); err != nil {
+				return err
+			}
+		} else if len(f.selections) > 0 {
+			return &Error{Message: fmt.Sprintf("field %q of type %s has no fields", f.key, named), Locations: []Location{f.selection.location}}
+		}
+	}
+	return nil
+}
+
+// selectionSet resolves the fields of a batch of objects of the same type. Each field is resolved once for the batch,
+// so the nested objects of all the items of a list are read together
+func (e *executor) selectionSet(ctx context.Context, objectName string, parents []interface{}, paths [][]interface{}, selections []*selection) []interface{} {
+	fields, _ := e.collect(objectName, selections, nil, map[string]bool{})
+	results := make([]*orderedObject, len(parents))
+	for i := range results {
+		results[i] = &orderedObject{}
+	}
+	o := e.schema.objects[objectName]
+	for _, f := range fields {
+		if f.selection.name == "__typename" {
+			for _, result := range results {
+				result.set(f.key, objectName)
+			}
+			continue
+		}
+		field := o.byName[f.selection.name]
+		fieldPaths := make([][]interface{}, len(parents))
+		for i := range parents {
+			fieldPaths[i] = append(append([]interface{}{}, paths[i]...), f.key)
+		}
+		values, err := e.resolve(ctx, field, f.selection, parents)
+		if err != nil {
+			e.fail(err, f.selection, fieldPaths[0])
+			values = make([]interface{}, len(parents))
+		}
+		completed := e.complete(ctx, field.typ, values, fieldPaths, f)
+		for i, result := range results {
+			result.set(f.key, completed[i])
+		}
+	}
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		values[i] = result
+	}
+	return values
+}
+
+func (e *executor) resolve(ctx context.Context, field *Field, s *selection, parents []interface{}) ([]interface{}, error) {
+	args, err := e.arguments(field, s)
+	if err != nil {
+		return nil, err
+	}
+	values, err := field.Resolve(ctx, parents, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(parents) {
+		return nil, fmt.Errorf("resolver of %q returned %d values for %d objects", s.name, len(values), len(parents))
+	}
+	return values, nil
+}
+
+func (e *executor) fail(err error, s *selection, path []interface{}) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{s.location}, Path: path})
+}
+
+// complete converts the resolved values to the type of the field. The items of the lists and the objects are completed as a single batch
+func (e *executor) complete(ctx context.Context, t *typeRef, values []interface{}, paths [][]interface{}, f *collected) []interface{} {
+	completed := make([]interface{}, len(values))
+	if t.elem != nil {
+		items := []interface{}{}
+		itemPaths := [][]interface{}{}
+		lengths := make([]int, len(values))
+		for i, v := range values {
+			if v == nil {
+				lengths[i] = -1
+				continue
+			}
+			list, ok := v.([]interface{})
+			if !ok {
+				e.fail(fmt.Errorf("resolver of %q returned %T instead of a list", f.selection.name, v), f.selection, paths[i])
+				lengths[i] = -1
+				continue
+			}
+			lengths[i] = len(list)
+			for j, item := range list {
+				items = append(items, item)
+				itemPaths = append(itemPaths, append(append([]interface{}{}, paths[i]...), j))
+			}
+		}
+		completedItems := e.complete(ctx, t.elem, items, itemPaths, f)
+		for i, length := range lengths {
+			if length < 0 {
+				continue
+			}
+			completed[i] = completedItems[:length:length]
+			completedItems = completedItems[length:]
+		}
+		return completed
+	}
+	if _, ok := e.schema.objects[t.name]; !ok {
+		// scalars and enums are sent as they were resolved
+		copy(completed, values)
+		return completed
+	}
+	objects := []interface{}{}
+	objectPaths := [][]interface{}{}
+	for i, v := range values {
+		if v != nil {
+			objects = append(objects, v)
+			objectPaths = append(objectPaths, paths[i])
+		}
+	}
+	if len(objects) == 0 {
+		return completed
+	}
+	resolved := e.selectionSet(ctx, t.name, objects, objectPaths, f.selections)
+	for i, v := range values {
+		if v != nil {
+			completed[i] = resolved[0]
+			resolved = resolved[1:]
+		}
+	}
+	return completed
+}
+
+// orderedObject is an object of the response, which keeps the fields in the order of the query
+type orderedObject struct {
+	keys   []string
+	values []interface{}
+}
+
+func (o *orderedObject) set(key string, v interface{}) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, v)
+}
+
+// MarshalJSON encodes the fields in the order of the query
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	b := &bytes.Buffer{}
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,352 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/graphql"
+)
+
+// store lists the items as the store does: the values of a filter are alternatives and the filters have to match together
+type store struct {
+	items  []interface{}
+	calls  []map[string][]string
+	counts []int
+	err    error
+}
+
+func (s *store) list(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	s.calls = append(s.calls, filter)
+	s.counts = append(s.counts, count)
+	if s.err != nil {
+		return nil, s.err
+	}
+	items := []interface{}{}
+	for _, item := range s.items {
+		if matches(item, filter) {
+			items = append(items, item)
+		}
+	}
+	if reverse {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	if count > 0 && len(items) > count {
+		items = items[:count]
+	}
+	return items, nil
+}
+
+func matches(item interface{}, filter map[string][]string) bool {
+	fields := map[string]string{}
+	switch i := item.(type) {
+	case *project.Project:
+		fields["identity.id"] = i.Identity.Id
+		fields["name"] = i.Name
+	case *testplan.TestPlan:
+		fields["identity.id"] = i.Identity.Id
+		fields["projectid"] = i.ProjectId
+	case *execution.Execution:
+		fields["identity.id"] = i.Identity.Id
+		fields["projectid"] = i.ProjectId
+		fields["testplanid"] = i.TestPlanId
+		fields["status"] = i.Status.String()
+	}
+	for key, values := range filter {
+		found := false
+		for _, v := range values {
+			found = found || fields[key] == v
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type fixture struct {
+	schema     *graphql.Schema
+	projects   *store
+	testPlans  *store
+	executions *store
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		projects: &store{items: []interface{}{
+			&project.Project{Identity: &metadata.Identity{Id: "p1", CreationTime: 1614035154}, Name: "shop"},
+			&project.Project{Identity: &metadata.Identity{Id: "p2"}, Name: "bank"},
+		}},
+		testPlans: &store{items: []interface{}{
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "t1"}, ProjectId: "p1", Name: "smoke"},
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "t2"}, ProjectId: "p1", Name: "regression"},
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "t3"}, ProjectId: "p2", Name: "payments"},
+		}},
+		executions: &store{items: []interface{}{
+			&execution.Execution{Identity: &metadata.Identity{Id: "e1"}, ProjectId: "p1", TestPlanId: "t1", Status: execution.Status_Pass},
+			&execution.Execution{
+				Identity:   &metadata.Identity{Id: "e2"},
+				ProjectId:  "p1",
+				TestPlanId: "t1",
+				Status:     execution.Status_Fail,
+				Issues:     []*metadata.LinkedIssue{{Link: "https://issues/1", Severity: metadata.Severity_HIGH}},
+			},
+			&execution.Execution{Identity: &metadata.Identity{Id: "e3"}, ProjectId: "p1", TestPlanId: "t2", Status: execution.Status_Fail},
+			&execution.Execution{Identity: &metadata.Identity{Id: "e4"}, ProjectId: "p2", TestPlanId: "t3", Configuration: map[string]string{"os": "linux"}},
+		}},
+	}
+	f.schema = graphql.NewSchema()
+	f.schema.Entity(&project.Project{}, "project", "projects", f.projects.list)
+	f.schema.Entity(&testplan.TestPlan{}, "testPlan", "testPlans", f.testPlans.list)
+	f.schema.Entity(&execution.Execution{}, "execution", "executions", f.executions.list)
+	f.schema.Collection("Project", "testPlans", "TestPlan", "projectId")
+	f.schema.Collection("TestPlan", "executions", "Execution", "testPlanId")
+	f.schema.Reference("TestPlan", "project", "projectId", "Project")
+	f.schema.Reference("Execution", "testPlan", "testPlanId", "TestPlan")
+	return f
+}
+
+func (f *fixture) query(t *testing.T, query string, variables map[string]interface{}) (string, []*graphql.Error) {
+	response := f.schema.Execute(context.Background(), &graphql.Request{Query: query, Variables: variables})
+	data, err := json.Marshal(response.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), response.Errors
+}
+
+func TestExecute_Nested(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	data, errs := f.query(t, `{
+		projects {
+			name
+			testPlans {
+				name
+				executions { identity { id } status issues { link severity } }
+			}
+		}
+	}`, nil)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(MatchJSON(`{"projects": [
+		{"name": "shop", "testPlans": [
+			{"name": "smoke", "executions": [
+				{"identity": {"id": "e1"}, "status": "Pass", "issues": []},
+				{"identity": {"id": "e2"}, "status": "Fail", "issues": [{"link": "https://issues/1", "severity": "HIGH"}]}
+			]},
+			{"name": "regression", "executions": [{"identity": {"id": "e3"}, "status": "Fail", "issues": []}]}
+		]},
+		{"name": "bank", "testPlans": [
+			{"name": "payments", "executions": [{"identity": {"id": "e4"}, "status": "Pending", "issues": []}]}
+		]}
+	]}`))
+	g.Expect(f.testPlans.calls).To(Equal([]map[string][]string{{"projectid": {"p1", "p2"}}}), "test plans of the projects were not read at once")
+	g.Expect(f.executions.calls).To(Equal([]map[string][]string{{"testplanid": {"t1", "t2", "t3"}}}), "executions of the test plans were not read at once")
+}
+
+func TestExecute_References(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	data, errs := f.query(t, `{
+		testPlans { executions { testPlan { name project { name } } } }
+		executions(filter: [{field: "status", values: ["Fail"]}]) { identity { id } testPlan { name } }
+	}`, nil)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(MatchJSON(`{
+		"testPlans": [
+			{"executions": [{"testPlan": {"name": "smoke", "project": {"name": "shop"}}}, {"testPlan": {"name": "smoke", "project": {"name": "shop"}}}]},
+			{"executions": [{"testPlan": {"name": "regression", "project": {"name": "shop"}}}]},
+			{"executions": [{"testPlan": {"name": "payments", "project": {"name": "bank"}}}]}
+		],
+		"executions": [{"identity": {"id": "e2"}, "testPlan": {"name": "smoke"}}, {"identity": {"id": "e3"}, "testPlan": {"name": "regression"}}]
+	}`))
+	g.Expect(f.testPlans.calls).To(HaveLen(1), "test plans read by the request were read again")
+	g.Expect(f.projects.calls).To(Equal([]map[string][]string{{"identity.id": {"p1", "p2"}}}), "projects were not read at once")
+	g.Expect(f.executions.calls[1]).To(Equal(map[string][]string{"status": {"Fail"}}))
+}
+
+func TestExecute_Arguments(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	data, errs := f.query(t, `query Dashboard($id: ID!, $count: Int = 1, $skipPlans: Boolean!) {
+		shop: project(id: $id) {
+			__typename
+			...names
+			identity { creationTime }
+			latest: testPlans(count: $count, sortBy: "name:desc") { name }
+			plans: testPlans @skip(if: $skipPlans) { name }
+		}
+		missing: project(id: "p9") { name }
+	}
+	fragment names on Project { name }`, map[string]interface{}{"id": "p1", "skipPlans": true})
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(Equal(`{"shop":{"__typename":"Project","name":"shop","identity":{"creationTime":"1614035154"},"latest":[{"name":"regression"}]},"missing":null}`), "fields were not sent in the order of the query")
+	g.Expect(f.testPlans.calls[0]).To(Equal(map[string][]string{"projectid": {"p1"}}))
+}
+
+func TestExecute_Maps(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	data, errs := f.query(t, `{ execution(id: "e4") { configuration } }`, nil)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(MatchJSON(`{"execution": {"configuration": {"os": "linux"}}}`))
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	f.testPlans.err = errors.New("store is down")
+	data, errs := f.query(t, `{ projects { name testPlans { name } } }`, nil)
+	g.Expect(data).To(MatchJSON(`{"projects": [{"name": "shop", "testPlans": null}, {"name": "bank", "testPlans": null}]}`))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Message).To(Equal("store is down"))
+	g.Expect(errs[0].Path).To(Equal([]interface{}{"projects", 0, "testPlans"}))
+}
+
+func TestExecute_RequestErrors(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	for query, message := range map[string]string{
+		`{ projects { nam } }`:                                 `type Project has no field "nam"`,
+		`{ projects }`:                                         `field "projects" of type Project needs a selection of its fields`,
+		`{ projects { name { id } } }`:                         `field "name" of type String has no fields`,
+		`{ project { name } }`:                                 `argument "id" of "project": expected a value of type ID!, found null`,
+		`{ projects(count: "ten") { name } }`:                  `argument "count" of "projects": expected a value of type Int, found ten`,
+		`{ projects(limit: 10) { name } }`:                     `field "projects" has no argument "limit"`,
+		`{ projects(filter: {field: "name"}) { id } }`:         `argument "filter" of "projects": field "values" of Filter: expected a value of type [String!]!, found null`,
+		`{ projects(count: $count) { name } }`:                 `variable $count is not defined`,
+		`{ projects { ...missing } }`:                          `fragment "missing" does not exist`,
+		`{ projects { ...a } } fragment a on Project { ...a }`: `fragment "a" spreads itself`,
+		`{ projects { ...a } } fragment a on Project { testPlans { project { ...a } } }`: `fragment "a" spreads itself`,
+		`mutation { projects { name } }`:                              `mutation operations are not supported`,
+		`{ projects { name }`:                                         `syntax error: unexpected end of the document`,
+		`query A { projects { name } } query B { projects { name } }`: `the document has several operations, the operationName has to be set`,
+	} {
+		response := f.schema.Execute(context.Background(), &graphql.Request{Query: query})
+		g.Expect(response.Data).To(BeNil(), query)
+		g.Expect(response.Errors).To(HaveLen(1), query)
+		g.Expect(response.Errors[0].Message).To(Equal(message), query)
+	}
+	g.Expect(f.projects.calls).To(BeEmpty(), "invalid queries were executed")
+
+	response := f.schema.Execute(context.Background(), &graphql.Request{Query: "{\n  projects {\n    nam\n  }\n}"})
+	g.Expect(response.Errors[0].Locations).To(Equal([]graphql.Location{{Line: 3, Column: 5}}))
+}
+
+func TestExecute_CollectionCount(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	data, errs := f.query(t, `{ projects { name testPlans(count: 1, sortBy: "name:desc") { name } } }`, nil)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(MatchJSON(`{"projects": [
+		{"name": "shop", "testPlans": [{"name": "regression"}]},
+		{"name": "bank", "testPlans": [{"name": "payments"}]}
+	]}`))
+	g.Expect(f.testPlans.calls).To(Equal([]map[string][]string{{"projectid": {"p1"}}, {"projectid": {"p2"}}}), "test plans of each project were not read on their own")
+	g.Expect(f.testPlans.counts).To(Equal([]int{1, 1}), "count was not passed to the store")
+}
+
+func TestParse_Nesting(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	for name, query := range map[string]string{
+		"selection sets": strings.Repeat("{ projects ", 100000) + strings.Repeat("}", 100000),
+		"lists":          `{ projects(filter: ` + strings.Repeat("[", 100000) + strings.Repeat("]", 100000) + `) { name } }`,
+		"objects":        `{ projects(filter: ` + strings.Repeat("{field: ", 100000) + `"name"` + strings.Repeat("}", 100000) + `) { name } }`,
+	} {
+		response := f.schema.Execute(context.Background(), &graphql.Request{Query: query})
+		g.Expect(response.Data).To(BeNil(), name)
+		g.Expect(response.Errors).To(HaveLen(1), name)
+		g.Expect(response.Errors[0].Message).To(Equal("the document is nested deeper than 32 levels"), name)
+	}
+	g.Expect(f.projects.calls).To(BeEmpty(), "nested queries were executed")
+}
+
+func TestExecute_Limits(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	deep := "{ testPlans { name " + strings.Repeat("project { testPlans { ", 5) + "name" + strings.Repeat(" } }", 5) + " } }"
+	wide := &strings.Builder{}
+	wide.WriteString("{ projects { ")
+	for i := 0; i <= 500; i++ {
+		fmt.Fprintf(wide, "name%d: name ", i)
+	}
+	wide.WriteString("} }")
+	for query, message := range map[string]string{
+		deep:          "the query is nested deeper than 10 levels",
+		wide.String(): "the query selects more than 500 fields",
+	} {
+		response := f.schema.Execute(context.Background(), &graphql.Request{Query: query})
+		g.Expect(response.Data).To(BeNil(), message)
+		g.Expect(response.Errors).To(HaveLen(1), message)
+		g.Expect(response.Errors[0].Message).To(Equal(message))
+	}
+	g.Expect(f.projects.calls).To(BeEmpty(), "queries over the limits were executed")
+	g.Expect(f.testPlans.calls).To(BeEmpty(), "queries over the limits were executed")
+}
+
+func TestExecute_RepeatedSpreads(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	query := &strings.Builder{}
+	query.WriteString("{ project(id: \"p1\") { ...f30 } } fragment f0 on Project { name }")
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(query, " fragment f%d on Project { ...f%d ...f%d }", i, i-1, i-1)
+	}
+	data, errs := f.query(t, query.String(), nil)
+	g.Expect(errs).To(BeEmpty())
+	g.Expect(data).To(MatchJSON(`{"project": {"name": "shop"}}`), "fragments spread several times were not collected once")
+}
+
+func TestRegister(t *testing.T) {
+	g := NewWithT(t)
+	f := newFixture()
+	r := mux.NewRouter()
+	graphql.Register(context.Background(), f.schema, r.PathPrefix("/graphql").Subrouter(), zap.NewNop().Sugar())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "query ($id: ID!) { project(id: $id) { name } }", "variables": {"id": "p2"}}`)))
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(MatchJSON(`{"data": {"project": {"name": "bank"}}}`))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{ projects(count: 1) { name } }`), nil))
+	g.Expect(w.Code).To(Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(MatchJSON(`{"data": {"projects": [{"name": "shop"}]}}`))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ projects { nam } }"}`)))
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(MatchJSON(`{"errors": [{"message": "type Project has no field \"nam\"", "locations": [{"line": 1, "column": 14}]}]}`))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`)))
+	g.Expect(w.Code).To(Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(MatchJSON(`{"errors": [{"message": "query not provided"}]}`))
+}
+
+func TestSchema_String(t *testing.T) {
+	g := NewWithT(t)
+	sdl := newFixture().schema.String()
+	g.Expect(sdl).To(ContainSubstring("  projects(filter: [Filter!], sortBy: String, count: Int, lastValue: String): [Project!]\n"))
+	g.Expect(sdl).To(ContainSubstring("  creationTime: String!\n"))
+	g.Expect(sdl).To(ContainSubstring("  executions(filter: [Filter!], sortBy: String, count: Int, lastValue: String): [Execution!]\n"))
+	g.Expect(sdl).To(ContainSubstring("enum Status {\n  Pending\n  Fail\n  Pass\n  Skipped\n}\n"))
+	g.Expect(sdl).To(ContainSubstring("  project: Project\n"))
+}
@@ -0,0 +1,884 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string
+	name       string
+	variables  []*variable
+	selections []*selection
+	location   Location
+}
+
+type variable struct {
+	name     string
+	typ      *typeRef
+	fallback *value
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []*selection
+	location      Location
+}
+
+// selection is a field, a fragment spread or an inline fragment
+type selection struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []*selection
+	// spread is the name of the spread fragment
+	spread string
+	// inline is set for the inline fragments, which may have a type condition
+	inline        bool
+	typeCondition string
+	location      Location
+}
+
+type argument struct {
+	name  string
+	value *value
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+}
+
+type valueKind int
+
+const (
+	variableValue valueKind = iota
+	intValue
+	floatValue
+	stringValue
+	booleanValue
+	nullValue
+	enumValue
+	listValue
+	objectValue
+)
+
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*argument
+}
+
+// typeRef is a type of an argument, a variable or a field, ie. `[ID!]!`
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// named returns the name of the type, without the lists
+func (t *typeRef) named() string {
+	if t.elem != nil {
+		return t.elem.named()
+	}
+	return t.name
+}
+
+// mustParseType parses the types declared by the schema, which are part of the code
+func mustParseType(s string) *typeRef {
+	p := &parser{lexer: &lexer{source: s, line: 1, lineStart: 0}}
+	t, err := p.parseTypeRef()
+	if err == nil {
+		err = p.expectKind(tokenEOF)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("graphql: invalid type %q: %s", s, err.Error()))
+	}
+	return t
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind     tokenKind
+	value    string
+	location Location
+}
+
+type lexer struct {
+	source    string
+	position  int
+	line      int
+	lineStart int
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: l.position - l.lineStart + 1}
+}
+
+func (l *lexer) errorf(location Location, format string, args ...interface{}) error {
+	return &Error{Message: "syntax error: " + fmt.Sprintf(format, args...), Locations: []Location{location}}
+}
+
+// skip ignores the white space, the commas and the comments
+func (l *lexer) skip() {
+	for l.position < len(l.source) {
+		switch c := l.source[l.position]; c {
+		case ' ', '\t', ',', '\r':
+			l.position++
+		case '\n':
+			l.position++
+			l.line++
+			l.lineStart = l.position
+		case '#':
+			for l.position < len(l.source) && l.source[l.position] != '\n' {
+				l.position++
+			}
+		default:
+			if strings.HasPrefix(l.source[l.position:], "\ufeff") {
+				l.position += len("\ufeff")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skip()
+	location := l.location()
+	if l.position >= len(l.source) {
+		return token{kind: tokenEOF, location: location}, nil
+	}
+	c := l.source[l.position]
+	switch {
+	case strings.HasPrefix(l.source[l.position:], "..."):
+		l.position += 3
+		return token{kind: tokenPunctuator, value: "...", location: location}, nil
+	case strings.IndexByte("!$()&:=@[]{}|", c) >= 0:
+		l.position++
+		return token{kind: tokenPunctuator, value: string(c), location: location}, nil
+	case c == '_' || isLetter(c):
+		start := l.position
+		for l.position < len(l.source) && (l.source[l.position] == '_' || isLetter(l.source[l.position]) || isDigit(l.source[l.position])) {
+			l.position++
+		}
+		return token{kind: tokenName, value: l.source[start:l.position], location: location}, nil
+	case c == '-' || isDigit(c):
+		return l.number(location)
+	case c == '"':
+		if strings.HasPrefix(l.source[l.position:], `"""`) {
+			return l.blockString(location)
+		}
+		return l.string(location)
+	}
+	r, _ := utf8.DecodeRuneInString(l.source[l.position:])
+	return token{}, l.errorf(location, "unexpected character %q", r)
+}
+
+func (l *lexer) number(location Location) (token, error) {
+	start := l.position
+	kind := tokenInt
+	if l.source[l.position] == '-' {
+		l.position++
+	}
+	if !l.digits() {
+		return token{}, l.errorf(location, "invalid number")
+	}
+	if l.position < len(l.source) && l.source[l.position] == '.' {
+		kind = tokenFloat
+		l.position++
+		if !l.digits() {
+			return token{}, l.errorf(location, "invalid number")
+		}
+	}
+	if l.position < len(l.source) && (l.source[l.position] == 'e' || l.source[l.position] == 'E') {
+		kind = tokenFloat
+		l.position++
+		if l.position < len(l.source) && (l.source[l.position] == '+' || l.source[l.position] == '-') {
+			l.position++
+		}
+		if !l.digits() {
+			return token{}, l.errorf(location, "invalid number")
+		}
+	}
+	return token{kind: kind, value: l.source[start:l.position], location: location}, nil
+}
+
+func (l *lexer) digits() bool {
+	start := l.position
+	for l.position < len(l.source) && isDigit(l.source[l.position]) {
+		l.position++
+	}
+	return l.position > start
+}
+
+func (l *lexer) string(location Location) (token, error) {
+	l.position++
+	b := strings.Builder{}
+	for l.position < len(l.source) {
+		c := l.source[l.position]
+		switch c {
+		case '"':
+			l.position++
+			return token{kind: tokenString, value: b.String(), location: location}, nil
+		case '\n':
+			return token{}, l.errorf(location, "unterminated string")
+		case '\\':
+			if l.position+1 >= len(l.source) {
+				return token{}, l.errorf(location, "unterminated string")
+			}
+			escaped := l.source[l.position+1]
+			l.position += 2
+			switch escaped {
+			case '"', '\\', '/':
+				b.WriteByte(escaped)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.position+4 > len(l.source) {
+					return token{}, l.errorf(location, "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(l.source[l.position:l.position+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(location, "invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				l.position += 4
+			default:
+				return token{}, l.errorf(location, "invalid escape \\%c", escaped)
+			}
+		default:
+			b.WriteByte(c)
+			l.position++
+		}
+	}
+	return token{}, l.errorf(location, "unterminated string")
+}
+
+// blockString reads a `"""` string. The common indentation and the blank first and last lines are removed
+func (l *lexer) blockString(location Location) (token, error) {
+	l.position += 3
+	end := strings.Index(l.source[l.position:], `"""`)
+	for end > 0 && l.source[l.position+end-1] == '\\' {
+		next := strings.Index(l.source[l.position+end+3:], `"""`)
+		if next < 0 {
+			end = -1
+			break
+		}
+		end += 3 + next
+	}
+	if end < 0 {
+		return token{}, l.errorf(location, "unterminated string")
+	}
+	raw := l.source[l.position : l.position+end]
+	for _, c := range raw {
+		if c == '\n' {
+			l.line++
+		}
+	}
+	if i := strings.LastIndexByte(raw, '\n'); i >= 0 {
+		l.lineStart = l.position + i + 1
+	}
+	l.position += end + 3
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, `\"""`, `"""`), "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && (indent < 0 || len(line)-len(trimmed) < indent) {
+			indent = len(line) - len(trimmed)
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return token{kind: tokenString, value: strings.Join(lines, "\n"), location: location}, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// maxNesting is the deepest nesting of selection sets, lists and objects in a document. The parser is recursive,
+// so the deeper documents are rejected before they exhaust the stack
+const maxNesting = 32
+
+// parser reads a query document. Type system definitions are not supported, the schema is declared in Go
+type parser struct {
+	lexer   *lexer
+	current token
+	started bool
+	// nesting is the number of selection sets, lists and objects being read
+	nesting int
+}
+
+func parse(source string) (*document, error) {
+	p := &parser{lexer: &lexer{source: source, line: 1}}
+	doc := &document{fragments: map[string]*fragment{}}
+	for {
+		t, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.kind == tokenEOF:
+			if len(doc.operations) == 0 {
+				return nil, p.lexer.errorf(t.location, "the document has no operation")
+			}
+			if err := doc.checkSpreads(); err != nil {
+				return nil, err
+			}
+			return doc, nil
+		case t.kind == tokenPunctuator && t.value == "{":
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: selections, location: t.location})
+		case t.kind == tokenName && t.value == "fragment":
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, &Error{Message: fmt.Sprintf("fragment %q is defined more than once", f.name), Locations: []Location{t.location}}
+			}
+			doc.fragments[f.name] = f
+		case t.kind == tokenName && (t.value == "query" || t.value == "mutation" || t.value == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		default:
+			return nil, p.unexpected(t)
+		}
+	}
+}
+
+// checkSpreads rejects the fragments which spread themselves, directly or through other fragments, as their fields never end
+func (d *document) checkSpreads() error {
+	names := make([]string, 0, len(d.fragments))
+	for name := range d.fragments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checked := map[string]bool{}
+	spreading := map[string]bool{}
+	var check func(name string, location Location) error
+	check = func(name string, location Location) error {
+		f, ok := d.fragments[name]
+		if !ok || checked[name] {
+			return nil
+		}
+		if spreading[name] {
+			return &Error{Message: fmt.Sprintf("fragment %q spreads itself", name), Locations: []Location{location}}
+		}
+		spreading[name] = true
+		for _, s := range spreads(f.selections) {
+			if err := check(s.spread, s.location); err != nil {
+				return err
+			}
+		}
+		delete(spreading, name)
+		checked[name] = true
+		return nil
+	}
+	for _, name := range names {
+		if err := check(name, d.fragments[name].location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// spreads returns the fragment spreads of the selections and of their nested selections
+func spreads(selections []*selection) []*selection {
+	found := []*selection{}
+	for _, s := range selections {
+		if s.spread != "" {
+			found = append(found, s)
+		}
+		found = append(found, spreads(s.selections)...)
+	}
+	return found
+}
+
+// nest enters a selection set, a list or an object, unless the document is already nested too deep
+func (p *parser) nest(location Location) error {
+	if p.nesting >= maxNesting {
+		return &Error{Message: fmt.Sprintf("the document is nested deeper than %d levels", maxNesting), Locations: []Location{location}}
+	}
+	p.nesting++
+	return nil
+}
+
+func (p *parser) peek() (token, error) {
+	if !p.started {
+		t, err := p.lexer.next()
+		if err != nil {
+			return token{}, err
+		}
+		p.current = t
+		p.started = true
+	}
+	return p.current, nil
+}
+
+func (p *parser) advance() (token, error) {
+	t, err := p.peek()
+	if err != nil {
+		return token{}, err
+	}
+	p.started = false
+	return t, nil
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return p.lexer.errorf(t.location, "unexpected end of the document")
+	}
+	return p.lexer.errorf(t.location, "unexpected %q", t.value)
+}
+
+// skipPunctuator consumes the punctuator when it is the next token
+func (p *parser) skipPunctuator(value string) (bool, error) {
+	t, err := p.peek()
+	if err != nil {
+		return false, err
+	}
+	if t.kind == tokenPunctuator && t.value == value {
+		_, err = p.advance()
+		return true, err
+	}
+	return false, nil
+}
+
+func (p *parser) expectPunctuator(value string) error {
+	t, err := p.advance()
+	if err != nil {
+		return err
+	}
+	if t.kind != tokenPunctuator || t.value != value {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) expectKind(kind tokenKind) error {
+	t, err := p.advance()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) parseName() (string, error) {
+	t, err := p.advance()
+	if err != nil {
+		return "", err
+	}
+	if t.kind != tokenName {
+		return "", p.unexpected(t)
+	}
+	return t.value, nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	t, err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+	op := &operation{kind: t.value, location: t.location}
+	if next, err := p.peek(); err != nil {
+		return nil, err
+	} else if next.kind == tokenName {
+		op.name = next.value
+		if _, err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.skipPunctuator("("); err != nil {
+		return nil, err
+	} else if ok {
+		for {
+			if done, err := p.skipPunctuator(")"); err != nil {
+				return nil, err
+			} else if done {
+				break
+			}
+			v, err := p.parseVariable()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, v)
+		}
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if op.selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseVariable() (*variable, error) {
+	if err := p.expectPunctuator("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunctuator(":"); err != nil {
+		return nil, err
+	}
+	v := &variable{name: name}
+	if v.typ, err = p.parseTypeRef(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skipPunctuator("="); err != nil {
+		return nil, err
+	} else if ok {
+		if v.fallback, err = p.parseValue(true); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) parseTypeRef() (*typeRef, error) {
+	t := &typeRef{}
+	if ok, err := p.skipPunctuator("["); err != nil {
+		return nil, err
+	} else if ok {
+		if t.elem, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunctuator("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		if t.name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	nonNull, err := p.skipPunctuator("!")
+	if err != nil {
+		return nil, err
+	}
+	t.nonNull = nonNull
+	return t, nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	t, err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.lexer.errorf(p.lexer.location(), "fragments cannot be named \"on\"")
+	}
+	if on, err := p.parseName(); err != nil {
+		return nil, err
+	} else if on != "on" {
+		return nil, p.lexer.errorf(p.lexer.location(), "expected \"on\", found %q", on)
+	}
+	f := &fragment{name: name, location: t.location}
+	if f.typeCondition, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if f.selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) parseSelectionSet() ([]*selection, error) {
+	t, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunctuator("{"); err != nil {
+		return nil, err
+	}
+	if err := p.nest(t.location); err != nil {
+		return nil, err
+	}
+	defer func() { p.nesting-- }()
+	selections := []*selection{}
+	for {
+		if done, err := p.skipPunctuator("}"); err != nil {
+			return nil, err
+		} else if done {
+			if len(selections) == 0 {
+				return nil, p.lexer.errorf(p.lexer.location(), "empty selection set")
+			}
+			return selections, nil
+		}
+		s, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+}
+
+func (p *parser) parseSelection() (*selection, error) {
+	t, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	s := &selection{location: t.location}
+	if ok, err := p.skipPunctuator("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.parseFragmentSelection(s)
+	}
+	if s.name, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skipPunctuator(":"); err != nil {
+		return nil, err
+	} else if ok {
+		s.alias = s.name
+		if s.name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if s.arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if s.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if next, err := p.peek(); err != nil {
+		return nil, err
+	} else if next.kind == tokenPunctuator && next.value == "{" {
+		if s.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (p *parser) parseFragmentSelection(s *selection) (*selection, error) {
+	t, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind == tokenName && t.value != "on" {
+		if _, err := p.advance(); err != nil {
+			return nil, err
+		}
+		s.spread = t.value
+		s.directives, err = p.parseDirectives()
+		return s, err
+	}
+	s.inline = true
+	if t.kind == tokenName {
+		if _, err := p.advance(); err != nil {
+			return nil, err
+		}
+		if s.typeCondition, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if s.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	s.selections, err = p.parseSelectionSet()
+	return s, err
+}
+
+func (p *parser) parseArguments(constant bool) ([]*argument, error) {
+	ok, err := p.skipPunctuator("(")
+	if err != nil || !ok {
+		return nil, err
+	}
+	arguments := []*argument{}
+	for {
+		if done, err := p.skipPunctuator(")"); err != nil {
+			return nil, err
+		} else if done {
+			return arguments, nil
+		}
+		a, err := p.parseArgument(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, a)
+	}
+}
+
+func (p *parser) parseArgument(constant bool) (*argument, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunctuator(":"); err != nil {
+		return nil, err
+	}
+	v, err := p.parseValue(constant)
+	if err != nil {
+		return nil, err
+	}
+	return &argument{name: name, value: v}, nil
+}
+
+func (p *parser) parseDirectives() ([]*directive, error) {
+	directives := []*directive{}
+	for {
+		ok, err := p.skipPunctuator("@")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return directives, nil
+		}
+		d := &directive{}
+		if d.name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+		if d.arguments, err = p.parseArguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+}
+
+// parseValue reads a value. The default values of the variables are constant and cannot use other variables
+func (p *parser) parseValue(constant bool) (*value, error) {
+	t, err := p.advance()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case tokenInt:
+		return &value{kind: intValue, raw: t.value}, nil
+	case tokenFloat:
+		return &value{kind: floatValue, raw: t.value}, nil
+	case tokenString:
+		return &value{kind: stringValue, raw: t.value}, nil
+	case tokenName:
+		switch t.value {
+		case "true", "false":
+			return &value{kind: booleanValue, raw: t.value}, nil
+		case "null":
+			return &value{kind: nullValue}, nil
+		}
+		return &value{kind: enumValue, raw: t.value}, nil
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			if constant {
+				return nil, p.lexer.errorf(t.location, "variables cannot be used in default values")
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			return &value{kind: variableValue, raw: name}, nil
+		case "[":
+			if err := p.nest(t.location); err != nil {
+				return nil, err
+			}
+			defer func() { p.nesting-- }()
+			list := &value{kind: listValue, list: []*value{}}
+			for {
+				if done, err := p.skipPunctuator("]"); err != nil {
+					return nil, err
+				} else if done {
+					return list, nil
+				}
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				list.list = append(list.list, item)
+			}
+		case "{":
+			if err := p.nest(t.location); err != nil {
+				return nil, err
+			}
+			defer func() { p.nesting-- }()
+			object := &value{kind: objectValue, fields: []*argument{}}
+			for {
+				if done, err := p.skipPunctuator("}"); err != nil {
+					return nil, err
+				} else if done {
+					return object, nil
+				}
+				field, err := p.parseArgument(constant)
+				if err != nil {
+					return nil, err
+				}
+				object.fields = append(object.fields, field)
+			}
+		}
+	}
+	return nil, p.unexpected(t)
+}
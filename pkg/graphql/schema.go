@@ -0,0 +1,308 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/curious-kitten/scratch-post/internal/mediatype"
+)
+
+// Query is the root type of the queries
+const Query = "Query"
+
+// Map is the scalar of the protobuf maps. It is sent as a JSON object
+const Map = "Map"
+
+// Resolver returns the values of a field for a batch of parents: all the objects of the type in the response at the same depth.
+// The values are returned in the order of the parents. Lists are returned as []interface{} and objects as the values of their type
+type Resolver func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error)
+
+// Field of an object type
+type Field struct {
+	Description string
+	// Type of the values, in the GraphQL syntax, ie. `[Execution!]`
+	Type      string
+	Arguments []Argument
+	Resolve   Resolver
+
+	typ *typeRef
+}
+
+// Argument of a field, or field of an input type
+type Argument struct {
+	Name        string
+	Type        string
+	Description string
+	// Default is the value used when the argument is not passed
+	Default interface{}
+
+	typ *typeRef
+}
+
+type object struct {
+	name        string
+	description string
+	fields      []string
+	byName      map[string]*Field
+}
+
+type input struct {
+	name        string
+	description string
+	fields      []*Argument
+}
+
+// Schema holds the types of the queries and their resolvers. Protobuf messages are added as object types whose fields are resolved from the messages
+type Schema struct {
+	objects  map[string]*object
+	inputs   map[string]*input
+	enums    map[string][]string
+	scalars  map[string]string
+	messages map[protoreflect.FullName]string
+	enumsOf  map[protoreflect.FullName]string
+	// descriptors are the messages of the object types, keyed by type
+	descriptors map[string]protoreflect.MessageDescriptor
+	entities    map[string]*entity
+	// order keeps the order in which the types were added, for the printed schema
+	order []string
+}
+
+// NewSchema returns a schema with the Query type, the built-in scalars, Map and the input type of the filters
+func NewSchema() *Schema {
+	s := &Schema{
+		objects:     map[string]*object{},
+		inputs:      map[string]*input{},
+		enums:       map[string][]string{},
+		messages:    map[protoreflect.FullName]string{},
+		enumsOf:     map[protoreflect.FullName]string{},
+		descriptors: map[string]protoreflect.MessageDescriptor{},
+		entities:    map[string]*entity{},
+		scalars: map[string]string{
+			"String":  "",
+			"Int":     "",
+			"Float":   "",
+			"Boolean": "",
+			"ID":      "",
+			Map:       "Protobuf map, sent as a JSON object",
+		},
+	}
+	s.Object(Query, "Entry points of the queries")
+	s.Input(filterType, "Keeps the items whose field has one of the values, as the query parameters of the REST collections",
+		Argument{Name: "field", Type: "String!", Description: "field of the items, ie. `status` or `identity.createdBy`"},
+		Argument{Name: "values", Type: "[String!]!"},
+	)
+	return s
+}
+
+// Object adds an object type. Its fields are added with Field
+func (s *Schema) Object(name string, description string) {
+	if s.typeExists(name) {
+		panic(fmt.Sprintf("graphql: type %q is defined more than once", name))
+	}
+	s.objects[name] = &object{name: name, description: description, byName: map[string]*Field{}}
+	s.order = append(s.order, name)
+}
+
+// Input adds an input type, used by the arguments
+func (s *Schema) Input(name string, description string, fields ...Argument) {
+	if s.typeExists(name) {
+		panic(fmt.Sprintf("graphql: type %q is defined more than once", name))
+	}
+	in := &input{name: name, description: description}
+	for i := range fields {
+		field := fields[i]
+		field.typ = mustParseType(field.Type)
+		in.fields = append(in.fields, &field)
+	}
+	s.inputs[name] = in
+	s.order = append(s.order, name)
+}
+
+// Field adds a field to an object type. The types it references have to be added before the schema is used
+func (s *Schema) Field(objectName string, name string, field Field) {
+	o, ok := s.objects[objectName]
+	if !ok {
+		panic(fmt.Sprintf("graphql: type %q does not exist", objectName))
+	}
+	if _, ok := o.byName[name]; ok {
+		panic(fmt.Sprintf("graphql: field %q of %q is defined more than once", name, objectName))
+	}
+	field.typ = mustParseType(field.Type)
+	arguments := make([]Argument, len(field.Arguments))
+	for i, a := range field.Arguments {
+		a.typ = mustParseType(a.Type)
+		arguments[i] = a
+	}
+	field.Arguments = arguments
+	o.fields = append(o.fields, name)
+	o.byName[name] = &field
+}
+
+// Message adds the object type of a protobuf message, and of the messages and enums of its fields, and returns its name.
+// The types are named as the messages, prefixed by their package when the name is taken
+func (s *Schema) Message(message proto.Message) string {
+	return s.message(message.ProtoReflect().Descriptor())
+}
+
+func (s *Schema) message(descriptor protoreflect.MessageDescriptor) string {
+	if name, ok := s.messages[descriptor.FullName()]; ok {
+		return name
+	}
+	name := s.typeName(descriptor)
+	s.Object(name, "")
+	s.messages[descriptor.FullName()] = name
+	s.descriptors[name] = descriptor
+	fields := descriptor.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		s.Field(name, field.JSONName(), Field{Type: s.fieldType(field), Resolve: resolveField(field)})
+	}
+	return name
+}
+
+func (s *Schema) enum(descriptor protoreflect.EnumDescriptor) string {
+	if name, ok := s.enumsOf[descriptor.FullName()]; ok {
+		return name
+	}
+	name := s.typeName(descriptor)
+	values := descriptor.Values()
+	names := make([]string, values.Len())
+	for i := range names {
+		names[i] = string(values.Get(i).Name())
+	}
+	s.enums[name] = names
+	s.enumsOf[descriptor.FullName()] = name
+	s.order = append(s.order, name)
+	return name
+}
+
+// typeName returns the name of the message or of the enum, prefixed by its package when another type has the name
+func (s *Schema) typeName(descriptor protoreflect.Descriptor) string {
+	name := string(descriptor.Name())
+	if !s.typeExists(name) {
+		return name
+	}
+	pkg := strings.Split(string(descriptor.ParentFile().Package()), ".")[0]
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+func (s *Schema) typeExists(name string) bool {
+	_, object := s.objects[name]
+	_, in := s.inputs[name]
+	_, enum := s.enums[name]
+	_, scalar := s.scalars[name]
+	return object || in || enum || scalar
+}
+
+// fieldType returns the GraphQL type of a message field, as protojson encodes it
+func (s *Schema) fieldType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return Map + "!"
+	}
+	named := ""
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		named = "Boolean!"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		named = "Int!"
+	case protoreflect.FloatKind, protoreflect.DoubleKind, protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		named = "Float!"
+	case protoreflect.EnumKind:
+		named = s.enum(field.Enum()) + "!"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		named = s.message(field.Message())
+		if field.IsList() {
+			named += "!"
+		}
+	default:
+		// strings, bytes and the 64-bit integers, which protojson sends as strings
+		named = "String!"
+	}
+	if field.IsList() {
+		return "[" + named + "]!"
+	}
+	return named
+}
+
+// resolveField returns the resolver of a message field
+func resolveField(field protoreflect.FieldDescriptor) Resolver {
+	return func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		values := make([]interface{}, len(parents))
+		for i, parent := range parents {
+			message, ok := parent.(proto.Message)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a protobuf message", parent)
+			}
+			var err error
+			if values[i], err = fieldValue(message.ProtoReflect(), field); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+}
+
+func fieldValue(m protoreflect.Message, field protoreflect.FieldDescriptor) (interface{}, error) {
+	switch {
+	case field.IsMap():
+		values := map[string]interface{}{}
+		var err error
+		m.Get(field).Map().Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+			values[key.String()], err = mapValue(field.MapValue(), v)
+			return err == nil
+		})
+		return values, err
+	case field.IsList():
+		list := m.Get(field).List()
+		values := make([]interface{}, list.Len())
+		for i := range values {
+			values[i] = singular(field, list.Get(i))
+		}
+		return values, nil
+	case field.Message() != nil && !m.Has(field):
+		return nil, nil
+	}
+	return singular(field, m.Get(field)), nil
+}
+
+func singular(field protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return float64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int32(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v.Message().Interface()
+	}
+	return v.Interface()
+}
+
+// mapValue converts the values of the maps, which are sent as JSON objects
+func mapValue(field protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	if field.Message() != nil {
+		data, err := mediatype.MarshalJSON(v.Message().Interface())
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(data), nil
+	}
+	return singular(field, v), nil
+}
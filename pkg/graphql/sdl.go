@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// String prints the schema in the GraphQL schema definition language
+func (s *Schema) String() string {
+	b := &strings.Builder{}
+	description(b, "", s.scalars[Map])
+	fmt.Fprintf(b, "scalar %s\n", Map)
+	for _, name := range s.order {
+		b.WriteString("\n")
+		if o, ok := s.objects[name]; ok {
+			description(b, "", o.description)
+			fmt.Fprintf(b, "type %s {\n", name)
+			for _, fieldName := range o.fields {
+				field := o.byName[fieldName]
+				description(b, "  ", field.Description)
+				fmt.Fprintf(b, "  %s%s: %s\n", fieldName, arguments(field.Arguments), field.Type)
+			}
+			b.WriteString("}\n")
+			continue
+		}
+		if in, ok := s.inputs[name]; ok {
+			description(b, "", in.description)
+			fmt.Fprintf(b, "input %s {\n", name)
+			for _, field := range in.fields {
+				description(b, "  ", field.Description)
+				fmt.Fprintf(b, "  %s: %s%s\n", field.Name, field.Type, fallback(field.Default))
+			}
+			b.WriteString("}\n")
+			continue
+		}
+		fmt.Fprintf(b, "enum %s {\n", name)
+		for _, value := range s.enums[name] {
+			fmt.Fprintf(b, "  %s\n", value)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func description(b *strings.Builder, indent string, text string) {
+	if text != "" {
+		fmt.Fprintf(b, "%s%q\n", indent, text)
+	}
+}
+
+func arguments(args []Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	printed := make([]string, len(args))
+	for i, a := range args {
+		printed[i] = fmt.Sprintf("%s: %s%s", a.Name, a.Type, fallback(a.Default))
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func fallback(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return " = " + string(data)
+}
@@ -0,0 +1,376 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/logger"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	issuev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+//go:generate mockgen -source ./issues.go -destination mocks/issues.go
+
+const (
+	// pageSize is the number of scenarios or executions retrieved at once when the linked issues are refreshed
+	pageSize = 500
+	// idSortKey is the field used to page through the scenarios and executions
+	idSortKey = "identity.id"
+)
+
+type itemLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type stateSetter func(ctx context.Context, user string, states map[string]string) (int, error)
+type refresher func(ctx context.Context, user string) (*issuev1.SyncResult, error)
+type itemGetter func(ctx context.Context, id string) (interface{}, error)
+type issueAdder func(ctx context.Context, user string, id string, position int32, issue *metadatav1.LinkedIssue) (*executionv1.Execution, error)
+
+// Tracker retrieves information from the issue tracker
+type Tracker interface {
+	State(ctx context.Context, link string) (string, error)
+	ParseWebhook(body io.Reader) ([]*issuev1.IssueState, error)
+	Create(ctx context.Context, defect *issuev1.Defect) (*metadatav1.LinkedIssue, error)
+}
+
+// Refresh returns a function used to retrieve the state of all linked issues from the issue tracker and to update the scenarios and executions they are linked to.
+// Issues that cannot be retrieved keep their state and are reported in the result
+func Refresh(tracker Tracker, listScenarios itemLister, listExecutions itemLister, setScenarioStates stateSetter, setExecutionStates stateSetter) func(ctx context.Context, user string) (*issuev1.SyncResult, error) {
+	return func(ctx context.Context, user string) (*issuev1.SyncResult, error) {
+		links, err := linkedIssues(ctx, listScenarios, listExecutions)
+		if err != nil {
+			return nil, err
+		}
+		result := &issuev1.SyncResult{States: []*issuev1.IssueState{}, Errors: []string{}}
+		for _, link := range links {
+			state, err := tracker.State(ctx, link)
+			if err != nil {
+				result.Errors = append(result.Errors, err.Error())
+				continue
+			}
+			result.States = append(result.States, &issuev1.IssueState{Link: link, State: state})
+		}
+		if err := apply(ctx, user, result, setScenarioStates, setExecutionStates); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// RefreshNow returns a function used to trigger the refresh of the linked issues through the API. The refresh runs in the
+// background and the result of the last finished refresh is returned
+func RefreshNow(request func(user string) *issuev1.SyncResult) func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+		return request(user), nil
+	}
+}
+
+// Webhook returns a function used to apply the issue states sent by the issue tracker
+func Webhook(tracker Tracker, setScenarioStates stateSetter, setExecutionStates stateSetter) func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+		states, err := tracker.ParseWebhook(data)
+		if err != nil {
+			return nil, err
+		}
+		result := &issuev1.SyncResult{States: states, Errors: []string{}}
+		if err := apply(ctx, user, result, setScenarioStates, setExecutionStates); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// ReportDefect returns a function used to create a defect in the issue tracker from a failed step of an execution.
+// The created issue is linked to both the step and the execution, which is returned
+func ReportDefect(tracker Tracker, getExecution itemGetter, getScenario itemGetter, addIssue issueAdder) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &issuev1.DefectRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		rawExecution, err := getExecution(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		execution, ok := rawExecution.(*executionv1.Execution)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for execution")
+		}
+		step := execution.Step(request.Position)
+		if step == nil {
+			return nil, decoder.NewValidationError(fmt.Sprintf("execution '%s' has no step at position %d", id, request.Position))
+		}
+		if step.Status != executionv1.Status_Fail {
+			return nil, decoder.NewValidationError(fmt.Sprintf("step at position %d has not failed", request.Position))
+		}
+		rawScenario, err := getScenario(ctx, execution.ScenarioId)
+		if err != nil {
+			return nil, err
+		}
+		scenario, ok := rawScenario.(*scenariov1.Scenario)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for scenario")
+		}
+		issue, err := tracker.Create(ctx, composeDefect(request, execution, step, scenario))
+		if err != nil {
+			return nil, err
+		}
+		return addIssue(ctx, user, id, request.Position, issue)
+	}
+}
+
+// Refresher runs the refreshes of the linked issues in the background, one at a time, so that they are not bound to the timeout of a request
+type Refresher struct {
+	refresh  refresher
+	requests chan string
+	mu       sync.Mutex
+	last     *issuev1.SyncResult
+}
+
+// NewRefresher creates a Refresher. The refreshes only run while Watch is running
+func NewRefresher(refresh refresher) *Refresher {
+	return &Refresher{
+		refresh:  refresh,
+		requests: make(chan string, 1),
+		last:     &issuev1.SyncResult{States: []*issuev1.IssueState{}, Errors: []string{}},
+	}
+}
+
+// Request queues a refresh made on behalf of the user and returns the result of the last finished refresh.
+// A request made while another one is queued is merged into it
+func (r *Refresher) Request(user string) *issuev1.SyncResult {
+	select {
+	case r.requests <- user:
+	default:
+	}
+	return r.Last()
+}
+
+// Last returns the result of the last finished refresh
+func (r *Refresher) Last() *issuev1.SyncResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// Watch runs the requested refreshes until the context is done. When the interval is greater than 0, the linked issues
+// are also refreshed at every interval on behalf of the user
+func (r *Refresher) Watch(ctx context.Context, interval time.Duration, user string, log logger.Logger) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			r.run(ctx, user, log)
+		case requester := <-r.requests:
+			r.run(ctx, requester, log)
+		}
+	}
+}
+
+func (r *Refresher) run(ctx context.Context, user string, log logger.Logger) {
+	result, err := r.refresh(ctx, user)
+	if err != nil {
+		log.Errorw("could not refresh linked issues", "error", err)
+		return
+	}
+	log.Infow("refreshed linked issues", "issues", len(result.States), "scenarios", result.Scenarios, "executions", result.Executions, "errors", len(result.Errors))
+	for _, e := range result.Errors {
+		log.Errorw("could not refresh linked issue", "error", e)
+	}
+	r.mu.Lock()
+	r.last = result
+	r.mu.Unlock()
+}
+
+// Blocked returns a function used to list the executions that have an open defect linked to them or to one of their steps
+func Blocked(listExecutions itemLister, closedStates []string) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		items, err := listExecutions(ctx, filter, sortBy, reverse, 0, previousLastValue)
+		if err != nil {
+			return nil, err
+		}
+		blocked := []interface{}{}
+		for _, item := range items {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			if !hasOpenDefect(execution, closedStates) {
+				continue
+			}
+			blocked = append(blocked, execution)
+			if count > 0 && len(blocked) == count {
+				break
+			}
+		}
+		return blocked, nil
+	}
+}
+
+func hasOpenDefect(execution *executionv1.Execution, closedStates []string) bool {
+	issues := append([]*metadatav1.LinkedIssue{}, execution.Issues...)
+	for _, step := range execution.Steps {
+		issues = append(issues, step.Issues...)
+	}
+	for _, issue := range issues {
+		if issue.IssueType == metadatav1.IssueType_DEFECT && issue.IsOpen(closedStates) {
+			return true
+		}
+	}
+	return false
+}
+
+// composeDefect describes the failed step in a defect. The description contains what the tester would copy by hand:
+// the scenario, the definition and result of the step, the attachments and who ran the execution, when and in which test plan
+func composeDefect(request *issuev1.DefectRequest, execution *executionv1.Execution, step *executionv1.StepExecution, scenario *scenariov1.Scenario) *issuev1.Defect {
+	defect := &issuev1.Defect{
+		Title:       request.Title,
+		Severity:    request.Severity,
+		ProjectId:   execution.ProjectId,
+		ScenarioId:  execution.ScenarioId,
+		ExecutionId: execution.Identity.GetId(),
+		TestPlanId:  execution.TestPlanId,
+		Position:    request.Position,
+		Attachments: step.Attachments,
+		Labels:      execution.Labels,
+	}
+	if defect.Title == "" {
+		defect.Title = fmt.Sprintf("%s: step %d '%s' failed", scenario.Name, request.Position, step.Definition.Name)
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Scenario: %s\n", scenario.Name)
+	if scenario.Description != "" {
+		fmt.Fprintf(b, "%s\n", scenario.Description)
+	}
+	if execution.Prerequisites != "" {
+		fmt.Fprintf(b, "\nPrerequisites: %s\n", execution.Prerequisites)
+	}
+	fmt.Fprintf(b, "\nStep %d: %s\n", request.Position, step.Definition.Name)
+	if step.Definition.Description != "" {
+		fmt.Fprintf(b, "%s\n", step.Definition.Description)
+	}
+	fmt.Fprintf(b, "Action: %s\n", step.Definition.Action)
+	fmt.Fprintf(b, "Expected outcome: %s\n", step.Definition.ExpectedOutcome)
+	fmt.Fprintf(b, "Actual result: %s\n", step.ActualResult)
+	if request.Comment != "" {
+		fmt.Fprintf(b, "\n%s\n", request.Comment)
+	}
+	if len(step.Attachments) != 0 {
+		fmt.Fprintf(b, "\nAttachments:\n")
+		for _, a := range step.Attachments {
+			fmt.Fprintf(b, "- %s: %s\n", a.Name, a.Link)
+		}
+	}
+	fmt.Fprintf(b, "\nExecution: %s\nTest plan: %s\n", execution.Identity.GetId(), execution.TestPlanId)
+	if identity := execution.Identity; identity != nil {
+		fmt.Fprintf(b, "Executed by: %s on %s\n", identity.UpdatedBy, time.Unix(identity.UpdateTime, 0).UTC().Format(time.RFC3339))
+	}
+	if len(execution.CustomFields) != 0 {
+		names := make([]string, 0, len(execution.CustomFields))
+		for name := range execution.CustomFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(b, "%s: %s\n", name, strings.Join(execution.CustomFields[name].GetValues(), ", "))
+		}
+	}
+	defect.Description = b.String()
+	return defect
+}
+
+// linkedIssues returns the links of all issues linked to scenarios, executions and execution steps
+func linkedIssues(ctx context.Context, listScenarios itemLister, listExecutions itemLister) ([]string, error) {
+	seen := map[string]bool{}
+	add := func(issues []*metadatav1.LinkedIssue) {
+		for _, issue := range issues {
+			if issue.Link != "" {
+				seen[issue.Link] = true
+			}
+		}
+	}
+	err := eachPage(ctx, listScenarios, func(item interface{}) (string, error) {
+		scenario, ok := item.(*scenariov1.Scenario)
+		if !ok {
+			return "", fmt.Errorf("invalid DB entry for scenario")
+		}
+		add(scenario.Issues)
+		return scenario.GetIdentity().GetId(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = eachPage(ctx, listExecutions, func(item interface{}) (string, error) {
+		execution, ok := item.(*executionv1.Execution)
+		if !ok {
+			return "", fmt.Errorf("invalid DB entry for execution")
+		}
+		add(execution.Issues)
+		for _, step := range execution.Steps {
+			add(step.Issues)
+		}
+		return execution.GetIdentity().GetId(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	links := make([]string, 0, len(seen))
+	for link := range seen {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+	return links, nil
+}
+
+// eachPage lists the items page by page, sorted by their ID, so that a refresh does not load a whole collection at once.
+// The visit function returns the ID of the item, used to request the next page
+func eachPage(ctx context.Context, list itemLister, visit func(item interface{}) (string, error)) error {
+	lastID := ""
+	for {
+		items, err := list(ctx, map[string][]string{}, idSortKey, false, pageSize, lastID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if lastID, err = visit(item); err != nil {
+				return err
+			}
+		}
+		if len(items) < pageSize || lastID == "" {
+			return nil
+		}
+	}
+}
+
+// apply stores the states of the result in the scenarios and executions
+func apply(ctx context.Context, user string, result *issuev1.SyncResult, setScenarioStates stateSetter, setExecutionStates stateSetter) error {
+	states := make(map[string]string, len(result.States))
+	for _, s := range result.States {
+		states[s.Link] = s.State
+	}
+	scenarios, err := setScenarioStates(ctx, user, states)
+	if err != nil {
+		return err
+	}
+	executions, err := setExecutionStates(ctx, user, states)
+	if err != nil {
+		return err
+	}
+	result.Scenarios = int32(scenarios)
+	result.Executions = int32(executions)
+	return nil
+}
@@ -0,0 +1,257 @@
+package issues_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	issue "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/issues"
+	mockIssues "github.com/curious-kitten/scratch-post/pkg/issues/mocks"
+)
+
+func listOf(items ...interface{}) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		return items, nil
+	}
+}
+
+// recordStates returns a state setter that saves the states it receives and reports that changed items were updated
+func recordStates(received *map[string]string, changed int) func(ctx context.Context, user string, states map[string]string) (int, error) {
+	return func(ctx context.Context, user string, states map[string]string) (int, error) {
+		*received = states
+		return changed, nil
+	}
+}
+
+func defect(link string, state string) *metadata.LinkedIssue {
+	return &metadata.LinkedIssue{Link: link, IssueType: metadata.IssueType_DEFECT, State: state}
+}
+
+func TestRefresh(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	mockTracker.EXPECT().State(ctx, "https://tracker/1").Return("Done", nil)
+	mockTracker.EXPECT().State(ctx, "https://tracker/2").Return("", fmt.Errorf("issue tracker responded with status 404"))
+	mockTracker.EXPECT().State(ctx, "https://tracker/3").Return("Open", nil)
+
+	scenarios := listOf(&scenario.Scenario{Issues: []*metadata.LinkedIssue{defect("https://tracker/1", "Open")}})
+	executions := listOf(&execution.Execution{
+		Issues: []*metadata.LinkedIssue{defect("https://tracker/1", "Open"), defect("https://tracker/2", "Open")},
+		Steps:  []*execution.StepExecution{{Issues: []*metadata.LinkedIssue{defect("https://tracker/3", "Open")}}},
+	})
+	scenarioStates := map[string]string{}
+	executionStates := map[string]string{}
+	refresh := issues.Refresh(mockTracker, scenarios, executions, recordStates(&scenarioStates, 1), recordStates(&executionStates, 2))
+	result, err := refresh(ctx, "tester")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.States).To(HaveLen(2), "wrong number of retrieved states")
+	g.Expect(result.Errors).To(HaveLen(1), "failed issue was not reported")
+	g.Expect(result.Scenarios).To(Equal(int32(1)), "wrong number of changed scenarios")
+	g.Expect(result.Executions).To(Equal(int32(2)), "wrong number of changed executions")
+	expected := map[string]string{"https://tracker/1": "Done", "https://tracker/3": "Open"}
+	g.Expect(scenarioStates).To(Equal(expected), "wrong states applied to scenarios")
+	g.Expect(executionStates).To(Equal(expected), "wrong states applied to executions")
+}
+
+func TestRefresh_Pages(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	mockTracker.EXPECT().State(ctx, "https://tracker/last").Return("Done", nil)
+
+	requested := []string{}
+	scenarios := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(sortBy).To(Equal("identity.id"), "scenarios are not paged by ID")
+		g.Expect(count).To(BeNumerically(">", 0), "all scenarios are listed at once")
+		requested = append(requested, previousLastValue)
+		if previousLastValue != "" {
+			return []interface{}{&scenario.Scenario{
+				Identity: &metadata.Identity{Id: "last"},
+				Issues:   []*metadata.LinkedIssue{defect("https://tracker/last", "Open")},
+			}}, nil
+		}
+		page := make([]interface{}, count)
+		for i := range page {
+			page[i] = &scenario.Scenario{Identity: &metadata.Identity{Id: fmt.Sprintf("s%04d", i)}}
+		}
+		return page, nil
+	}
+	scenarioStates := map[string]string{}
+	executionStates := map[string]string{}
+	refresh := issues.Refresh(mockTracker, scenarios, listOf(), recordStates(&scenarioStates, 1), recordStates(&executionStates, 0))
+	result, err := refresh(ctx, "tester")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(requested).To(HaveLen(2), "the next page was not requested")
+	g.Expect(requested[1]).To(HavePrefix("s"), "the next page does not start after the last scenario")
+	g.Expect(result.States).To(HaveLen(1), "issue of the next page was not refreshed")
+}
+
+func TestRefresher(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	users := make(chan string, 1)
+	refresher := issues.NewRefresher(func(ctx context.Context, user string) (*issue.SyncResult, error) {
+		users <- user
+		return &issue.SyncResult{States: []*issue.IssueState{{Link: "https://tracker/1", State: "Done"}}, Scenarios: 1}, nil
+	})
+	g.Expect(refresher.Request("tester").States).To(BeEmpty(), "a refresh ran before Watch was started")
+
+	go refresher.Watch(ctx, 0, "issue-tracker", zap.NewNop().Sugar())
+	g.Eventually(users).Should(Receive(Equal("tester")), "the requested refresh did not run on behalf of the user")
+	g.Eventually(func() int { return len(refresher.Last().States) }).Should(Equal(1), "the result of the refresh was not kept")
+
+	result, err := issues.RefreshNow(refresher.Request)(ctx, "admin", strings.NewReader(""))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.(*issue.SyncResult).Scenarios).To(Equal(int32(1)), "the last result was not returned")
+	g.Eventually(users).Should(Receive(Equal("admin")), "the requested refresh did not run")
+}
+
+func TestWebhook(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	mockTracker.EXPECT().ParseWebhook(gomock.Any()).Return([]*issue.IssueState{{Link: "https://tracker/1", State: "Closed"}}, nil)
+
+	scenarioStates := map[string]string{}
+	executionStates := map[string]string{}
+	webhook := issues.Webhook(mockTracker, recordStates(&scenarioStates, 0), recordStates(&executionStates, 1))
+	raw, err := webhook(ctx, "issue-tracker", strings.NewReader("{}"))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(raw.(*issue.SyncResult).Executions).To(Equal(int32(1)), "wrong number of changed executions")
+	g.Expect(executionStates).To(Equal(map[string]string{"https://tracker/1": "Closed"}), "state was not applied")
+}
+
+func TestWebhook_InvalidPayload(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	mockTracker.EXPECT().ParseWebhook(gomock.Any()).Return(nil, fmt.Errorf("invalid payload"))
+
+	setter := func(ctx context.Context, user string, states map[string]string) (int, error) {
+		t.Fatal("states should not be applied")
+		return 0, nil
+	}
+	_, err := issues.Webhook(mockTracker, setter, setter)(ctx, "issue-tracker", strings.NewReader("{}"))
+	g.Expect(err).Should(HaveOccurred(), "no error for an invalid payload")
+}
+
+func TestBlocked(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	executions := listOf(
+		&execution.Execution{Name: "open defect", Issues: []*metadata.LinkedIssue{defect("https://tracker/1", "In Progress")}},
+		&execution.Execution{Name: "closed defect", Issues: []*metadata.LinkedIssue{defect("https://tracker/2", "done")}},
+		&execution.Execution{Name: "open story", Issues: []*metadata.LinkedIssue{{Link: "https://tracker/3", IssueType: metadata.IssueType_STORY}}},
+		&execution.Execution{Name: "open step defect", Steps: []*execution.StepExecution{{Issues: []*metadata.LinkedIssue{defect("https://tracker/4", "")}}}},
+	)
+	blocked, err := issues.Blocked(executions, []string{"Done"})(ctx, map[string][]string{}, "", false, 0, "")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(blocked).To(HaveLen(2), "wrong number of blocked executions")
+	g.Expect(blocked[0].(*execution.Execution).Name).To(Equal("open defect"), "execution with open defect is not blocked")
+	g.Expect(blocked[1].(*execution.Execution).Name).To(Equal("open step defect"), "execution with open step defect is not blocked")
+
+	limited, err := issues.Blocked(executions, []string{"Done"})(ctx, map[string][]string{}, "", false, 1, "")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(limited).To(HaveLen(1), "count was not applied")
+}
+
+func failedExecution() *execution.Execution {
+	return &execution.Execution{
+		Identity:      &metadata.Identity{Id: "e1", UpdatedBy: "tester", UpdateTime: 1614610294},
+		ProjectId:     "p1",
+		ScenarioId:    "s1",
+		TestPlanId:    "tp1",
+		Prerequisites: "an existing account",
+		Labels:        []string{"smoke"},
+		Steps: []*execution.StepExecution{
+			{Definition: &scenario.Step{Position: 1, Name: "open app"}, Status: execution.Status_Pass},
+			{
+				Definition:   &scenario.Step{Position: 2, Name: "login", Action: "log in with the account", ExpectedOutcome: "home page is shown"},
+				Status:       execution.Status_Fail,
+				ActualResult: "error page is shown",
+				Attachments:  []*execution.Attachment{{Name: "screenshot.png", Link: "https://files/screenshot.png"}},
+			},
+		},
+	}
+}
+
+func getItem(item interface{}) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		return item, nil
+	}
+}
+
+func TestReportDefect(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	created := &metadata.LinkedIssue{Link: "https://tracker/31", IssueType: metadata.IssueType_DEFECT}
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	mockTracker.
+		EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, defect *issue.Defect) (*metadata.LinkedIssue, error) {
+			g.Expect(defect.Title).To(Equal("Login: step 2 'login' failed"), "wrong default title")
+			g.Expect(defect.Severity).To(Equal(metadata.Severity_HIGH), "severity was not set")
+			g.Expect(defect.ExecutionId).To(Equal("e1"), "execution was not set")
+			g.Expect(defect.Attachments).To(HaveLen(1), "attachments were not set")
+			g.Expect(defect.Labels).To(Equal([]string{"smoke"}), "labels were not set")
+			for _, part := range []string{"Scenario: Login", "an existing account", "Step 2: login", "log in with the account", "home page is shown", "Actual result: error page is shown", "also on mobile", "https://files/screenshot.png", "Test plan: tp1", "Executed by: tester"} {
+				g.Expect(defect.Description).To(ContainSubstring(part), "description is missing information")
+			}
+			return created, nil
+		})
+	var linked *metadata.LinkedIssue
+	addIssue := func(ctx context.Context, user string, id string, position int32, issue *metadata.LinkedIssue) (*execution.Execution, error) {
+		g.Expect(id).To(Equal("e1"), "issue added to the wrong execution")
+		g.Expect(position).To(Equal(int32(2)), "issue added to the wrong step")
+		linked = issue
+		return failedExecution(), nil
+	}
+	report := issues.ReportDefect(mockTracker, getItem(failedExecution()), getItem(&scenario.Scenario{Name: "Login"}), addIssue)
+	request := &issue.DefectRequest{Position: 2, Severity: metadata.Severity_HIGH, Comment: "also on mobile"}
+	_, err := report(ctx, "tester", "e1", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(linked).To(Equal(created), "created issue was not linked")
+}
+
+func TestReportDefect_StepNotFailed(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockTracker := mockIssues.NewMockTracker(ctrl)
+	addIssue := func(ctx context.Context, user string, id string, position int32, issue *metadata.LinkedIssue) (*execution.Execution, error) {
+		t.Fatal("issue should not be added")
+		return nil, nil
+	}
+	report := issues.ReportDefect(mockTracker, getItem(failedExecution()), getItem(&scenario.Scenario{Name: "Login"}), addIssue)
+	_, err := report(ctx, "tester", "e1", transformers.ToReadCloser(&issue.DefectRequest{Position: 1}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error for a step that passed")
+	_, err = report(ctx, "tester", "e1", transformers.ToReadCloser(&issue.DefectRequest{Position: 3}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error for a missing step")
+	_, err = report(ctx, "tester", "e1", transformers.ToReadCloser(&issue.DefectRequest{}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error without a position")
+}
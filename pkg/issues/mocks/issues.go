@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./issues.go
+
+// Package mock_issues is a generated GoMock package.
+package mock_issues
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	issue "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTracker is a mock of Tracker interface.
+type MockTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackerMockRecorder
+}
+
+// MockTrackerMockRecorder is the mock recorder for MockTracker.
+type MockTrackerMockRecorder struct {
+	mock *MockTracker
+}
+
+// NewMockTracker creates a new mock instance.
+func NewMockTracker(ctrl *gomock.Controller) *MockTracker {
+	mock := &MockTracker{ctrl: ctrl}
+	mock.recorder = &MockTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTracker) EXPECT() *MockTrackerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTracker) Create(ctx context.Context, defect *issue.Defect) (*metadata.LinkedIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, defect)
+	ret0, _ := ret[0].(*metadata.LinkedIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTrackerMockRecorder) Create(ctx, defect interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTracker)(nil).Create), ctx, defect)
+}
+
+// ParseWebhook mocks base method.
+func (m *MockTracker) ParseWebhook(body io.Reader) ([]*issue.IssueState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseWebhook", body)
+	ret0, _ := ret[0].([]*issue.IssueState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseWebhook indicates an expected call of ParseWebhook.
+func (mr *MockTrackerMockRecorder) ParseWebhook(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseWebhook", reflect.TypeOf((*MockTracker)(nil).ParseWebhook), body)
+}
+
+// State mocks base method.
+func (m *MockTracker) State(ctx context.Context, link string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State", ctx, link)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// State indicates an expected call of State.
+func (mr *MockTrackerMockRecorder) State(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockTracker)(nil).State), ctx, link)
+}
@@ -0,0 +1,113 @@
+package issuetracker
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	issuev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+)
+
+// User is the name used as author for the changes made on behalf of the issue tracker
+const User = "issue-tracker"
+
+// WebhookSecretHeader is the header in which the issue tracker has to send the webhook secret
+const WebhookSecretHeader = "X-Webhook-Secret"
+
+// DefaultClosedStates are the states of an issue that are considered closed when the configuration does not provide them
+var DefaultClosedStates = []string{"Closed", "Done", "Resolved"}
+
+// Connector is implemented by the integrations with issue trackers
+type Connector interface {
+	// State returns the current state of the issue found at the link
+	State(ctx context.Context, link string) (string, error)
+	// ParseWebhook returns the issue states sent by the issue tracker through a webhook
+	ParseWebhook(body io.Reader) ([]*issuev1.IssueState, error)
+	// Create submits the defect to the issue tracker and returns the created issue
+	Create(ctx context.Context, defect *issuev1.Defect) (*metadatav1.LinkedIssue, error)
+}
+
+// Factory creates a connector based on the configuration
+type Factory func(cfg Config, client *http.Client) (Connector, error)
+
+var connectors = map[string]Factory{
+	"rest": NewREST,
+}
+
+// Register makes a connector available for the type. Registering a type twice replaces the previous connector
+func Register(connectorType string, factory Factory) {
+	connectors[connectorType] = factory
+}
+
+// New creates the connector for the type set in the configuration
+func New(cfg Config, client *http.Client) (Connector, error) {
+	factory, ok := connectors[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown issue tracker type '%s'", cfg.Type)
+	}
+	return factory(cfg, client)
+}
+
+// Config represents the connection to the issue tracker
+type Config struct {
+	// Type of the connector, ie. rest
+	Type string `json:"type"`
+	// Time between two refreshes of the linked issues, ie. 15m. An empty value disables the refresh
+	RefreshInterval string `json:"refreshInterval"`
+	// States of an issue which are considered closed
+	ClosedStates []string `json:"closedStates"`
+	// Secret the issue tracker sends when calling the webhook. An empty value disables the webhook
+	WebhookSecret string `json:"webhookSecret"`
+	// Settings of the generic REST connector
+	REST RESTConfig `json:"rest"`
+}
+
+// Validate that the config object is correct
+func (c Config) Validate() error {
+	if c.Type == "" {
+		return fmt.Errorf("type field is mandatory")
+	}
+	if _, ok := connectors[c.Type]; !ok {
+		types := make([]string, 0, len(connectors))
+		for t := range connectors {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return fmt.Errorf("unknown issue tracker type '%s', known types are: %s", c.Type, strings.Join(types, ", "))
+	}
+	if c.RefreshInterval != "" {
+		if _, err := time.ParseDuration(c.RefreshInterval); err != nil {
+			return fmt.Errorf("refreshInterval is not a valid duration: %w", err)
+		}
+	}
+	return nil
+}
+
+// Interval returns the time between two refreshes of the linked issues. It is 0 if the refresh is disabled
+func (c Config) Interval() time.Duration {
+	interval, _ := time.ParseDuration(c.RefreshInterval)
+	return interval
+}
+
+// Closed returns the states which are considered closed
+func (c Config) Closed() []string {
+	if len(c.ClosedStates) == 0 {
+		return DefaultClosedStates
+	}
+	return c.ClosedStates
+}
+
+// WebhookUser checks that the request contains the webhook secret and returns the user used for the changes
+func (c Config) WebhookUser(r *http.Request) (string, error) {
+	secret := r.Header.Get(WebhookSecretHeader)
+	if c.WebhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(c.WebhookSecret)) != 1 {
+		return "", fmt.Errorf("request has an invalid webhook secret")
+	}
+	return User, nil
+}
@@ -0,0 +1,247 @@
+package issuetracker_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	issue "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	"github.com/curious-kitten/scratch-post/pkg/issuetracker"
+)
+
+// stubTracker serves issues in the format used by most trackers: {"key": "...", "fields": {"status": {"name": "..."}}}
+func stubTracker(states map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/issue/")
+		state, ok := states[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "` + key + `", "fields": {"status": {"name": "` + state + `"}}}`))
+	}))
+}
+
+func restConfig(server *httptest.Server) issuetracker.Config {
+	return issuetracker.Config{
+		Type:          "rest",
+		WebhookSecret: "secret",
+		REST: issuetracker.RESTConfig{
+			IssueURL:            server.URL + "/rest/api/issue/{id}",
+			StateField:          "fields.status.name",
+			Headers:             map[string]string{"Authorization": "Bearer token"},
+			WebhookLinkField:    "issue.key",
+			WebhookLinkTemplate: "https://tracker.example.com/browse/{id}",
+			WebhookStateField:   "issue.fields.status.name",
+		},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	g := NewWithT(t)
+	cfg := issuetracker.Config{}
+	g.Expect(cfg.Validate()).Should(HaveOccurred(), "no error without a type")
+	cfg.Type = "unknown"
+	g.Expect(cfg.Validate()).Should(HaveOccurred(), "no error with an unknown type")
+	cfg.Type = "rest"
+	cfg.RefreshInterval = "often"
+	g.Expect(cfg.Validate()).Should(HaveOccurred(), "no error with an invalid interval")
+	cfg.RefreshInterval = "15m"
+	g.Expect(cfg.Validate()).ShouldNot(HaveOccurred(), "unexpected error with a valid config")
+	g.Expect(cfg.Interval().Minutes()).To(Equal(float64(15)), "wrong interval")
+	g.Expect(cfg.Closed()).To(Equal(issuetracker.DefaultClosedStates), "default closed states are not used")
+}
+
+func TestREST_State(t *testing.T) {
+	g := NewWithT(t)
+	server := stubTracker(map[string]string{"SHOP-12": "In Progress"})
+	defer server.Close()
+	connector, err := issuetracker.New(restConfig(server), server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	state, err := connector.State(context.Background(), "https://tracker.example.com/browse/SHOP-12")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(state).To(Equal("In Progress"), "wrong state")
+
+	_, err = connector.State(context.Background(), "https://tracker.example.com/browse/SHOP-13")
+	g.Expect(err).Should(HaveOccurred(), "no error for an issue that does not exist")
+}
+
+func TestREST_StateFromLink(t *testing.T) {
+	g := NewWithT(t)
+	server := stubTracker(map[string]string{"SHOP-12": "Done"})
+	defer server.Close()
+	cfg := restConfig(server)
+	cfg.REST.IssueURL = ""
+	cfg.REST.TrackerURL = server.URL + "/rest"
+	connector, err := issuetracker.New(cfg, server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	state, err := connector.State(context.Background(), server.URL+"/rest/api/issue/SHOP-12")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(state).To(Equal("Done"), "wrong state")
+}
+
+func TestREST_StateFromLinkOutsideTracker(t *testing.T) {
+	g := NewWithT(t)
+	received := false
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer other.Close()
+	server := stubTracker(map[string]string{"SHOP-12": "Done"})
+	defer server.Close()
+	cfg := restConfig(server)
+	cfg.REST.IssueURL = ""
+	cfg.REST.TrackerURL = server.URL + "/rest"
+
+	connector, err := issuetracker.New(cfg, other.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+	for _, link := range []string{other.URL + "/rest/api/issue/SHOP-12", server.URL + "/other/SHOP-12", server.URL + "/rest/../other/SHOP-12", "file:///etc/passwd"} {
+		_, err = connector.State(context.Background(), link)
+		g.Expect(err).Should(HaveOccurred(), "no error for a link outside of the tracker: %s", link)
+	}
+	g.Expect(received).To(BeFalse(), "a request was sent outside of the tracker")
+
+	cfg.REST.TrackerURL = ""
+	connector, err = issuetracker.New(cfg, server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+	_, err = connector.State(context.Background(), server.URL+"/rest/api/issue/SHOP-12")
+	g.Expect(err).Should(HaveOccurred(), "links are retrieved without a tracker URL")
+
+	cfg.REST.TrackerURL = "tracker"
+	_, err = issuetracker.New(cfg, server.Client())
+	g.Expect(err).Should(HaveOccurred(), "no error for an invalid tracker URL")
+}
+
+func TestREST_MissingStateField(t *testing.T) {
+	g := NewWithT(t)
+	server := stubTracker(map[string]string{"SHOP-12": "Done"})
+	defer server.Close()
+	cfg := restConfig(server)
+	cfg.REST.StateField = "fields.resolution.name"
+	connector, err := issuetracker.New(cfg, server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	_, err = connector.State(context.Background(), "https://tracker.example.com/browse/SHOP-12")
+	g.Expect(err).Should(HaveOccurred(), "no error when the state field is missing")
+}
+
+func TestREST_ParseWebhook(t *testing.T) {
+	g := NewWithT(t)
+	server := stubTracker(map[string]string{})
+	defer server.Close()
+	connector, err := issuetracker.New(restConfig(server), server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	states, err := connector.ParseWebhook(strings.NewReader(`{"event": "updated", "issue": {"key": "SHOP-12", "fields": {"status": {"name": "Done"}}}}`))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(states).To(HaveLen(1), "wrong number of states")
+	g.Expect(states[0].Link).To(Equal("https://tracker.example.com/browse/SHOP-12"), "link was not built from the template")
+	g.Expect(states[0].State).To(Equal("Done"), "wrong state")
+
+	_, err = connector.ParseWebhook(strings.NewReader(`{"event": "deleted"}`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error for an unknown payload")
+}
+
+func TestNew_MissingWebhookFields(t *testing.T) {
+	g := NewWithT(t)
+	cfg := issuetracker.Config{Type: "rest", WebhookSecret: "secret", REST: issuetracker.RESTConfig{StateField: "state"}}
+	_, err := issuetracker.New(cfg, http.DefaultClient)
+	g.Expect(err).Should(HaveOccurred(), "no error when the webhook fields are missing")
+}
+
+func TestConfig_WebhookUser(t *testing.T) {
+	g := NewWithT(t)
+	cfg := issuetracker.Config{WebhookSecret: "secret"}
+	r := httptest.NewRequest(http.MethodPost, "/issues/webhook", nil)
+	_, err := cfg.WebhookUser(r)
+	g.Expect(err).Should(HaveOccurred(), "no error without a secret")
+	r.Header.Set(issuetracker.WebhookSecretHeader, "wrong")
+	_, err = cfg.WebhookUser(r)
+	g.Expect(err).Should(HaveOccurred(), "no error with a wrong secret")
+	r.Header.Set(issuetracker.WebhookSecretHeader, "secret")
+	user, err := cfg.WebhookUser(r)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error with the right secret")
+	g.Expect(user).To(Equal(issuetracker.User), "wrong user")
+}
+
+func TestREST_Create(t *testing.T) {
+	g := NewWithT(t)
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/issue" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "10001", "key": "SHOP-31"}`))
+	}))
+	defer server.Close()
+	cfg := restConfig(server)
+	cfg.REST.CreateURL = server.URL + "/rest/api/issue"
+	cfg.REST.CreateBody = `{"fields": {"summary": {{json .Title}}, "description": {{json .Description}}, "priority": {"name": "{{.Severity}}"}}}`
+	cfg.REST.CreatedLinkField = "key"
+	cfg.REST.CreatedLinkTemplate = "https://tracker.example.com/browse/{id}"
+	connector, err := issuetracker.New(cfg, server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	defect := &issue.Defect{Title: `Login "fails"`, Description: "Step 1 failed\nActual result: error", Severity: metadata.Severity_HIGH}
+	created, err := connector.Create(context.Background(), defect)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.Link).To(Equal("https://tracker.example.com/browse/SHOP-31"), "link was not built from the template")
+	g.Expect(created.IssueType).To(Equal(metadata.IssueType_DEFECT), "created issue is not a defect")
+	g.Expect(created.Severity).To(Equal(metadata.Severity_HIGH), "severity was not kept")
+	fields := received["fields"].(map[string]interface{})
+	g.Expect(fields["summary"]).To(Equal(`Login "fails"`), "title was not sent")
+	g.Expect(fields["description"]).To(Equal(defect.Description), "description was not sent")
+	g.Expect(fields["priority"]).To(Equal(map[string]interface{}{"name": "HIGH"}), "severity was not sent")
+}
+
+func TestREST_CreateDefaultBody(t *testing.T) {
+	g := NewWithT(t)
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_, _ = w.Write([]byte(`{"link": "https://tracker.example.com/issues/7", "state": "open"}`))
+	}))
+	defer server.Close()
+	cfg := issuetracker.Config{Type: "rest", REST: issuetracker.RESTConfig{StateField: "state", CreateURL: server.URL, CreatedLinkField: "link"}}
+	connector, err := issuetracker.New(cfg, server.Client())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+
+	created, err := connector.Create(context.Background(), &issue.Defect{Title: "Login fails", ExecutionId: "e1"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.Link).To(Equal("https://tracker.example.com/issues/7"), "wrong link")
+	g.Expect(created.State).To(Equal("open"), "state was not read from the response")
+	g.Expect(received["title"]).To(Equal("Login fails"), "defect was not sent as JSON")
+	g.Expect(received["executionId"]).To(Equal("e1"), "defect was not sent as JSON")
+}
+
+func TestREST_CreateNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	connector, err := issuetracker.New(issuetracker.Config{Type: "rest", REST: issuetracker.RESTConfig{StateField: "state"}}, http.DefaultClient)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error creating the connector")
+	_, err = connector.Create(context.Background(), &issue.Defect{})
+	g.Expect(err).Should(HaveOccurred(), "no error when the creation is not configured")
+
+	cfg := issuetracker.Config{Type: "rest", REST: issuetracker.RESTConfig{StateField: "state", CreateURL: "http://tracker", CreatedLinkField: "key", CreateBody: "{{.Title"}}
+	_, err = issuetracker.New(cfg, http.DefaultClient)
+	g.Expect(err).Should(HaveOccurred(), "no error with an invalid body template")
+}
@@ -0,0 +1,243 @@
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	issuev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/issue"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+)
+
+// RESTConfig configures the generic REST connector.
+// URL templates can contain {id}, which is replaced by the last element of the issue link, and {link}, which is replaced by the escaped issue link.
+// Fields are paths in the JSON documents, with the elements separated by '.', ie. fields.status.name
+type RESTConfig struct {
+	// Template of the URL used to retrieve an issue. The issue link is used when empty
+	IssueURL string `json:"issueUrl"`
+	// Base URL of the issue tracker. When IssueURL is empty, only the issue links under it are retrieved,
+	// so that the headers are not sent to other servers
+	TrackerURL string `json:"trackerUrl"`
+	// Field of the issue that contains the state
+	StateField string `json:"stateField"`
+	// Headers added to the requests, ie. Authorization
+	Headers map[string]string `json:"headers"`
+	// Field of the webhook payload that contains the issue link or ID
+	WebhookLinkField string `json:"webhookLinkField"`
+	// Template used to build the issue link from the value of WebhookLinkField. The value is used as link when empty
+	WebhookLinkTemplate string `json:"webhookLinkTemplate"`
+	// Field of the webhook payload that contains the state of the issue
+	WebhookStateField string `json:"webhookStateField"`
+	// URL to which new defects are sent. Defects cannot be created when empty
+	CreateURL string `json:"createUrl"`
+	// Go template of the body used to create a defect. The fields of the defect are available, ie. {{json .Title}}.
+	// The defect is sent as JSON when empty
+	CreateBody string `json:"createBody"`
+	// Field of the response to the creation that contains the link or ID of the created issue
+	CreatedLinkField string `json:"createdLinkField"`
+	// Template used to build the issue link from the value of CreatedLinkField. The value is used as link when empty
+	CreatedLinkTemplate string `json:"createdLinkTemplate"`
+}
+
+// REST is a connector for issue trackers that expose issues as JSON documents over HTTP
+type REST struct {
+	cfg     RESTConfig
+	client  *http.Client
+	body    *template.Template
+	tracker *url.URL
+}
+
+// NewREST creates a REST connector
+func NewREST(cfg Config, client *http.Client) (Connector, error) {
+	if cfg.REST.StateField == "" {
+		return nil, fmt.Errorf("rest.stateField field is mandatory")
+	}
+	if cfg.WebhookSecret != "" && (cfg.REST.WebhookLinkField == "" || cfg.REST.WebhookStateField == "") {
+		return nil, fmt.Errorf("rest.webhookLinkField and rest.webhookStateField are mandatory when the webhook is enabled")
+	}
+	r := &REST{cfg: cfg.REST, client: client}
+	if cfg.REST.TrackerURL != "" {
+		tracker, err := url.Parse(cfg.REST.TrackerURL)
+		if err != nil || tracker.Scheme == "" || tracker.Host == "" {
+			return nil, fmt.Errorf("rest.trackerUrl is not a valid URL")
+		}
+		r.tracker = tracker
+	}
+	if cfg.REST.CreateURL != "" {
+		if cfg.REST.CreatedLinkField == "" {
+			return nil, fmt.Errorf("rest.createdLinkField is mandatory when rest.createUrl is set")
+		}
+		if cfg.REST.CreateBody != "" {
+			body, err := template.New("createBody").Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.REST.CreateBody)
+			if err != nil {
+				return nil, fmt.Errorf("rest.createBody is not a valid template: %w", err)
+			}
+			r.body = body
+		}
+	}
+	return r, nil
+}
+
+// State returns the current state of the issue found at the link
+func (r *REST) State(ctx context.Context, link string) (string, error) {
+	address := link
+	if r.cfg.IssueURL != "" {
+		address = expand(r.cfg.IssueURL, link)
+	} else if !r.onTracker(link) {
+		return "", fmt.Errorf("could not retrieve issue %s: the link is not on the issue tracker", link)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return "", err
+	}
+	var issue interface{}
+	if err := r.do(req, &issue); err != nil {
+		return "", fmt.Errorf("could not retrieve issue %s: %w", link, err)
+	}
+	state, err := field(issue, r.cfg.StateField)
+	if err != nil {
+		return "", fmt.Errorf("could not read issue %s: %w", link, err)
+	}
+	return state, nil
+}
+
+// Create submits the defect to the issue tracker and returns the created issue
+func (r *REST) Create(ctx context.Context, defect *issuev1.Defect) (*metadatav1.LinkedIssue, error) {
+	if r.cfg.CreateURL == "" {
+		return nil, fmt.Errorf("the issue tracker is not configured to create defects")
+	}
+	body := &bytes.Buffer{}
+	if r.body != nil {
+		if err := r.body.Execute(body, defect); err != nil {
+			return nil, fmt.Errorf("could not compose the defect: %w", err)
+		}
+	} else if err := json.NewEncoder(body).Encode(defect); err != nil {
+		return nil, fmt.Errorf("could not compose the defect: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.CreateURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var created interface{}
+	if err := r.do(req, &created); err != nil {
+		return nil, fmt.Errorf("could not create defect: %w", err)
+	}
+	link, err := field(created, r.cfg.CreatedLinkField)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the created defect: %w", err)
+	}
+	if r.cfg.CreatedLinkTemplate != "" {
+		link = strings.ReplaceAll(r.cfg.CreatedLinkTemplate, "{id}", link)
+	}
+	// not all trackers return the state of the created issue, in which case it is filled in by the next refresh
+	state, _ := field(created, r.cfg.StateField)
+	return &metadatav1.LinkedIssue{
+		Link:      link,
+		Severity:  defect.Severity,
+		IssueType: metadatav1.IssueType_DEFECT,
+		State:     state,
+	}, nil
+}
+
+// onTracker checks that the link has the scheme and host of the issue tracker and is under its path
+func (r *REST) onTracker(link string) bool {
+	if r.tracker == nil {
+		return false
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.User != nil {
+		return false
+	}
+	base := strings.TrimSuffix(r.tracker.Path, "/") + "/"
+	return strings.EqualFold(u.Scheme, r.tracker.Scheme) && strings.EqualFold(u.Host, r.tracker.Host) && strings.HasPrefix(path.Clean(u.Path)+"/", base)
+}
+
+// do sends the request with the configured headers and decodes the JSON response
+func (r *REST) do(req *http.Request, response interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	for k, v := range r.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("issue tracker responded with status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// ParseWebhook returns the issue state sent by the issue tracker through a webhook
+func (r *REST) ParseWebhook(body io.Reader) ([]*issuev1.IssueState, error) {
+	var payload interface{}
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid body: %s", err.Error()))
+	}
+	link, err := field(payload, r.cfg.WebhookLinkField)
+	if err != nil {
+		return nil, decoder.NewValidationError(err.Error())
+	}
+	if r.cfg.WebhookLinkTemplate != "" {
+		link = strings.ReplaceAll(r.cfg.WebhookLinkTemplate, "{id}", link)
+	}
+	state, err := field(payload, r.cfg.WebhookStateField)
+	if err != nil {
+		return nil, decoder.NewValidationError(err.Error())
+	}
+	return []*issuev1.IssueState{{Link: link, State: state}}, nil
+}
+
+// expand replaces the placeholders of the template with the values taken from the issue link
+func expand(template string, link string) string {
+	id := link
+	if u, err := url.Parse(link); err == nil && u.Path != "" {
+		id = path.Base(u.Path)
+	}
+	replacer := strings.NewReplacer("{id}", url.PathEscape(id), "{link}", url.QueryEscape(link))
+	return replacer.Replace(template)
+}
+
+// field returns the value found at the path in a JSON document
+func field(document interface{}, fieldPath string) (string, error) {
+	value := document
+	for _, name := range strings.Split(fieldPath, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("field '%s' not found", fieldPath)
+		}
+		if value, ok = object[name]; !ok {
+			return "", fmt.Errorf("field '%s' not found", fieldPath)
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("field '%s' is not a value", fieldPath)
+	}
+}
+
+// toJSON is used by the body template to write values as JSON
+func toJSON(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,113 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+)
+
+// suite is either the testsuites root or a testsuite, which can contain other test suites
+type suite struct {
+	Suites []suite    `xml:"testsuite"`
+	Cases  []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	ClassName string    `xml:"classname,attr"`
+	Name      string    `xml:"name,attr"`
+	Time      string    `xml:"time,attr"`
+	Failures  []problem `xml:"failure"`
+	Errors    []problem `xml:"error"`
+	Skipped   *problem  `xml:"skipped"`
+}
+
+type problem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Parse reads the test cases of a JUnit XML report, which has either testsuites or a testsuite as root.
+//
+// The automation key of a test case is `classname.name`, or the name when the test case has no class name.
+// Test cases with a failure or an error fail, the messages of the failures being the actual result.
+func Parse(content io.Reader) ([]*executionv1.TestResult, error) {
+	root := &suite{}
+	if err := xml.NewDecoder(content).Decode(root); err != nil {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid JUnit report: %s", err))
+	}
+	results := []*executionv1.TestResult{}
+	var collect func(s *suite) error
+	collect = func(s *suite) error {
+		for i := range s.Cases {
+			result, err := convert(&s.Cases[i])
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		for i := range s.Suites {
+			if err := collect(&s.Suites[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := collect(root); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func convert(c *testCase) (*executionv1.TestResult, error) {
+	if c.Name == "" {
+		return nil, decoder.NewValidationError("invalid JUnit report: test case without a name")
+	}
+	result := &executionv1.TestResult{
+		Key:    c.Name,
+		Name:   c.Name,
+		Status: executionv1.Status_Pass,
+	}
+	if c.ClassName != "" {
+		result.Key = c.ClassName + "." + c.Name
+	}
+	if c.Time != "" {
+		duration, err := strconv.ParseFloat(strings.ReplaceAll(c.Time, ",", ""), 64)
+		if err != nil {
+			return nil, decoder.NewValidationError(fmt.Sprintf("invalid JUnit report: time '%s' of test case '%s' is not a number", c.Time, result.Key))
+		}
+		result.Duration = duration
+	}
+	problems := append(append([]problem{}, c.Failures...), c.Errors...)
+	switch {
+	case len(problems) != 0:
+		result.Status = executionv1.Status_Fail
+		messages := make([]string, 0, len(problems))
+		for _, p := range problems {
+			messages = append(messages, p.String())
+		}
+		result.ActualResult = strings.Join(messages, "\n")
+	case c.Skipped != nil:
+		result.Status = executionv1.Status_Skipped
+		result.ActualResult = c.Skipped.String()
+	}
+	return result, nil
+}
+
+// String returns the message followed by the details of the problem
+func (p problem) String() string {
+	message := strings.TrimSpace(p.Message)
+	text := strings.TrimSpace(p.Text)
+	switch {
+	case message == "":
+		return text
+	case text == "" || text == message:
+		return message
+	default:
+		return message + "\n" + text
+	}
+}
@@ -0,0 +1,66 @@
+package junit_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/junit"
+)
+
+const report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="shop" tests="4">
+    <testcase classname="shop.LoginTest" name="login" time="1.5"/>
+    <testcase classname="shop.CartTest" name="add" time="0.25">
+      <failure message="cart is empty" type="AssertionError">expected 1 product
+at CartTest.java:12</failure>
+    </testcase>
+    <testsuite name="checkout">
+      <testcase classname="shop.CheckoutTest" name="pay" time="1,200.0">
+        <error message="connection refused"/>
+      </testcase>
+      <testcase name="refund">
+        <skipped message="not implemented"/>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	results, err := junit.Parse(strings.NewReader(report))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(results).To(HaveLen(4), "test cases of nested suites were not read")
+	g.Expect(results[0]).To(Equal(&execution.TestResult{Key: "shop.LoginTest.login", Name: "login", Status: execution.Status_Pass, Duration: 1.5}))
+	g.Expect(results[1].Status).To(Equal(execution.Status_Fail), "test case with a failure did not fail")
+	g.Expect(results[1].ActualResult).To(Equal("cart is empty\nexpected 1 product\nat CartTest.java:12"), "failure was not the actual result")
+	g.Expect(results[2].Status).To(Equal(execution.Status_Fail), "test case with an error did not fail")
+	g.Expect(results[2].Duration).To(Equal(1200.0), "duration with a thousands separator was not read")
+	g.Expect(results[3].Key).To(Equal("refund"), "name is not the key of a test case without a class name")
+	g.Expect(results[3].Status).To(Equal(execution.Status_Skipped), "skipped test case was not skipped")
+	g.Expect(results[3].ActualResult).To(Equal("not implemented"))
+}
+
+func TestParse_SingleSuite(t *testing.T) {
+	g := NewWithT(t)
+	results, err := junit.Parse(strings.NewReader(`<testsuite name="shop"><testcase classname="shop" name="login"/></testsuite>`))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(results).To(HaveLen(1), "test suite root was not read")
+	g.Expect(results[0].Key).To(Equal("shop.login"))
+}
+
+func TestParse_Errors(t *testing.T) {
+	g := NewWithT(t)
+	for name, content := range map[string]string{
+		"xml":  `<testsuite><testcase name="login">`,
+		"name": `<testsuite><testcase classname="shop"/></testsuite>`,
+		"time": `<testsuite><testcase name="login" time="fast"/></testsuite>`,
+	} {
+		_, err := junit.Parse(strings.NewReader(content))
+		g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid %s is not a validation error", name)
+	}
+}
@@ -0,0 +1,116 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	folderv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+// Folder holds the test cases exported by another test management tool. The root folder is the project
+type Folder struct {
+	Name        string
+	Description string
+	Folders     []*Folder
+	Cases       []*Case
+}
+
+// Case is a test case exported by another test management tool
+type Case struct {
+	// ID of the test case in the tool, ie. C123 for TestRail
+	ID       string
+	Scenario *scenariov1.Scenario
+}
+
+// Creator creates an item, ie. through the API or in the store, and returns its ID
+type Creator func(ctx context.Context, item interface{}) (string, error)
+
+// Result tells what an import created
+type Result struct {
+	ProjectID string
+	Folders   int
+	Scenarios int
+	// Failed holds the reasons why test cases could not be imported
+	Failed []string
+}
+
+// Import creates a project named after the root folder, or uses the project with the given ID, and then the folders and the scenarios of the tree.
+// The ID of every test case is kept in a `<source>:<ID>` label of its scenario and is appended to the name of the scenario when another test case has the same name.
+// Test cases that cannot be created are reported in the result, failing to create the project or a folder stops the import.
+func Import(ctx context.Context, source string, root *Folder, projectID string, createProject Creator, createFolder Creator, createScenario Creator) (*Result, error) {
+	result := &Result{ProjectID: projectID}
+	if projectID == "" {
+		id, err := createProject(ctx, &projectv1.Project{Name: root.Name, Description: root.Description})
+		if err != nil {
+			return nil, fmt.Errorf("could not create project '%s': %w", root.Name, err)
+		}
+		result.ProjectID = id
+	}
+	i := &importer{source: source, result: result, names: map[string]bool{}, createFolder: createFolder, createScenario: createScenario}
+	if err := i.folder(ctx, root, ""); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type importer struct {
+	source         string
+	result         *Result
+	names          map[string]bool
+	createFolder   Creator
+	createScenario Creator
+}
+
+// folder creates the scenarios of the folder, then its subfolders
+func (i *importer) folder(ctx context.Context, f *Folder, folderID string) error {
+	for _, c := range f.Cases {
+		scenario := c.Scenario
+		scenario.ProjectId = i.result.ProjectID
+		scenario.FolderId = folderID
+		if c.ID != "" {
+			scenario.Labels = append(scenario.Labels, i.source+":"+c.ID)
+			if i.names[scenario.Name] {
+				scenario.Name = fmt.Sprintf("%s (%s)", scenario.Name, c.ID)
+			}
+		}
+		i.names[scenario.Name] = true
+		if _, err := i.createScenario(ctx, scenario); err != nil {
+			i.result.Failed = append(i.result.Failed, fmt.Sprintf("could not create scenario '%s': %s", scenario.Name, err))
+			continue
+		}
+		i.result.Scenarios++
+	}
+	for _, sub := range f.Folders {
+		id, err := i.createFolder(ctx, &folderv1.Folder{ProjectId: i.result.ProjectID, Name: sub.Name, Description: sub.Description, ParentId: folderID})
+		if err != nil {
+			return fmt.Errorf("could not create folder '%s': %w", sub.Name, err)
+		}
+		i.result.Folders++
+		if err := i.folder(ctx, sub, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subfolder returns the folder at the path below the folder, creating the missing folders
+func (f *Folder) Subfolder(path []string) *Folder {
+	current := f
+	for _, name := range path {
+		var next *Folder
+		for _, sub := range current.Folders {
+			if sub.Name == name {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			next = &Folder{Name: name}
+			current.Folders = append(current.Folders, next)
+		}
+		current = next
+	}
+	return current
+}
@@ -0,0 +1,79 @@
+package migration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	folder "github.com/curious-kitten/scratch-post/pkg/api/v1/folder"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/migration"
+)
+
+func tree() *migration.Folder {
+	root := &migration.Folder{Name: "Shop"}
+	root.Cases = append(root.Cases, &migration.Case{ID: "C1", Scenario: &scenario.Scenario{Name: "Login"}})
+	cart := root.Subfolder([]string{"Shop", "Cart"})
+	cart.Cases = append(cart.Cases,
+		&migration.Case{ID: "C2", Scenario: &scenario.Scenario{Name: "Login", Labels: []string{"smoke"}}},
+		&migration.Case{ID: "C3", Scenario: &scenario.Scenario{Name: "Broken"}},
+	)
+	return root
+}
+
+func TestImport(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	folders := []*folder.Folder{}
+	scenarios := []*scenario.Scenario{}
+	createProject := func(ctx context.Context, item interface{}) (string, error) {
+		g.Expect(item.(*project.Project).Name).To(Equal("Shop"), "project is not named after the root folder")
+		return "project", nil
+	}
+	createFolder := func(ctx context.Context, item interface{}) (string, error) {
+		folders = append(folders, item.(*folder.Folder))
+		return fmt.Sprintf("folder%d", len(folders)), nil
+	}
+	createScenario := func(ctx context.Context, item interface{}) (string, error) {
+		s := item.(*scenario.Scenario)
+		if s.Name == "Broken" {
+			return "", fmt.Errorf("name is taken")
+		}
+		scenarios = append(scenarios, s)
+		return "scenario", nil
+	}
+	result, err := migration.Import(ctx, "testrail", tree(), "", createProject, createFolder, createScenario)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.ProjectID).To(Equal("project"))
+	g.Expect(result.Folders).To(Equal(2))
+	g.Expect(result.Scenarios).To(Equal(2))
+	g.Expect(result.Failed).To(Equal([]string{"could not create scenario 'Broken': name is taken"}), "failure was not reported")
+
+	g.Expect(folders[1].ParentId).To(Equal("folder1"), "subfolder is not in its parent")
+	g.Expect(folders[1].ProjectId).To(Equal("project"))
+	g.Expect(scenarios[0].Labels).To(Equal([]string{"testrail:C1"}), "original ID was not kept")
+	g.Expect(scenarios[0].FolderId).To(BeEmpty())
+	g.Expect(scenarios[1].Name).To(Equal("Login (C2)"), "duplicate name was not made unique")
+	g.Expect(scenarios[1].Labels).To(Equal([]string{"smoke", "testrail:C2"}))
+	g.Expect(scenarios[1].FolderId).To(Equal("folder2"), "scenario is not in its folder")
+}
+
+func TestImport_ExistingProject(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	createProject := func(ctx context.Context, item interface{}) (string, error) {
+		return "", fmt.Errorf("project should not be created")
+	}
+	failFolder := func(ctx context.Context, item interface{}) (string, error) {
+		return "", fmt.Errorf("an error")
+	}
+	createScenario := func(ctx context.Context, item interface{}) (string, error) {
+		g.Expect(item.(*scenario.Scenario).ProjectId).To(Equal("existing"), "scenario is not in the existing project")
+		return "scenario", nil
+	}
+	_, err := migration.Import(ctx, "testrail", tree(), "existing", createProject, failFolder, createScenario)
+	g.Expect(err).Should(HaveOccurred(), "folder error did not stop the import")
+}
@@ -8,12 +8,20 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
 	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
 )
 
 //go:generate mockgen -source ./projects.go -destination mocks/projects.go
 
+// FilterKey is the filter used to find the items of a project
+const FilterKey = "projectid"
+
+type itemLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+
 // MetaHandler handles metadata information
 type MetaHandler interface {
 	NewMeta(author string, objType string) (*metadatav1.Identity, error)
@@ -127,3 +135,47 @@ func Update(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context
 		return project, nil
 	}
 }
+
+// FieldSummary returns a function used to count the custom field values used by the scenarios and executions of a project
+func FieldSummary(collection Getter, listScenarios itemLister, listExecutions itemLister) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		project, ok := raw.(*projectv1.Project)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for project %s", id)
+		}
+		filter := map[string][]string{FilterKey: {id}}
+		scenarios, err := listScenarios(ctx, filter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		scenarioFields := make([]map[string]*customfieldv1.Value, 0, len(scenarios))
+		for _, item := range scenarios {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for scenario")
+			}
+			scenarioFields = append(scenarioFields, scenario.CustomFields)
+		}
+		executions, err := listExecutions(ctx, filter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		executionFields := make([]map[string]*customfieldv1.Value, 0, len(executions))
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			executionFields = append(executionFields, execution.CustomFields)
+		}
+		return &customfieldv1.Summary{
+			ProjectId:  id,
+			Scenarios:  customfieldv1.Count(project.ScenarioFields, scenarioFields),
+			Executions: customfieldv1.Count(project.ExecutionFields, executionFields),
+		}, nil
+	}
+}
@@ -12,8 +12,11 @@ import (
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/test/matchers"
 	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	customfield "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
 	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
 	"github.com/curious-kitten/scratch-post/pkg/projects"
 	mockProjects "github.com/curious-kitten/scratch-post/pkg/projects/mocks"
 )
@@ -72,7 +75,7 @@ func TestNew_Create(t *testing.T) {
 		Identity: &identity,
 		Name:     testProject.Name,
 	}
-	g.Expect(scenario).To(Equal(expectedProject), "projects did not match")
+	g.Expect(scenario).To(matchers.EqualProto(expectedProject), "projects did not match")
 }
 
 func TestNew_MarshallError(t *testing.T) {
@@ -255,7 +258,7 @@ func TestUpdate(t *testing.T) {
 		Identity: &identity,
 		Name:     testProject.Name,
 	}
-	g.Expect(createdProject).To(Equal(expectedProject), "projects did not match")
+	g.Expect(createdProject).To(matchers.EqualProto(expectedProject), "projects did not match")
 }
 
 func TestUpdate_ValidationError(t *testing.T) {
@@ -309,3 +312,145 @@ func TestUpdate_UpdateError(t *testing.T) {
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testProject))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
+
+func TestProject_ValidateCustomFields(t *testing.T) {
+	g := NewWithT(t)
+	p := &project.Project{
+		Name: "Test Name",
+		ScenarioFields: []*customfield.Definition{
+			{Name: "priority", Type: customfield.FieldType_Enum},
+		},
+	}
+	err := p.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "enum field without options is not a validation error")
+	p.ScenarioFields[0].Options = []string{"low", "high"}
+	p.ScenarioFields[0].DefaultValues = []string{"medium"}
+	err = p.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "default value outside of the options is not a validation error")
+	p.ScenarioFields[0].DefaultValues = []string{"low"}
+	p.ScenarioFields = append(p.ScenarioFields, &customfield.Definition{Name: "priority"})
+	err = p.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "duplicate field is not a validation error")
+	p.ScenarioFields[1].Name = "component.name"
+	err = p.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "field name containing a dot is not a validation error")
+	p.ScenarioFields[1].Name = "component"
+	p.ExecutionFields = []*customfield.Definition{{Name: "run date", Type: customfield.FieldType_Date, DefaultValues: []string{"01/02/2021"}}}
+	err = p.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid date default is not a validation error")
+	p.ExecutionFields[0].DefaultValues = []string{"2021-02-01"}
+	err = p.Validate()
+	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred for valid custom fields")
+}
+
+func TestProject_ValidateConfigurationDimensions(t *testing.T) {
+	g := NewWithT(t)
+	p := &project.Project{
+		Name: "Test Name",
+		ConfigurationDimensions: []*project.Dimension{
+			{Name: "browser"},
+		},
+	}
+	g.Expect(decoder.IsValidationError(p.Validate())).To(BeTrue(), "dimension without values is not a validation error")
+	p.ConfigurationDimensions[0].Values = []string{"chrome", "chrome"}
+	g.Expect(decoder.IsValidationError(p.Validate())).To(BeTrue(), "duplicate value is not a validation error")
+	p.ConfigurationDimensions[0].Values = []string{"chrome", "firefox"}
+	p.ConfigurationDimensions = append(p.ConfigurationDimensions, &project.Dimension{Name: "browser", Values: []string{"safari"}})
+	g.Expect(decoder.IsValidationError(p.Validate())).To(BeTrue(), "duplicate dimension is not a validation error")
+	p.ConfigurationDimensions[1] = &project.Dimension{Name: "os", Values: []string{"linux", "windows"}}
+	g.Expect(p.Validate()).ShouldNot(HaveOccurred(), "error occurred for valid dimensions")
+
+	g.Expect(p.ValidateMatrix([]*project.Dimension{{Name: "device", Values: []string{"phone"}}})).Should(HaveOccurred(), "dimension outside of the project was accepted")
+	g.Expect(p.ValidateMatrix([]*project.Dimension{{Name: "browser", Values: []string{"safari"}}})).Should(HaveOccurred(), "value outside of the project was accepted")
+	g.Expect(p.ValidateMatrix([]*project.Dimension{{Name: "browser", Values: []string{"firefox"}}})).ShouldNot(HaveOccurred(), "valid matrix was not accepted")
+
+	filtered := p.FilterMatrix([]*project.Dimension{
+		{Name: "browser", Values: []string{"safari", "chrome"}},
+		{Name: "device", Values: []string{"phone"}},
+	})
+	g.Expect(filtered).To(Equal([]*project.Dimension{{Name: "browser", Values: []string{"chrome"}}}), "matrix was not filtered")
+}
+
+func TestCombinations(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(project.Combinations(nil)).To(BeEmpty(), "empty matrix has combinations")
+	combinations := project.Combinations([]*project.Dimension{
+		{Name: "browser", Values: []string{"chrome", "firefox"}},
+		{Name: "os", Values: []string{"linux", "windows"}},
+	})
+	g.Expect(combinations).To(Equal([]map[string]string{
+		{"browser": "chrome", "os": "linux"},
+		{"browser": "chrome", "os": "windows"},
+		{"browser": "firefox", "os": "linux"},
+		{"browser": "firefox", "os": "windows"},
+	}), "combinations did not match")
+}
+
+func TestCustomField_Apply(t *testing.T) {
+	g := NewWithT(t)
+	definitions := []*customfield.Definition{
+		{Name: "priority", Type: customfield.FieldType_Enum, Options: []string{"low", "high"}, DefaultValues: []string{"low"}},
+		{Name: "platforms", Type: customfield.FieldType_MultiSelect, Options: []string{"linux", "windows"}},
+		{Name: "risk", Type: customfield.FieldType_Number, Required: true},
+	}
+	values, err := customfield.Apply(definitions, map[string]*customfield.Value{
+		"risk":      {Values: []string{"3.5"}},
+		"platforms": {Values: []string{"linux", "windows"}},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(values["priority"].Values).To(Equal([]string{"low"}), "default value was not applied")
+	g.Expect(values["platforms"].Values).To(HaveLen(2), "multi select values were not kept")
+
+	_, err = customfield.Apply(definitions, map[string]*customfield.Value{})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "missing required field is not a validation error")
+	_, err = customfield.Apply(definitions, map[string]*customfield.Value{"risk": {Values: []string{"high"}}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "text in number field is not a validation error")
+	_, err = customfield.Apply(definitions, map[string]*customfield.Value{"risk": {Values: []string{"1"}}, "owner": {Values: []string{"me"}}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "undefined field is not a validation error")
+	_, err = customfield.Apply(definitions, map[string]*customfield.Value{"risk": {Values: []string{"1"}}, "priority": {Values: []string{"low", "high"}}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "multiple values for an enum field is not a validation error")
+}
+
+func TestFieldSummary(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockProjects.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&project.Project{})).
+		Do(func(ctx context.Context, id string, p *project.Project) {
+			p.ScenarioFields = []*customfield.Definition{
+				{Name: "priority", Type: customfield.FieldType_Enum, Options: []string{"low", "high"}},
+			}
+			p.ExecutionFields = []*customfield.Definition{{Name: "platform"}}
+		})
+	listScenarios := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{projects.FilterKey: {identity.Id}}), "scenarios were not filtered by project")
+		return []interface{}{
+			&scenario.Scenario{CustomFields: map[string]*customfield.Value{"priority": {Values: []string{"high"}}}},
+			&scenario.Scenario{CustomFields: map[string]*customfield.Value{"priority": {Values: []string{"low"}}}},
+			&scenario.Scenario{CustomFields: map[string]*customfield.Value{"priority": {Values: []string{"high"}}}},
+			&scenario.Scenario{},
+		}, nil
+	}
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		return []interface{}{
+			&execution.Execution{CustomFields: map[string]*customfield.Value{"platform": {Values: []string{"linux"}}}},
+		}, nil
+	}
+	summary, err := projects.FieldSummary(mockGetter, listScenarios, listExecutions)(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	expected := &customfield.Summary{
+		ProjectId: identity.Id,
+		Scenarios: []*customfield.FieldCount{
+			{Field: "priority", Value: "high", Count: 2},
+			{Field: "priority", Value: "low", Count: 1},
+		},
+		Executions: []*customfield.FieldCount{
+			{Field: "platform", Value: "linux", Count: 1},
+		},
+	}
+	g.Expect(summary).To(Equal(expected), "summary did not match")
+}
@@ -0,0 +1,340 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	releasev1 "github.com/curious-kitten/scratch-post/pkg/api/v1/release"
+	requirementv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+)
+
+//go:generate mockgen -source ./releases.go -destination mocks/releases.go
+
+const (
+	// ProjectFilterKey is the filter used to find the releases, requirements and scenarios of a project
+	ProjectFilterKey = "projectid"
+	// ReleaseFilterKey is the filter used to find the test plans and executions of a release
+	ReleaseFilterKey = "releaseid"
+	// VersionFilterKey is the filter used to find releases by version
+	VersionFilterKey = "version"
+)
+
+type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type itemLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+
+// MetaHandler handles metadata information
+type MetaHandler interface {
+	NewMeta(author string, objType string) (*metadatav1.Identity, error)
+	UpdateMeta(author string, identity *metadatav1.Identity)
+}
+
+// Adder is used to add items to the store
+type Adder interface {
+	AddOne(ctx context.Context, item interface{}) error
+}
+
+// Getter is used to retrieve items from the store
+type Getter interface {
+	Get(ctx context.Context, id string, item interface{}) error
+	GetAll(ctx context.Context, items interface{}, filterMap map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) error
+}
+
+// Deleter deletes an entry from the collection
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+// Updater is used to replace information into the Data Base
+type Updater interface {
+	Update(ctx context.Context, id string, item interface{}) error
+}
+
+// ReaderUpdater is used to read and update objects in the Data Base
+type ReaderUpdater interface {
+	Getter
+	Updater
+}
+
+// New returns a function used to create a release
+func New(meta MetaHandler, collection Adder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+		release := &releasev1.Release{}
+		if err := decoder.Decode(release, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, release.ProjectId); err != nil {
+			return nil, err
+		}
+		identity, err := meta.NewMeta(author, "release")
+		if err != nil {
+			return nil, err
+		}
+		release.Identity = identity
+		if err := collection.AddOne(ctx, release); err != nil {
+			return nil, err
+		}
+		return release, nil
+	}
+}
+
+// List returns a function used to return the releases
+func List(collection Getter) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		releases := []releasev1.Release{}
+		err := collection.GetAll(ctx, &releases, filter, sortBy, reverse, count, previousLastValue)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(releases))
+		for i := range releases {
+			items[i] = proto.Clone(&releases[i]).(*releasev1.Release)
+		}
+		return items, nil
+	}
+}
+
+// Get returns a function to retrieve a release based on the passed ID
+func Get(collection Getter) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		release := &releasev1.Release{}
+		if err := collection.Get(ctx, id, release); err != nil {
+			return nil, err
+		}
+		return release, nil
+	}
+}
+
+// Delete returns a function to delete a release based on the passed ID.
+// Only releases that do not have test plans or executions attached can be deleted.
+func Delete(collection Deleter, listTestPlans itemLister, listExecutions itemLister) func(ctx context.Context, id string) error {
+	return func(ctx context.Context, id string) error {
+		filter := map[string][]string{ReleaseFilterKey: {id}}
+		testplans, err := listTestPlans(ctx, filter, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(testplans) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("release '%s' has test plans", id))
+		}
+		executions, err := listExecutions(ctx, filter, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(executions) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("release '%s' has executions", id))
+		}
+		if err := collection.Delete(ctx, id); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// Update is used to replace a release with the provided release
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		release := &releasev1.Release{}
+		if err := decoder.Decode(release, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, release.ProjectId); err != nil {
+			return nil, err
+		}
+		found, err := getRelease(ctx, collection, id)
+		if err != nil {
+			return nil, err
+		}
+		if found.ProjectId != release.ProjectId {
+			return nil, decoder.NewValidationError("a release cannot be moved to another project")
+		}
+		release.Identity = found.Identity
+		meta.UpdateMeta(user, release.Identity)
+		if err := collection.Update(ctx, id, release); err != nil {
+			return nil, err
+		}
+		return release, nil
+	}
+}
+
+// InProject returns a function used to check that a release is part of a project. An empty ID is accepted
+func InProject(collection Getter) func(ctx context.Context, projectID string, id string) error {
+	return func(ctx context.Context, projectID string, id string) error {
+		if id == "" {
+			return nil
+		}
+		release, err := getRelease(ctx, collection, id)
+		if err != nil {
+			return err
+		}
+		if release.ProjectId != projectID {
+			return decoder.NewValidationError(fmt.Sprintf("release '%s' is not part of project '%s'", id, projectID))
+		}
+		return nil
+	}
+}
+
+// TestPlans returns a function used to retrieve the test plans of a release
+func TestPlans(listTestPlans itemLister) func(ctx context.Context, id string) ([]interface{}, error) {
+	return func(ctx context.Context, id string) ([]interface{}, error) {
+		return listTestPlans(ctx, map[string][]string{ReleaseFilterKey: {id}}, "", false, 0, "")
+	}
+}
+
+// Executions returns a function used to retrieve the executions of a release
+func Executions(listExecutions itemLister) func(ctx context.Context, id string) ([]interface{}, error) {
+	return func(ctx context.Context, id string) ([]interface{}, error) {
+		return listExecutions(ctx, map[string][]string{ReleaseFilterKey: {id}}, "", false, 0, "")
+	}
+}
+
+// Readiness returns a function used to report on a release: the results of its executions,
+// the open defects linked to them and the coverage of the requirements of the project.
+// An issue is open when its state is not one of the closed states.
+func Readiness(collection Getter, listTestPlans itemLister, listExecutions itemLister, listRequirements itemLister, listScenarios itemLister, closedStates []string) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		release, err := getRelease(ctx, collection, id)
+		if err != nil {
+			return nil, err
+		}
+		releaseFilter := map[string][]string{ReleaseFilterKey: {id}}
+		projectFilter := map[string][]string{ProjectFilterKey: {release.ProjectId}}
+		testplans, err := listTestPlans(ctx, releaseFilter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		executions, err := listExecutions(ctx, releaseFilter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		requirements, err := listRequirements(ctx, projectFilter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		scenarios, err := listScenarios(ctx, projectFilter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+
+		readiness := &releasev1.Readiness{
+			ReleaseId:   id,
+			Version:     release.Version,
+			Status:      release.Status,
+			ReleaseDate: release.ReleaseDate,
+			TestPlanIds: []string{},
+			Executions:  &releasev1.ExecutionSummary{},
+			OpenDefects: []*releasev1.DefectCount{},
+			Coverage:    &releasev1.CoverageSummary{},
+		}
+		for _, item := range testplans {
+			testplan, ok := item.(*testplanv1.TestPlan)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for test plan")
+			}
+			readiness.TestPlanIds = append(readiness.TestPlanIds, testplan.Identity.GetId())
+		}
+		sort.Strings(readiness.TestPlanIds)
+
+		latest := map[string]*executionv1.Execution{}
+		defects := map[string]metadatav1.Severity{}
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			readiness.Executions.Total++
+			switch execution.Status {
+			case executionv1.Status_Pending:
+				readiness.Executions.Pending++
+			case executionv1.Status_Fail:
+				readiness.Executions.Failed++
+			case executionv1.Status_Pass:
+				readiness.Executions.Passed++
+			case executionv1.Status_Skipped:
+				readiness.Executions.Skipped++
+			}
+			if previous, ok := latest[execution.ScenarioId]; !ok || previous.Identity.GetUpdateTime() <= execution.Identity.GetUpdateTime() {
+				latest[execution.ScenarioId] = execution
+			}
+			issues := append([]*metadatav1.LinkedIssue{}, execution.Issues...)
+			for _, step := range execution.Steps {
+				issues = append(issues, step.Issues...)
+			}
+			for _, issue := range issues {
+				if issue.IssueType == metadatav1.IssueType_DEFECT && issue.IsOpen(closedStates) {
+					defects[issue.Link] = issue.Severity
+				}
+			}
+		}
+
+		counts := map[metadatav1.Severity]int32{}
+		for _, severity := range defects {
+			counts[severity]++
+		}
+		for severity, count := range counts {
+			readiness.OpenDefects = append(readiness.OpenDefects, &releasev1.DefectCount{Severity: severity, Count: count})
+		}
+		sort.Slice(readiness.OpenDefects, func(i, j int) bool {
+			return readiness.OpenDefects[i].Severity > readiness.OpenDefects[j].Severity
+		})
+
+		linked := map[string][]string{}
+		for _, item := range scenarios {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for scenario")
+			}
+			for _, r := range scenario.RequirementIds {
+				linked[r] = append(linked[r], scenario.Identity.GetId())
+			}
+		}
+		for _, item := range requirements {
+			requirement, ok := item.(*requirementv1.Requirement)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for requirement")
+			}
+			readiness.Coverage.Requirements++
+			scenarioIDs := linked[requirement.Identity.GetId()]
+			covered := false
+			passing := len(scenarioIDs) != 0
+			for _, scenarioID := range scenarioIDs {
+				execution, ok := latest[scenarioID]
+				if !ok {
+					passing = false
+					continue
+				}
+				covered = true
+				if execution.Status != executionv1.Status_Pass {
+					passing = false
+				}
+			}
+			if covered {
+				readiness.Coverage.Covered++
+			}
+			if passing {
+				readiness.Coverage.Passing++
+			}
+		}
+		return readiness, nil
+	}
+}
+
+func getRelease(ctx context.Context, collection Getter, id string) (*releasev1.Release, error) {
+	raw, err := Get(collection)(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	release, ok := raw.(*releasev1.Release)
+	if !ok {
+		return nil, fmt.Errorf("invalid DB entry for release %s", id)
+	}
+	return release, nil
+}
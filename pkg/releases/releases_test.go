@@ -0,0 +1,316 @@
+package releases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/test/matchers"
+	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	release "github.com/curious-kitten/scratch-post/pkg/api/v1/release"
+	requirement "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/releases"
+	mockReleases "github.com/curious-kitten/scratch-post/pkg/releases/mocks"
+)
+
+var (
+	identity = metadata.Identity{
+		Id:           "aabbccddee",
+		Type:         "release",
+		Version:      1,
+		CreatedBy:    "author",
+		UpdatedBy:    "author",
+		CreationTime: time.Now().Unix(),
+		UpdateTime:   time.Now().Unix(),
+	}
+
+	testRelease = &release.Release{
+		ProjectId:   "zzxxxccvv",
+		Version:     "1.0.0",
+		StartDate:   "2021-03-01",
+		ReleaseDate: "2021-04-15",
+	}
+)
+
+func goodGetProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, nil
+}
+
+func noItems(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return []interface{}{}, nil
+}
+
+func listOf(items ...interface{}) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		return items, nil
+	}
+}
+
+// expectRelease makes the store return testRelease with the identity
+func expectRelease(ctx context.Context, mockGetter *mockReleases.MockGetter) {
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&release.Release{})).
+		Do(func(ctx context.Context, id string, r *release.Release) {
+			r.Identity = &identity
+			r.ProjectId = testRelease.ProjectId
+			r.Version = testRelease.Version
+			r.ReleaseDate = testRelease.ReleaseDate
+			r.Status = release.Status_InProgress
+		})
+}
+
+func TestRelease_Validate(t *testing.T) {
+	g := NewWithT(t)
+	r := &release.Release{}
+	err := r.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with empty release")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty release error is not a validation error")
+	r.ProjectId = "aabbccdd"
+	r.Version = "1.0.0"
+	g.Expect(r.Validate()).ShouldNot(HaveOccurred(), "error occurred when minimun requirements have been met")
+	r.StartDate = "01/03/2021"
+	g.Expect(decoder.IsValidationError(r.Validate())).To(BeTrue(), "invalid date format was accepted")
+	r.StartDate = "2021-03-01"
+	r.ReleaseDate = "2021-02-01"
+	g.Expect(decoder.IsValidationError(r.Validate())).To(BeTrue(), "release date before start date was accepted")
+	r.ReleaseDate = "2021-04-15"
+	r.Status = 10
+	g.Expect(decoder.IsValidationError(r.Validate())).To(BeTrue(), "unknown status was accepted")
+}
+
+func TestRelease_UniqueKeys(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(&release.Release{ProjectId: "project", Version: "1.0"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the unique index of the versions within a project is built on the filter keys
+	g.Expect(bson.Raw(stored).Lookup(releases.ProjectFilterKey).StringValue()).To(Equal("project"), "project filter key is not the stored field")
+	g.Expect(bson.Raw(stored).Lookup(releases.VersionFilterKey).StringValue()).To(Equal("1.0"), "version filter key is not the stored field")
+}
+
+func TestNew_Create(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockReleases.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "release").
+		Return(&identity, nil)
+	mockAdder := mockReleases.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&release.Release{})).
+		Return(nil)
+
+	creator := releases.New(mockMetaHandler, mockAdder, goodGetProject)
+	created, err := creator(ctx, "tester", transformers.ToReadCloser(testRelease))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.(*release.Release).Identity).To(Equal(&identity), "identity was not set")
+	g.Expect(created.(*release.Release).Version).To(Equal(testRelease.Version), "version was not set")
+}
+
+func TestNew_ProjectNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockReleases.NewMockMetaHandler(ctrl)
+	mockAdder := mockReleases.NewMockAdder(ctrl)
+	noProject := func(ctx context.Context, id string) (interface{}, error) {
+		return nil, mongo.ErrNoDocuments
+	}
+	creator := releases.New(mockMetaHandler, mockAdder, noProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testRelease))
+	g.Expect(err).To(Equal(mongo.ErrNoDocuments), "project error was not returned")
+}
+
+func TestDelete_HasTestPlans(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockDeleter := mockReleases.NewMockDeleter(ctrl)
+	listTestPlans := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{releases.ReleaseFilterKey: {identity.Id}}), "test plans were not filtered by release")
+		return []interface{}{&testplan.TestPlan{}}, nil
+	}
+	err := releases.Delete(mockDeleter, listTestPlans, noItems)(ctx, identity.Id)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release with test plans was deleted")
+}
+
+func TestDelete_HasExecutions(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockDeleter := mockReleases.NewMockDeleter(ctrl)
+	err := releases.Delete(mockDeleter, noItems, listOf(&execution.Execution{}))(ctx, identity.Id)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release with executions was deleted")
+}
+
+func TestDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockDeleter := mockReleases.NewMockDeleter(ctrl)
+	mockDeleter.EXPECT().Delete(ctx, identity.Id).Return(nil)
+	err := releases.Delete(mockDeleter, noItems, noItems)(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+}
+
+func TestUpdate_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockReleases.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&release.Release{})).
+		Do(func(ctx context.Context, id string, r *release.Release) {
+			r.Identity = &identity
+			r.ProjectId = "other project"
+		})
+	mockMetaHandler := mockReleases.NewMockMetaHandler(ctrl)
+	updater := releases.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testRelease))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release was moved to another project")
+}
+
+func TestUpdate(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockReleases.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&release.Release{})).
+		Do(func(ctx context.Context, id string, r *release.Release) {
+			r.Identity = &identity
+			r.ProjectId = testRelease.ProjectId
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&release.Release{})).
+		Return(nil)
+	mockMetaHandler := mockReleases.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", &identity)
+	updater := releases.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updated, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(&release.Release{ProjectId: testRelease.ProjectId, Version: "1.0.1", Status: release.Status_Released}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*release.Release).Identity).To(Equal(&identity), "identity was not kept")
+	g.Expect(updated.(*release.Release).Status).To(Equal(release.Status_Released), "status was not changed")
+}
+
+func TestInProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockReleases.NewMockGetter(ctrl)
+	inProject := releases.InProject(mockGetter)
+	g.Expect(inProject(ctx, "any project", "")).ShouldNot(HaveOccurred(), "empty release was not accepted")
+
+	expectRelease(ctx, mockGetter)
+	g.Expect(inProject(ctx, testRelease.ProjectId, identity.Id)).ShouldNot(HaveOccurred(), "release of the project was not accepted")
+	expectRelease(ctx, mockGetter)
+	err := inProject(ctx, "other project", identity.Id)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release of another project was accepted")
+}
+
+func TestReadiness(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockReleases.NewMockGetter(ctrl)
+	expectRelease(ctx, mockGetter)
+
+	releaseFilter := map[string][]string{releases.ReleaseFilterKey: {identity.Id}}
+	projectFilter := map[string][]string{releases.ProjectFilterKey: {testRelease.ProjectId}}
+	listTestPlans := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(releaseFilter), "test plans were not filtered by release")
+		return []interface{}{
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "second plan"}},
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "first plan"}},
+		}, nil
+	}
+	openDefect := &metadata.LinkedIssue{Link: "defect-1", IssueType: metadata.IssueType_DEFECT, Severity: metadata.Severity_HIGH, State: "Open"}
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(releaseFilter), "executions were not filtered by release")
+		return []interface{}{
+			// login failed first and passed when executed again
+			&execution.Execution{Identity: &metadata.Identity{UpdateTime: 1}, ScenarioId: "login", Status: execution.Status_Fail, Issues: []*metadata.LinkedIssue{openDefect}},
+			&execution.Execution{Identity: &metadata.Identity{UpdateTime: 2}, ScenarioId: "login", Status: execution.Status_Pass},
+			&execution.Execution{Identity: &metadata.Identity{UpdateTime: 1}, ScenarioId: "reset", Status: execution.Status_Fail, Steps: []*execution.StepExecution{
+				{Issues: []*metadata.LinkedIssue{
+					openDefect,
+					{Link: "defect-2", IssueType: metadata.IssueType_DEFECT, Severity: metadata.Severity_LOW, State: "Open"},
+					{Link: "defect-3", IssueType: metadata.IssueType_DEFECT, Severity: metadata.Severity_LOW, State: "Done"},
+					{Link: "story-1", IssueType: metadata.IssueType_STORY, State: "Open"},
+				}},
+			}},
+			&execution.Execution{Identity: &metadata.Identity{UpdateTime: 1}, ScenarioId: "logout", Status: execution.Status_Pending},
+		}, nil
+	}
+	listRequirements := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(projectFilter), "requirements were not filtered by project")
+		return []interface{}{
+			&requirement.Requirement{Identity: &metadata.Identity{Id: "login req"}},
+			&requirement.Requirement{Identity: &metadata.Identity{Id: "reset req"}},
+			&requirement.Requirement{Identity: &metadata.Identity{Id: "profile req"}},
+			&requirement.Requirement{Identity: &metadata.Identity{Id: "uncovered req"}},
+		}, nil
+	}
+	listScenarios := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(projectFilter), "scenarios were not filtered by project")
+		return []interface{}{
+			&scenario.Scenario{Identity: &metadata.Identity{Id: "login"}, RequirementIds: []string{"login req"}},
+			&scenario.Scenario{Identity: &metadata.Identity{Id: "reset"}, RequirementIds: []string{"reset req"}},
+			&scenario.Scenario{Identity: &metadata.Identity{Id: "profile"}, RequirementIds: []string{"profile req"}},
+			&scenario.Scenario{Identity: &metadata.Identity{Id: "logout"}},
+		}, nil
+	}
+
+	readiness := releases.Readiness(mockGetter, listTestPlans, listExecutions, listRequirements, listScenarios, []string{"done"})
+	raw, err := readiness(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	report := raw.(*release.Readiness)
+	g.Expect(report.Version).To(Equal(testRelease.Version), "version was not reported")
+	g.Expect(report.Status).To(Equal(release.Status_InProgress), "status was not reported")
+	g.Expect(report.TestPlanIds).To(Equal([]string{"first plan", "second plan"}), "test plans were not reported")
+	g.Expect(report.Executions).To(Equal(&release.ExecutionSummary{Total: 4, Pending: 1, Failed: 2, Passed: 1}), "executions were not counted")
+	g.Expect(report.OpenDefects).To(Equal([]*release.DefectCount{
+		{Severity: metadata.Severity_HIGH, Count: 1},
+		{Severity: metadata.Severity_LOW, Count: 1},
+	}), "open defects were not counted")
+	g.Expect(report.Coverage).To(Equal(&release.CoverageSummary{Requirements: 4, Covered: 2, Passing: 1}), "coverage was not computed")
+}
+
+func TestReadiness_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockReleases.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&release.Release{})).
+		Return(mongo.ErrNoDocuments)
+	_, err := releases.Readiness(mockGetter, noItems, noItems, noItems, noItems, nil)(ctx, identity.Id)
+	g.Expect(err).To(Equal(mongo.ErrNoDocuments), "missing release error was not returned")
+}
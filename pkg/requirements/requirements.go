@@ -0,0 +1,344 @@
+package requirements
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	requirementv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+//go:generate mockgen -source ./requirements.go -destination mocks/requirements.go
+
+const (
+	// ProjectFilterKey is the filter used to find the requirements, scenarios and executions of a project
+	ProjectFilterKey = "projectid"
+	// ParentFilterKey is the filter used to find the child requirements of a requirement
+	ParentFilterKey = "parentid"
+	// ScenarioFilterKey is the filter used to find the scenarios linked to a requirement
+	ScenarioFilterKey = "requirementids"
+	// KeyFilterKey is the filter used to find requirements by key
+	KeyFilterKey = "key"
+)
+
+type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type itemLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type scenarioLinker func(ctx context.Context, user string, projectID string, requirementID string, ids []string, link bool) ([]interface{}, error)
+
+// MetaHandler handles metadata information
+type MetaHandler interface {
+	NewMeta(author string, objType string) (*metadatav1.Identity, error)
+	UpdateMeta(author string, identity *metadatav1.Identity)
+}
+
+// Adder is used to add items to the store
+type Adder interface {
+	AddOne(ctx context.Context, item interface{}) error
+}
+
+// Getter is used to retrieve items from the store
+type Getter interface {
+	Get(ctx context.Context, id string, item interface{}) error
+	GetAll(ctx context.Context, items interface{}, filterMap map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) error
+}
+
+// Deleter deletes an entry from the collection
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+// Updater is used to replace information into the Data Base
+type Updater interface {
+	Update(ctx context.Context, id string, item interface{}) error
+}
+
+// ReaderAdder is used to read and add objects in the Data Base
+type ReaderAdder interface {
+	Getter
+	Adder
+}
+
+// ReaderUpdater is used to read and update objects in the Data Base
+type ReaderUpdater interface {
+	Getter
+	Updater
+}
+
+// ReaderDeleter is used to read and delete objects in the Data Base
+type ReaderDeleter interface {
+	Getter
+	Deleter
+}
+
+// New returns a function used to create a requirement
+func New(meta MetaHandler, collection ReaderAdder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+		requirement := &requirementv1.Requirement{}
+		if err := decoder.Decode(requirement, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, requirement.ProjectId); err != nil {
+			return nil, err
+		}
+		if err := inProject(ctx, collection, requirement.ProjectId, requirement.ParentId); err != nil {
+			return nil, err
+		}
+		identity, err := meta.NewMeta(author, "requirement")
+		if err != nil {
+			return nil, err
+		}
+		requirement.Identity = identity
+		if err := collection.AddOne(ctx, requirement); err != nil {
+			return nil, err
+		}
+		return requirement, nil
+	}
+}
+
+// List returns a function used to return the requirements
+func List(collection Getter) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		requirements := []requirementv1.Requirement{}
+		err := collection.GetAll(ctx, &requirements, filter, sortBy, reverse, count, previousLastValue)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(requirements))
+		for i := range requirements {
+			items[i] = proto.Clone(&requirements[i]).(*requirementv1.Requirement)
+		}
+		return items, nil
+	}
+}
+
+// Get returns a function to retrieve a requirement based on the passed ID
+func Get(collection Getter) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		requirement := &requirementv1.Requirement{}
+		if err := collection.Get(ctx, id, requirement); err != nil {
+			return nil, err
+		}
+		return requirement, nil
+	}
+}
+
+// Delete returns a function to delete a requirement based on the passed ID.
+// Only requirements that do not have child requirements or linked scenarios can be deleted.
+func Delete(collection ReaderDeleter, listScenarios itemLister) func(ctx context.Context, id string) error {
+	return func(ctx context.Context, id string) error {
+		children, err := List(collection)(ctx, map[string][]string{ParentFilterKey: {id}}, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(children) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("requirement '%s' has child requirements", id))
+		}
+		scenarios, err := listScenarios(ctx, map[string][]string{ScenarioFilterKey: {id}}, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(scenarios) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("requirement '%s' is linked to scenarios", id))
+		}
+		if err := collection.Delete(ctx, id); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// Update is used to replace a requirement with the provided requirement
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		requirement := &requirementv1.Requirement{}
+		if err := decoder.Decode(requirement, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, requirement.ProjectId); err != nil {
+			return nil, err
+		}
+		found, err := getRequirement(ctx, collection, id)
+		if err != nil {
+			return nil, err
+		}
+		if found.ProjectId != requirement.ProjectId {
+			return nil, decoder.NewValidationError("a requirement cannot be moved to another project")
+		}
+		if requirement.ParentId != "" {
+			if err := inProject(ctx, collection, requirement.ProjectId, requirement.ParentId); err != nil {
+				return nil, err
+			}
+			parent := requirement.ParentId
+			for parent != "" {
+				if parent == id {
+					return nil, decoder.NewValidationError("a requirement cannot be a child of itself or of one of its children")
+				}
+				p, err := getRequirement(ctx, collection, parent)
+				if err != nil {
+					return nil, err
+				}
+				parent = p.ParentId
+			}
+		}
+		requirement.Identity = found.Identity
+		meta.UpdateMeta(user, requirement.Identity)
+		if err := collection.Update(ctx, id, requirement); err != nil {
+			return nil, err
+		}
+		return requirement, nil
+	}
+}
+
+// Scenarios returns a function used to retrieve the scenarios linked to a requirement
+func Scenarios(listScenarios itemLister) func(ctx context.Context, id string) ([]interface{}, error) {
+	return func(ctx context.Context, id string) ([]interface{}, error) {
+		return listScenarios(ctx, map[string][]string{ScenarioFilterKey: {id}}, "", false, 0, "")
+	}
+}
+
+// Link returns a function used to link scenarios to a requirement. The linked scenarios are returned
+func Link(collection Getter, linkScenarios scenarioLinker) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return changeLinks(collection, linkScenarios, true)
+}
+
+// Unlink returns a function used to remove the links between scenarios and a requirement. The unlinked scenarios are returned
+func Unlink(collection Getter, linkScenarios scenarioLinker) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return changeLinks(collection, linkScenarios, false)
+}
+
+func changeLinks(collection Getter, linkScenarios scenarioLinker, link bool) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &requirementv1.LinkScenariosRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		requirement, err := getRequirement(ctx, collection, id)
+		if err != nil {
+			return nil, err
+		}
+		return linkScenarios(ctx, user, requirement.ProjectId, id, request.ScenarioIds, link)
+	}
+}
+
+// Coverage returns a function used to report, for each requirement of a project, the linked scenarios
+// and the status of their latest execution in each test plan
+func Coverage(collection Getter, listScenarios itemLister, listExecutions itemLister) func(ctx context.Context, projectID string) (interface{}, error) {
+	return func(ctx context.Context, projectID string) (interface{}, error) {
+		filter := map[string][]string{ProjectFilterKey: {projectID}}
+		requirements, err := List(collection)(ctx, filter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		scenarios, err := listScenarios(ctx, filter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		executions, err := listExecutions(ctx, filter, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+
+		latest := map[string]map[string]*requirementv1.TestPlanStatus{}
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			status := &requirementv1.TestPlanStatus{
+				TestPlanId: execution.TestPlanId,
+				Status:     execution.Status,
+			}
+			if execution.Identity != nil {
+				status.ExecutionId = execution.Identity.Id
+				status.UpdateTime = execution.Identity.UpdateTime
+			}
+			if latest[execution.ScenarioId] == nil {
+				latest[execution.ScenarioId] = map[string]*requirementv1.TestPlanStatus{}
+			}
+			if previous, ok := latest[execution.ScenarioId][execution.TestPlanId]; !ok || previous.UpdateTime <= status.UpdateTime {
+				latest[execution.ScenarioId][execution.TestPlanId] = status
+			}
+		}
+
+		linked := map[string][]*requirementv1.ScenarioCoverage{}
+		for _, item := range scenarios {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for scenario")
+			}
+			if len(scenario.RequirementIds) == 0 {
+				continue
+			}
+			scenarioCoverage := &requirementv1.ScenarioCoverage{
+				ScenarioId: scenario.Identity.Id,
+				Name:       scenario.Name,
+				TestPlans:  []*requirementv1.TestPlanStatus{},
+			}
+			for _, status := range latest[scenario.Identity.Id] {
+				scenarioCoverage.TestPlans = append(scenarioCoverage.TestPlans, status)
+			}
+			sort.Slice(scenarioCoverage.TestPlans, func(i, j int) bool {
+				return scenarioCoverage.TestPlans[i].TestPlanId < scenarioCoverage.TestPlans[j].TestPlanId
+			})
+			for _, r := range scenario.RequirementIds {
+				linked[r] = append(linked[r], scenarioCoverage)
+			}
+		}
+
+		coverage := &requirementv1.Coverage{ProjectId: projectID, Requirements: []*requirementv1.RequirementCoverage{}}
+		for _, item := range requirements {
+			requirement := item.(*requirementv1.Requirement)
+			requirementCoverage := &requirementv1.RequirementCoverage{
+				RequirementId: requirement.Identity.Id,
+				Key:           requirement.Key,
+				Title:         requirement.Title,
+				Covered:       len(linked[requirement.Identity.Id]) != 0,
+				Scenarios:     linked[requirement.Identity.Id],
+			}
+			if requirementCoverage.Covered {
+				coverage.Covered++
+			} else {
+				coverage.Uncovered++
+			}
+			coverage.Requirements = append(coverage.Requirements, requirementCoverage)
+		}
+		sort.SliceStable(coverage.Requirements, func(i, j int) bool {
+			return coverage.Requirements[i].Key < coverage.Requirements[j].Key
+		})
+		return coverage, nil
+	}
+}
+
+func getRequirement(ctx context.Context, collection Getter, id string) (*requirementv1.Requirement, error) {
+	raw, err := Get(collection)(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	requirement, ok := raw.(*requirementv1.Requirement)
+	if !ok {
+		return nil, fmt.Errorf("invalid DB entry for requirement %s", id)
+	}
+	return requirement, nil
+}
+
+// inProject checks that the requirement is part of the project. An empty ID is accepted
+func inProject(ctx context.Context, collection Getter, projectID string, id string) error {
+	if id == "" {
+		return nil
+	}
+	requirement, err := getRequirement(ctx, collection, id)
+	if err != nil {
+		return err
+	}
+	if requirement.ProjectId != projectID {
+		return decoder.NewValidationError(fmt.Sprintf("requirement '%s' is not part of project '%s'", id, projectID))
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package requirements_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/test/matchers"
+	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	requirement "github.com/curious-kitten/scratch-post/pkg/api/v1/requirement"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/requirements"
+	mockRequirements "github.com/curious-kitten/scratch-post/pkg/requirements/mocks"
+)
+
+var (
+	identity = metadata.Identity{
+		Id:           "aabbccddee",
+		Type:         "requirement",
+		Version:      1,
+		CreatedBy:    "author",
+		UpdatedBy:    "author",
+		CreationTime: time.Now().Unix(),
+		UpdateTime:   time.Now().Unix(),
+	}
+
+	testRequirement = &requirement.Requirement{
+		Key:       "REQ-1",
+		Title:     "Users can log in",
+		ProjectId: "zzxxxccvv",
+	}
+
+	// epic -> story, other is a second root requirement
+	projectRequirements = []requirement.Requirement{
+		{Identity: &metadata.Identity{Id: "epic"}, ProjectId: "zzxxxccvv", Key: "REQ-1", Title: "epic"},
+		{Identity: &metadata.Identity{Id: "story"}, ProjectId: "zzxxxccvv", Key: "REQ-2", Title: "story", ParentId: "epic"},
+		{Identity: &metadata.Identity{Id: "other"}, ProjectId: "zzxxxccvv", Key: "REQ-3", Title: "other"},
+	}
+)
+
+func goodGetProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, nil
+}
+
+func noProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, mongo.ErrNoDocuments
+}
+
+func noItems(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return []interface{}{}, nil
+}
+
+func listOf(items ...interface{}) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		return items, nil
+	}
+}
+
+type requirementRecorder interface {
+	Get(ctx, id, item interface{}) *gomock.Call
+	GetAll(ctx, items, filterMap, sortBy, reverse, count, previousLastValue interface{}) *gomock.Call
+}
+
+// expectRequirements makes the store return the requirements in projectRequirements
+func expectRequirements(ctx context.Context, recorder requirementRecorder) {
+	recorder.
+		Get(ctx, gomock.Any(), matchers.OfType(&requirement.Requirement{})).
+		DoAndReturn(func(ctx context.Context, id string, r *requirement.Requirement) error {
+			for i := range projectRequirements {
+				if projectRequirements[i].Identity.Id == id {
+					r.Identity = projectRequirements[i].Identity
+					r.ProjectId = projectRequirements[i].ProjectId
+					r.Key = projectRequirements[i].Key
+					r.Title = projectRequirements[i].Title
+					r.ParentId = projectRequirements[i].ParentId
+					return nil
+				}
+			}
+			return mongo.ErrNoDocuments
+		}).
+		AnyTimes()
+	recorder.
+		GetAll(ctx, matchers.OfType(&[]requirement.Requirement{}), map[string][]string{requirements.ProjectFilterKey: {"zzxxxccvv"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]requirement.Requirement, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, projectRequirements...)
+		}).
+		AnyTimes()
+}
+
+func TestRequirement_Validate(t *testing.T) {
+	g := NewWithT(t)
+	r := &requirement.Requirement{}
+	err := r.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with empty requirement")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty requirement error is not a validation error")
+	r.ProjectId = "aabbccdd"
+	r.Key = "REQ-1"
+	err = r.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with requirement without title")
+	r.Title = "title"
+	err = r.Validate()
+	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred when minimun requirements have been met")
+}
+
+func TestRequirement_UniqueKeys(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(&requirement.Requirement{ProjectId: "project", Key: "REQ-1"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the unique index of the keys within a project is built on the filter keys
+	g.Expect(bson.Raw(stored).Lookup(requirements.ProjectFilterKey).StringValue()).To(Equal("project"), "project filter key is not the stored field")
+	g.Expect(bson.Raw(stored).Lookup(requirements.KeyFilterKey).StringValue()).To(Equal("REQ-1"), "key filter key is not the stored field")
+}
+
+func TestNew_Create(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "requirement").
+		Return(&identity, nil)
+	mockReaderAdder := mockRequirements.NewMockReaderAdder(ctrl)
+	mockReaderAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&requirement.Requirement{})).
+		Return(nil)
+
+	creator := requirements.New(mockMetaHandler, mockReaderAdder, goodGetProject)
+	created, err := creator(ctx, "tester", transformers.ToReadCloser(testRequirement))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.(*requirement.Requirement).Identity).To(Equal(&identity), "identity was not set")
+}
+
+func TestNew_ProjectNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockReaderAdder := mockRequirements.NewMockReaderAdder(ctrl)
+
+	creator := requirements.New(mockMetaHandler, mockReaderAdder, noProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testRequirement))
+	g.Expect(err).Should(HaveOccurred(), "no error when the project does not exist")
+}
+
+func TestNew_ParentInOtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockReaderAdder := mockRequirements.NewMockReaderAdder(ctrl)
+	expectRequirements(ctx, mockReaderAdder.EXPECT())
+
+	r := &requirement.Requirement{Key: "OTHER-1", Title: "t", ProjectId: "another", ParentId: "epic"}
+	creator := requirements.New(mockMetaHandler, mockReaderAdder, goodGetProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(r))
+	g.Expect(err).Should(HaveOccurred(), "no error when the parent is in another project")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "error is not a validation error")
+}
+
+func TestDelete_HasChildren(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockRequirements.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]requirement.Requirement{}), map[string][]string{requirements.ParentFilterKey: {"epic"}}, "", false, 1, "").
+		Do(func(ctx context.Context, items *[]requirement.Requirement, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, projectRequirements[1:2]...)
+		})
+
+	err := requirements.Delete(mockReaderDeleter, noItems)(ctx, "epic")
+	g.Expect(err).Should(HaveOccurred(), "no error when deleting a requirement with children")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "error is not a validation error")
+}
+
+func TestDelete_LinkedScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockRequirements.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]requirement.Requirement{}), map[string][]string{requirements.ParentFilterKey: {"story"}}, "", false, 1, "").
+		Return(nil)
+
+	err := requirements.Delete(mockReaderDeleter, listOf(&scenario.Scenario{}))(ctx, "story")
+	g.Expect(err).Should(HaveOccurred(), "no error when deleting a requirement with linked scenarios")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "error is not a validation error")
+}
+
+func TestDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderDeleter := mockRequirements.NewMockReaderDeleter(ctrl)
+	mockReaderDeleter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]requirement.Requirement{}), map[string][]string{requirements.ParentFilterKey: {"story"}}, "", false, 1, "").
+		Return(nil)
+	mockReaderDeleter.
+		EXPECT().
+		Delete(ctx, "story").
+		Return(nil)
+
+	err := requirements.Delete(mockReaderDeleter, noItems)(ctx, "story")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+}
+
+func TestUpdate_Cycle(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockReaderUpdater := mockRequirements.NewMockReaderUpdater(ctrl)
+	expectRequirements(ctx, mockReaderUpdater.EXPECT())
+
+	r := &requirement.Requirement{Key: "REQ-1", Title: "epic", ProjectId: "zzxxxccvv", ParentId: "story"}
+	_, err := requirements.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)(ctx, "tester", "epic", transformers.ToReadCloser(r))
+	g.Expect(err).Should(HaveOccurred(), "no error when a requirement is moved under its child")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "error is not a validation error")
+}
+
+func TestUpdate_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockReaderUpdater := mockRequirements.NewMockReaderUpdater(ctrl)
+	expectRequirements(ctx, mockReaderUpdater.EXPECT())
+
+	r := &requirement.Requirement{Key: "REQ-3", Title: "other", ProjectId: "another"}
+	_, err := requirements.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)(ctx, "tester", "other", transformers.ToReadCloser(r))
+	g.Expect(err).Should(HaveOccurred(), "no error when a requirement is moved to another project")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "error is not a validation error")
+}
+
+func TestUpdate(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockRequirements.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		UpdateMeta("tester", gomock.Any())
+	mockReaderUpdater := mockRequirements.NewMockReaderUpdater(ctrl)
+	expectRequirements(ctx, mockReaderUpdater.EXPECT())
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, "other", matchers.OfType(&requirement.Requirement{})).
+		Return(nil)
+
+	r := &requirement.Requirement{Key: "REQ-3", Title: "other", ProjectId: "zzxxxccvv", ParentId: "story"}
+	updated, err := requirements.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)(ctx, "tester", "other", transformers.ToReadCloser(r))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*requirement.Requirement).Identity.Id).To(Equal("other"), "identity was not kept")
+	g.Expect(updated.(*requirement.Requirement).ParentId).To(Equal("story"), "parent was not changed")
+}
+
+func TestLink(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockRequirements.NewMockGetter(ctrl)
+	expectRequirements(ctx, mockGetter.EXPECT())
+
+	var linked []string
+	var linking bool
+	linker := func(ctx context.Context, user string, projectID string, requirementID string, ids []string, link bool) ([]interface{}, error) {
+		g.Expect(projectID).To(Equal("zzxxxccvv"), "project of the requirement was not used")
+		g.Expect(requirementID).To(Equal("story"), "wrong requirement was linked")
+		linked = ids
+		linking = link
+		return []interface{}{}, nil
+	}
+	request := &requirement.LinkScenariosRequest{ScenarioIds: []string{"s1", "s2"}}
+	_, err := requirements.Link(mockGetter, linker)(ctx, "tester", "story", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(linked).To(Equal([]string{"s1", "s2"}), "scenarios were not passed")
+	g.Expect(linking).To(BeTrue(), "scenarios were not linked")
+
+	_, err = requirements.Unlink(mockGetter, linker)(ctx, "tester", "story", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(linking).To(BeFalse(), "scenarios were not unlinked")
+}
+
+func TestLink_Empty(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockRequirements.NewMockGetter(ctrl)
+
+	linker := func(ctx context.Context, user string, projectID string, requirementID string, ids []string, link bool) ([]interface{}, error) {
+		t.Fatal("linker should not be called")
+		return nil, nil
+	}
+	var body io.Reader = transformers.ToReadCloser(&requirement.LinkScenariosRequest{})
+	_, err := requirements.Link(mockGetter, linker)(ctx, "tester", "story", body)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error without scenarios")
+}
+
+func TestCoverage(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockRequirements.NewMockGetter(ctrl)
+	expectRequirements(ctx, mockGetter.EXPECT())
+
+	scenarios := listOf(
+		&scenario.Scenario{Identity: &metadata.Identity{Id: "login"}, Name: "login", RequirementIds: []string{"epic", "story"}},
+		&scenario.Scenario{Identity: &metadata.Identity{Id: "logout"}, Name: "logout"},
+	)
+	executions := listOf(
+		&execution.Execution{Identity: &metadata.Identity{Id: "e1", UpdateTime: 1}, ScenarioId: "login", TestPlanId: "tp1", Status: execution.Status_Fail},
+		&execution.Execution{Identity: &metadata.Identity{Id: "e2", UpdateTime: 2}, ScenarioId: "login", TestPlanId: "tp1", Status: execution.Status_Pass},
+		&execution.Execution{Identity: &metadata.Identity{Id: "e3", UpdateTime: 1}, ScenarioId: "login", TestPlanId: "tp2", Status: execution.Status_Pending},
+		&execution.Execution{Identity: &metadata.Identity{Id: "e4", UpdateTime: 1}, ScenarioId: "logout", TestPlanId: "tp1", Status: execution.Status_Pass},
+	)
+
+	raw, err := requirements.Coverage(mockGetter, scenarios, executions)(ctx, "zzxxxccvv")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	coverage := raw.(*requirement.Coverage)
+	g.Expect(coverage.Covered).To(Equal(int32(2)), "wrong number of covered requirements")
+	g.Expect(coverage.Uncovered).To(Equal(int32(1)), "wrong number of uncovered requirements")
+	g.Expect(coverage.Requirements).To(HaveLen(3), "all requirements should be reported")
+	g.Expect(coverage.Requirements[2].Key).To(Equal("REQ-3"), "requirements are not ordered by key")
+	g.Expect(coverage.Requirements[2].Covered).To(BeFalse(), "requirement without scenarios is covered")
+	story := coverage.Requirements[1]
+	g.Expect(story.Scenarios).To(HaveLen(1), "linked scenario missing")
+	g.Expect(story.Scenarios[0].TestPlans).To(HaveLen(2), "test plan statuses missing")
+	g.Expect(story.Scenarios[0].TestPlans[0].ExecutionId).To(Equal("e2"), "latest execution was not used")
+	g.Expect(story.Scenarios[0].TestPlans[0].Status).To(Equal(execution.Status_Pass), "latest status was not used")
+	g.Expect(story.Scenarios[0].TestPlans[1].TestPlanId).To(Equal("tp2"), "test plans are not ordered")
+}
@@ -1,20 +1,62 @@
 package scenarios
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
 	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/gherkin"
+	"github.com/curious-kitten/scratch-post/pkg/spreadsheet"
 )
 
 //go:generate mockgen -source ./scenarios.go -destination mocks/scenarios.go
 
+const (
+	// ProjectFilterKey is the filter used to find the scenarios of a project
+	ProjectFilterKey = "projectid"
+	// NameFilterKey is the filter used to find scenarios by name
+	NameFilterKey = "name"
+	// IssueFilterKey is the filter used to find scenarios by the link of their issues
+	IssueFilterKey = "issues.link"
+	// LabelFilterKey is the filter used to find scenarios by label
+	LabelFilterKey = "labels"
+	// FolderFilterKey is the filter used to find the scenarios in a folder
+	FolderFilterKey = "folderid"
+	// IDFilterKey is the filter used to find scenarios by ID
+	IDFilterKey = "identity.id"
+	// AutomationKeyFilterKey is the filter used to find scenarios by automation key
+	AutomationKeyFilterKey = "automationkeys"
+	// TestPlanFilterKey is the filter used to find the executions of a test plan
+	TestPlanFilterKey = "testplanid"
+	// StateFilterKey is the filter used to find scenarios by state
+	StateFilterKey = "state"
+	// DefaultRuns is the number of runs in which automated scenarios are expected to produce a result
+	DefaultRuns = 5
+)
+
 type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type stepBlockRetriever func(ctx context.Context, projectID string, ids []string) (map[string][]*scenariov1.Step, error)
+type folderChecker func(ctx context.Context, projectID string, id string) error
+type folderDescendants func(ctx context.Context, id string) ([]string, error)
+type folderPaths func(ctx context.Context, projectID string) (map[string]string, error)
+type itemLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type scenarioCopier func(ctx context.Context, user string, projectID string, ids []string, onCollision metadatav1.CollisionStrategy) (*scenariov1.CopyResult, error)
 
 // MetaHandler handles metadata information
 type MetaHandler interface {
@@ -49,14 +91,36 @@ type ReaderUpdater interface {
 	Updater
 }
 
+// ReaderWriter is used to read, add and update objects in the Data Base
+type ReaderWriter interface {
+	Getter
+	Adder
+	Updater
+}
+
+// ReaderWriterDeleter is used to read, add, update and delete objects in the Data Base
+type ReaderWriterDeleter interface {
+	ReaderWriter
+	Deleter
+}
+
 // New returns a function used to create a scenario
-func New(meta MetaHandler, collection Adder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+func New(meta MetaHandler, collection ReaderWriter, getProject projectRetriever, getStepBlocks stepBlockRetriever, inProjectFolder folderChecker) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 		scenario := &scenariov1.Scenario{}
 		if err := decoder.Decode(scenario, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, scenario.ProjectId); err != nil {
+		if err := applyCustomFields(ctx, getProject, scenario); err != nil {
+			return nil, err
+		}
+		if _, err := getStepBlocks(ctx, scenario.ProjectId, scenario.StepBlockIDs()); err != nil {
+			return nil, err
+		}
+		if err := inProjectFolder(ctx, scenario.ProjectId, scenario.FolderId); err != nil {
+			return nil, err
+		}
+		if err := checkAutomationKeys(ctx, collection, scenario.ProjectId, "", scenario.AutomationKeys); err != nil {
 			return nil, err
 		}
 		identity, err := meta.NewMeta(author, "scenario")
@@ -64,6 +128,9 @@ func New(meta MetaHandler, collection Adder, getProject projectRetriever) func(c
 			return nil, err
 		}
 		scenario.Identity = identity
+		scenario.State = scenariov1.State_Draft
+		scenario.Reviews = nil
+		scenario.RequirementIds = nil
 
 		if err := collection.AddOne(ctx, scenario); err != nil {
 			return nil, err
@@ -110,14 +177,21 @@ func Delete(collection Deleter) func(ctx context.Context, id string) error {
 	}
 }
 
-// Update is used to replace a scenario with the provided scenario
-func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+// Update is used to replace a scenario with the provided scenario.
+// The state, reviews and requirements of the scenario are kept, except for approved scenarios which return to Draft.
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever, getStepBlocks stepBlockRetriever, inProjectFolder folderChecker) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
 	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
 		scenario := &scenariov1.Scenario{}
 		if err := decoder.Decode(scenario, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, scenario.ProjectId); err != nil {
+		if err := applyCustomFields(ctx, getProject, scenario); err != nil {
+			return nil, err
+		}
+		if _, err := getStepBlocks(ctx, scenario.ProjectId, scenario.StepBlockIDs()); err != nil {
+			return nil, err
+		}
+		if err := inProjectFolder(ctx, scenario.ProjectId, scenario.FolderId); err != nil {
 			return nil, err
 		}
 		foundScenario, err := Get(collection)(ctx, id)
@@ -129,7 +203,17 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetrie
 		if s, ok = foundScenario.(*scenariov1.Scenario); !ok {
 			return nil, fmt.Errorf("invalid data structure in DB")
 		}
+		if err := checkAutomationKeys(ctx, collection, scenario.ProjectId, s.Identity.GetId(), scenario.AutomationKeys); err != nil {
+			return nil, err
+		}
 		scenario.Identity = s.Identity
+		scenario.Reviews = s.Reviews
+		scenario.RequirementIds = s.RequirementIds
+		scenario.State = s.State
+		if scenario.State == scenariov1.State_Approved {
+			// an edited scenario has to be reviewed again
+			scenario.State = scenariov1.State_Draft
+		}
 		meta.UpdateMeta(user, scenario.Identity)
 		if err := collection.Update(ctx, id, scenario); err != nil {
 			return nil, err
@@ -138,6 +222,994 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetrie
 	}
 }
 
+// MoveToFolder returns a function used to move multiple scenarios of a project to a folder.
+// An empty folder ID moves the scenarios to the root of the project.
+func MoveToFolder(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, projectID string, folderID string, ids []string) ([]interface{}, error) {
+	return func(ctx context.Context, user string, projectID string, folderID string, ids []string) ([]interface{}, error) {
+		scenarios := make([]*scenariov1.Scenario, len(ids))
+		for i, id := range ids {
+			raw, err := Get(collection)(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			scenario, ok := raw.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid data structure in DB")
+			}
+			if scenario.ProjectId != projectID {
+				return nil, decoder.NewValidationError(fmt.Sprintf("scenario '%s' is not part of project '%s'", id, projectID))
+			}
+			scenarios[i] = scenario
+		}
+		moved := make([]interface{}, len(scenarios))
+		for i, scenario := range scenarios {
+			scenario.FolderId = folderID
+			meta.UpdateMeta(user, scenario.Identity)
+			if err := collection.Update(ctx, scenario.Identity.Id, scenario); err != nil {
+				return nil, err
+			}
+			moved[i] = scenario
+		}
+		return moved, nil
+	}
+}
+
+// LinkRequirement returns a function used to link or unlink multiple scenarios of a project and a requirement
+func LinkRequirement(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, projectID string, requirementID string, ids []string, link bool) ([]interface{}, error) {
+	return func(ctx context.Context, user string, projectID string, requirementID string, ids []string, link bool) ([]interface{}, error) {
+		scenarios := make([]*scenariov1.Scenario, len(ids))
+		for i, id := range ids {
+			raw, err := Get(collection)(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			scenario, ok := raw.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid data structure in DB")
+			}
+			if scenario.ProjectId != projectID {
+				return nil, decoder.NewValidationError(fmt.Sprintf("scenario '%s' is not part of project '%s'", id, projectID))
+			}
+			scenarios[i] = scenario
+		}
+		updated := make([]interface{}, len(scenarios))
+		for i, scenario := range scenarios {
+			requirements := []string{}
+			for _, r := range scenario.RequirementIds {
+				if r != requirementID {
+					requirements = append(requirements, r)
+				}
+			}
+			if link {
+				requirements = append(requirements, requirementID)
+			}
+			scenario.RequirementIds = requirements
+			meta.UpdateMeta(user, scenario.Identity)
+			if err := collection.Update(ctx, scenario.Identity.Id, scenario); err != nil {
+				return nil, err
+			}
+			updated[i] = scenario
+		}
+		return updated, nil
+	}
+}
+
+// SetIssueStates returns a function used to change the state of the issues linked to scenarios.
+// The states are mapped by issue link and the number of changed scenarios is returned
+func SetIssueStates(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, states map[string]string) (int, error) {
+	return func(ctx context.Context, user string, states map[string]string) (int, error) {
+		if len(states) == 0 {
+			return 0, nil
+		}
+		links := make([]string, 0, len(states))
+		for link := range states {
+			links = append(links, link)
+		}
+		items, err := List(collection)(ctx, map[string][]string{IssueFilterKey: links}, "", false, 0, "")
+		if err != nil {
+			return 0, err
+		}
+		changed := 0
+		for _, item := range items {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return changed, fmt.Errorf("invalid data structure in DB")
+			}
+			if !metadatav1.SetIssueStates(scenario.Issues, states) {
+				continue
+			}
+			meta.UpdateMeta(user, scenario.Identity)
+			if err := collection.Update(ctx, scenario.Identity.Id, scenario); err != nil {
+				return changed, err
+			}
+			changed++
+		}
+		return changed, nil
+	}
+}
+
+// Transition returns a function used to move a scenario to a new state.
+// The change of state has to be allowed by the transitions of the project.
+func Transition(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.TransitionRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		scenario, ok := raw.(*scenariov1.Scenario)
+		if !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		rawProject, err := getProject(ctx, scenario.ProjectId)
+		if err != nil {
+			return nil, err
+		}
+		project, ok := rawProject.(*projectv1.Project)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for project %s", scenario.ProjectId)
+		}
+		if !scenariov1.CanTransition(project.ScenarioTransitions, scenario.State, request.State) {
+			return nil, decoder.NewValidationError(fmt.Sprintf("scenario cannot be moved from %s to %s", scenario.State, request.State))
+		}
+		scenario.State = request.State
+		meta.UpdateMeta(user, scenario.Identity)
+		if err := collection.Update(ctx, id, scenario); err != nil {
+			return nil, err
+		}
+		return scenario, nil
+	}
+}
+
+// Review returns a function used to review a scenario that is InReview.
+// Approving the scenario moves it to Approved, requesting changes moves it back to Draft.
+func Review(meta MetaHandler, collection ReaderUpdater) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.ReviewRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		scenario, ok := raw.(*scenariov1.Scenario)
+		if !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		if scenario.State != scenariov1.State_InReview {
+			return nil, decoder.NewValidationError(fmt.Sprintf("only scenarios that are %s can be reviewed", scenariov1.State_InReview))
+		}
+		if !scenario.CanReview(user) {
+			return nil, decoder.NewValidationError(fmt.Sprintf("user '%s' is not a reviewer of the scenario", user))
+		}
+		scenario.Reviews = append(scenario.Reviews, &scenariov1.Review{
+			Reviewer: user,
+			Decision: request.Decision,
+			Comment:  request.Comment,
+			Time:     time.Now().Unix(),
+		})
+		if request.Decision == scenariov1.ReviewDecision_Approve {
+			scenario.State = scenariov1.State_Approved
+		} else {
+			scenario.State = scenariov1.State_Draft
+		}
+		meta.UpdateMeta(user, scenario.Identity)
+		if err := collection.Update(ctx, id, scenario); err != nil {
+			return nil, err
+		}
+		return scenario, nil
+	}
+}
+
+// Copy returns a function used to copy scenarios to a project.
+// Copies start as Draft and record the scenario they were copied from. When a scenario is copied to another project,
+// the steps of its step blocks are copied into the scenario, it is placed at the root of the project and only
+// the custom fields defined by the project are kept. Links to requirements are only kept in the same project.
+func Copy(meta MetaHandler, collection ReaderWriter, getProject projectRetriever, getStepBlocks stepBlockRetriever) func(ctx context.Context, user string, projectID string, ids []string, onCollision metadatav1.CollisionStrategy) (*scenariov1.CopyResult, error) {
+	return func(ctx context.Context, user string, projectID string, ids []string, onCollision metadatav1.CollisionStrategy) (*scenariov1.CopyResult, error) {
+		raw, err := getProject(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		project, ok := raw.(*projectv1.Project)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for project %s", projectID)
+		}
+		clones := make([]*scenariov1.Scenario, len(ids))
+		for i, id := range ids {
+			raw, err := Get(collection)(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			source, ok := raw.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid data structure in DB")
+			}
+			clone := proto.Clone(source).(*scenariov1.Scenario)
+			if source.ProjectId != projectID {
+				blocks, err := getStepBlocks(ctx, source.ProjectId, source.StepBlockIDs())
+				if err != nil {
+					return nil, err
+				}
+				if err := clone.InlineStepBlocks(blocks); err != nil {
+					return nil, err
+				}
+				clone.ProjectId = projectID
+				clone.FolderId = ""
+				clone.RequirementIds = nil
+				for name := range clone.CustomFields {
+					if !isDefined(project.ScenarioFields, name) {
+						delete(clone.CustomFields, name)
+					}
+				}
+			}
+			if clone.CustomFields, err = customfieldv1.Apply(project.ScenarioFields, clone.CustomFields); err != nil {
+				return nil, err
+			}
+			clone.State = scenariov1.State_Draft
+			clone.Reviews = nil
+			clone.CopiedFrom = &metadatav1.Provenance{
+				SourceId:        source.Identity.Id,
+				SourceProjectId: source.ProjectId,
+				CopiedBy:        user,
+				Time:            time.Now().Unix(),
+			}
+			clones[i] = clone
+		}
+		result := &scenariov1.CopyResult{}
+		claimed := map[string]string{}
+		for _, clone := range clones {
+			existing, err := findByName(ctx, collection, projectID, clone.Name)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil && onCollision == metadatav1.CollisionStrategy_OVERWRITE {
+				clone.AutomationKeys, err = freeAutomationKeys(ctx, collection, projectID, existing.Identity.Id, clone.AutomationKeys, claimed)
+			} else {
+				clone.AutomationKeys, err = freeAutomationKeys(ctx, collection, projectID, "", clone.AutomationKeys, claimed)
+			}
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case existing == nil:
+			case onCollision == metadatav1.CollisionStrategy_SKIP:
+				result.Skipped = append(result.Skipped, clone.CopiedFrom.SourceId)
+				continue
+			case onCollision == metadatav1.CollisionStrategy_OVERWRITE:
+				if existing.Identity.Id == clone.CopiedFrom.SourceId {
+					return nil, decoder.NewValidationError(fmt.Sprintf("scenario '%s' cannot overwrite itself", existing.Identity.Id))
+				}
+				clone.Identity = existing.Identity
+				meta.UpdateMeta(user, clone.Identity)
+				if err := collection.Update(ctx, clone.Identity.Id, clone); err != nil {
+					return nil, err
+				}
+				result.Copied = append(result.Copied, clone)
+				continue
+			default:
+				if clone.Name, err = freeName(ctx, collection, projectID, clone.Name); err != nil {
+					return nil, err
+				}
+			}
+			if clone.Identity, err = meta.NewMeta(user, "scenario"); err != nil {
+				return nil, err
+			}
+			if err := collection.AddOne(ctx, clone); err != nil {
+				return nil, err
+			}
+			result.Copied = append(result.Copied, clone)
+		}
+		return result, nil
+	}
+}
+
+// CopySelection returns a function used to copy the scenarios listed in a copy request
+func CopySelection(copyScenarios scenarioCopier) func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.CopyRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		if len(request.ScenarioIds) == 0 {
+			return nil, decoder.NewValidationError("at least one scenario has to be copied")
+		}
+		return copyScenarios(ctx, user, request.ProjectId, request.ScenarioIds, request.OnCollision)
+	}
+}
+
+// CopyOne returns a function used to copy a single scenario
+func CopyOne(copyScenarios scenarioCopier) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.CopyRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		if len(request.ScenarioIds) != 0 {
+			return nil, decoder.NewValidationError("scenarioIds cannot be used when copying a single scenario")
+		}
+		return copyScenarios(ctx, user, request.ProjectId, []string{id}, request.OnCollision)
+	}
+}
+
+// ImportGherkin returns a function used to import the scenarios of a Gherkin feature file, see gherkin.Parse for the mapping.
+// Imported scenarios are identified by the feature path and their name. Scenarios that were already imported are skipped,
+// unless the request asks for an update. An update replaces the description, prerequisites, steps, labels and parameters
+// and keeps everything else. Scenarios with the same name that were not imported from the feature file are never changed.
+func ImportGherkin(meta MetaHandler, collection ReaderWriter, getProject projectRetriever, inProjectFolder folderChecker) func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.GherkinImportRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		if err := inProjectFolder(ctx, request.ProjectId, request.FolderId); err != nil {
+			return nil, err
+		}
+		imported, err := gherkin.Parse(request.FeaturePath, strings.NewReader(request.Content))
+		if err != nil {
+			return nil, err
+		}
+		if len(imported) == 0 {
+			return nil, decoder.NewValidationError(fmt.Sprintf("feature file '%s' has no scenarios", request.FeaturePath))
+		}
+		for _, scenario := range imported {
+			scenario.ProjectId = request.ProjectId
+			scenario.FolderId = request.FolderId
+			if err := applyCustomFields(ctx, getProject, scenario); err != nil {
+				return nil, err
+			}
+		}
+
+		result := &scenariov1.ImportResult{}
+		claimed := map[string]string{}
+		for _, scenario := range imported {
+			existing, byID, err := findImported(ctx, collection, scenario)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				if scenario.Identity, err = meta.NewMeta(user, "scenario"); err != nil {
+					return nil, err
+				}
+				if scenario.AutomationKeys, err = freeAutomationKeys(ctx, collection, scenario.ProjectId, scenario.Identity.Id, scenario.AutomationKeys, claimed); err != nil {
+					return nil, err
+				}
+				scenario.State = scenariov1.State_Draft
+				if err := collection.AddOne(ctx, scenario); err != nil {
+					return nil, err
+				}
+				result.Created = append(result.Created, scenario)
+				continue
+			}
+			if !request.Update || (!byID && existing.FeaturePath != request.FeaturePath) {
+				result.Skipped = append(result.Skipped, scenario.Name)
+				continue
+			}
+			updated := proto.Clone(existing).(*scenariov1.Scenario)
+			updated.Name = scenario.Name
+			updated.FeaturePath = scenario.FeaturePath
+			updated.Description = scenario.Description
+			updated.Prerequisites = scenario.Prerequisites
+			updated.Steps = scenario.Steps
+			updated.Labels = scenario.Labels
+			updated.Parameters = scenario.Parameters
+			edited := !proto.Equal(updated, existing)
+			if updated.AutomationKeys, err = freeAutomationKeys(ctx, collection, existing.ProjectId, existing.Identity.Id, featureKeys(existing, scenario), claimed); err != nil {
+				return nil, err
+			}
+			if !edited && proto.Equal(updated, existing) {
+				result.Skipped = append(result.Skipped, scenario.Name)
+				continue
+			}
+			if edited && updated.State == scenariov1.State_Approved {
+				// an edited scenario has to be reviewed again
+				updated.State = scenariov1.State_Draft
+			}
+			meta.UpdateMeta(user, updated.Identity)
+			if err := collection.Update(ctx, updated.Identity.Id, updated); err != nil {
+				return nil, err
+			}
+			result.Updated = append(result.Updated, updated)
+		}
+		return result, nil
+	}
+}
+
+// featureKeys returns the automation keys of the existing scenario followed by the ones of the scenario imported from a feature file.
+// The key of the feature file the existing scenario was imported from is dropped when the imported scenario does not have it.
+func featureKeys(existing *scenariov1.Scenario, imported *scenariov1.Scenario) []string {
+	keys := map[string]bool{}
+	for _, key := range imported.AutomationKeys {
+		keys[key] = true
+	}
+	stale := ""
+	if existing.FeaturePath != "" {
+		stale = gherkin.AutomationKey(existing.FeaturePath, existing.Name)
+	}
+	result := []string{}
+	for _, key := range existing.AutomationKeys {
+		if key == stale && !keys[key] {
+			continue
+		}
+		result = append(result, key)
+		delete(keys, key)
+	}
+	for _, key := range imported.AutomationKeys {
+		if keys[key] {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// ExportGherkin returns a function used to export the scenarios of a project as a zip of Gherkin feature files.
+// The filter contains the projectId, optionally the labels the scenarios need to have one of and the folderId the scenarios are in, including its subfolders.
+//
+// The scenarios imported from a feature file are exported to the same path, the other ones to a file named after their folder or after the project.
+func ExportGherkin(collection Getter, getProject projectRetriever, getStepBlocks stepBlockRetriever, descendants folderDescendants, paths folderPaths) func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+	return func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+		project, scenarios, folders, err := exported(ctx, filter, collection, getProject, getStepBlocks, descendants, paths)
+		if err != nil {
+			return "", nil, err
+		}
+		features := map[string][]*scenariov1.Scenario{}
+		for _, scenario := range scenarios {
+			file := project.Name + ".feature"
+			switch {
+			case scenario.FeaturePath != "":
+				file = scenario.FeaturePath
+			case folders[scenario.FolderId] != "":
+				file = folders[scenario.FolderId] + ".feature"
+			}
+			file = strings.TrimLeft(path.Clean("/"+file), "/")
+			features[file] = append(features[file], scenario)
+		}
+		files := make([]string, 0, len(features))
+		for file := range features {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+
+		archive := &bytes.Buffer{}
+		writer := zip.NewWriter(archive)
+		for _, file := range files {
+			w, err := writer.Create(file)
+			if err != nil {
+				return "", nil, err
+			}
+			if err := gherkin.Render(w, strings.TrimSuffix(path.Base(file), ".feature"), features[file]); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return "", nil, err
+		}
+		return strings.ReplaceAll(project.Name, "/", "-") + ".zip", archive.Bytes(), nil
+	}
+}
+
+// ExportCSV returns a function used to export the scenarios of a project as a CSV file, see spreadsheet.WriteScenarios for the columns.
+// The filter is the one of ExportGherkin, with an optional layout: `scenario` for one row per scenario, the default, or `step` for one row per step.
+func ExportCSV(collection Getter, getProject projectRetriever, getStepBlocks stepBlockRetriever, descendants folderDescendants, paths folderPaths) func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+	return func(ctx context.Context, filter map[string][]string) (string, []byte, error) {
+		stepRows := false
+		query := map[string][]string{}
+		for key, values := range filter {
+			if key != "layout" {
+				query[key] = values
+				continue
+			}
+			switch strings.Join(values, ",") {
+			case "scenario":
+			case "step":
+				stepRows = true
+			default:
+				return "", nil, decoder.NewValidationError("layout has to be either 'scenario' or 'step'")
+			}
+		}
+		project, scenarios, folders, err := exported(ctx, query, collection, getProject, getStepBlocks, descendants, paths)
+		if err != nil {
+			return "", nil, err
+		}
+		fields := make([]string, 0, len(project.ScenarioFields))
+		for _, definition := range project.ScenarioFields {
+			fields = append(fields, definition.Name)
+		}
+		file := &bytes.Buffer{}
+		if err := spreadsheet.WriteScenarios(file, scenarios, folders, fields, stepRows); err != nil {
+			return "", nil, err
+		}
+		return strings.ReplaceAll(project.Name, "/", "-") + ".csv", file.Bytes(), nil
+	}
+}
+
+// ImportCSV returns a function used to create or update the scenarios of a project from the rows of a CSV file, see spreadsheet.Read for the format.
+// The scenarios are identified by their name within the project: new scenarios are created as Draft and the existing ones only get the values of the mapped columns.
+// The steps of the step blocks are inlined in the exported files, the existing scenarios keep their step blocks when the inlined steps are not edited.
+// Every scenario of the file is validated and reported on; the invalid ones are not imported and a dry run does not change anything.
+func ImportCSV(meta MetaHandler, collection ReaderWriter, getProject projectRetriever, getStepBlocks stepBlockRetriever, inProjectFolder folderChecker) func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, data io.Reader) (interface{}, error) {
+		request := &scenariov1.CSVImportRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		if err := inProjectFolder(ctx, request.ProjectId, request.FolderId); err != nil {
+			return nil, err
+		}
+		raw, err := getProject(ctx, request.ProjectId)
+		if err != nil {
+			return nil, err
+		}
+		project, ok := raw.(*projectv1.Project)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for project %s", request.ProjectId)
+		}
+		records, fields, err := spreadsheet.Read(strings.NewReader(request.Content), request.Mapping, project.ScenarioFields)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, decoder.NewValidationError("the CSV file has no scenarios")
+		}
+
+		result := &scenariov1.ImportResult{}
+		claimed := map[string]string{}
+		created := map[string]int32{}
+		for _, record := range records {
+			report := &scenariov1.RowReport{Row: int32(record.Row), Name: record.Scenario.Name, Errors: record.Errors}
+			result.Rows = append(result.Rows, report)
+			scenario := record.Scenario
+			scenario.ProjectId = request.ProjectId
+			var existing *scenariov1.Scenario
+			if scenario.Name != "" {
+				if existing, err = findByName(ctx, collection, request.ProjectId, scenario.Name); err != nil {
+					return nil, err
+				}
+			}
+			if existing != nil {
+				blocks := map[string][]*scenariov1.Step{}
+				if ids := existing.StepBlockIDs(); len(ids) != 0 {
+					if blocks, err = getStepBlocks(ctx, request.ProjectId, ids); err != nil {
+						return nil, err
+					}
+				}
+				scenario = proto.Clone(existing).(*scenariov1.Scenario)
+				spreadsheet.Merge(fields, record.Scenario, scenario, blocks)
+			} else {
+				scenario.FolderId = request.FolderId
+				if row, ok := created[scenario.Name]; ok && len(report.Errors) == 0 {
+					// the scenarios are not created by a dry run, so the earlier rows do not make the name taken
+					report.Errors = append(report.Errors, fmt.Sprintf("scenario '%s' is already created on row %d", scenario.Name, row))
+				}
+				created[scenario.Name] = report.Row
+			}
+			if err := scenario.Validate(); err != nil {
+				report.Errors = append(report.Errors, err.Error())
+			}
+			used, err := usedAutomationKeys(ctx, collection, request.ProjectId, existing.GetIdentity().GetId(), scenario.AutomationKeys)
+			if err != nil {
+				return nil, err
+			}
+			for _, key := range scenario.AutomationKeys {
+				if name, ok := claimed[key]; ok && name != scenario.Name {
+					used[key] = name
+				}
+				claimed[key] = scenario.Name
+			}
+			for _, key := range scenario.AutomationKeys {
+				if name, ok := used[key]; ok {
+					report.Errors = append(report.Errors, fmt.Sprintf("automation key '%s' is already used by scenario '%s'", key, name))
+				}
+			}
+			if scenario.CustomFields, err = customfieldv1.Apply(project.ScenarioFields, scenario.CustomFields); err != nil {
+				if !decoder.IsValidationError(err) {
+					return nil, err
+				}
+				report.Errors = append(report.Errors, err.Error())
+			}
+			switch {
+			case len(report.Errors) != 0:
+				report.Outcome = scenariov1.RowOutcome_Invalid
+			case existing == nil:
+				report.Outcome = scenariov1.RowOutcome_Create
+				scenario.State = scenariov1.State_Draft
+				if !request.DryRun {
+					if scenario.Identity, err = meta.NewMeta(user, "scenario"); err != nil {
+						return nil, err
+					}
+					if err := collection.AddOne(ctx, scenario); err != nil {
+						return nil, err
+					}
+				}
+				result.Created = append(result.Created, scenario)
+			case proto.Equal(scenario, existing):
+				report.Outcome = scenariov1.RowOutcome_Unchanged
+				result.Skipped = append(result.Skipped, scenario.Name)
+			default:
+				report.Outcome = scenariov1.RowOutcome_Update
+				if scenario.State == scenariov1.State_Approved {
+					// an edited scenario has to be reviewed again
+					scenario.State = scenariov1.State_Draft
+				}
+				if !request.DryRun {
+					meta.UpdateMeta(user, scenario.Identity)
+					if err := collection.Update(ctx, scenario.Identity.Id, scenario); err != nil {
+						return nil, err
+					}
+				}
+				result.Updated = append(result.Updated, scenario)
+			}
+		}
+		return result, nil
+	}
+}
+
+// exported returns the project, the scenarios, with their step blocks inlined, and the folder paths used by the exports
+func exported(ctx context.Context, filter map[string][]string, collection Getter, getProject projectRetriever, getStepBlocks stepBlockRetriever, descendants folderDescendants, paths folderPaths) (*projectv1.Project, []*scenariov1.Scenario, map[string]string, error) {
+	query := map[string][]string{}
+	for key, values := range filter {
+		switch key {
+		case "projectId":
+			query[ProjectFilterKey] = values
+		case "label":
+			query[LabelFilterKey] = values
+		case "folderId":
+			if len(values) != 1 {
+				return nil, nil, nil, decoder.NewValidationError("only one folderId can be exported")
+			}
+			ids, err := descendants(ctx, values[0])
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			query[FolderFilterKey] = ids
+		default:
+			return nil, nil, nil, decoder.NewValidationError(fmt.Sprintf("scenarios cannot be exported by '%s'", key))
+		}
+	}
+	if len(query[ProjectFilterKey]) != 1 {
+		return nil, nil, nil, decoder.NewValidationError("projectId is a mandatory parameter")
+	}
+	projectID := query[ProjectFilterKey][0]
+	raw, err := getProject(ctx, projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	project, ok := raw.(*projectv1.Project)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("invalid DB entry for project %s", projectID)
+	}
+	folders, err := paths(ctx, projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	items, err := List(collection)(ctx, query, NameFilterKey, false, 0, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	blockIDs := []string{}
+	for _, item := range items {
+		blockIDs = append(blockIDs, item.(*scenariov1.Scenario).StepBlockIDs()...)
+	}
+	blocks := map[string][]*scenariov1.Step{}
+	if len(blockIDs) != 0 {
+		if blocks, err = getStepBlocks(ctx, projectID, blockIDs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	scenarios := make([]*scenariov1.Scenario, 0, len(items))
+	for _, item := range items {
+		scenario := item.(*scenariov1.Scenario)
+		if err := scenario.InlineStepBlocks(blocks); err != nil {
+			return nil, nil, nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return project, scenarios, folders, nil
+}
+
+// Automated returns a function used to find the scenario of a project that has an automation key.
+// The returned scenario is nil when no scenario has the key.
+func Automated(collection Getter) func(ctx context.Context, projectID string, key string) (*scenariov1.Scenario, error) {
+	return func(ctx context.Context, projectID string, key string) (*scenariov1.Scenario, error) {
+		return findAutomated(ctx, collection, projectID, key)
+	}
+}
+
+// CreateAutomated returns a function used to create an automated Draft scenario for each automation key of a project.
+// A scenario is named after its key, or after the key and its ID when the name is already used in the project.
+// All the scenarios are prepared before they are stored and the stored ones are removed when one of them cannot be stored.
+func CreateAutomated(meta MetaHandler, collection ReaderWriterDeleter, getProject projectRetriever) func(ctx context.Context, user string, projectID string, keys []string) ([]*scenariov1.Scenario, error) {
+	return func(ctx context.Context, user string, projectID string, keys []string) ([]*scenariov1.Scenario, error) {
+		created := []*scenariov1.Scenario{}
+		for _, key := range keys {
+			scenario := &scenariov1.Scenario{
+				ProjectId:      projectID,
+				Name:           key,
+				Automated:      true,
+				AutomationKeys: []string{key},
+				State:          scenariov1.State_Draft,
+			}
+			if err := applyCustomFields(ctx, getProject, scenario); err != nil {
+				return nil, err
+			}
+			if err := scenario.Validate(); err != nil {
+				return nil, err
+			}
+			var err error
+			if scenario.Identity, err = meta.NewMeta(user, "scenario"); err != nil {
+				return nil, err
+			}
+			existing, err := findByName(ctx, collection, projectID, key)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				scenario.Name = fmt.Sprintf("%s (%s)", key, scenario.Identity.Id)
+			}
+			created = append(created, scenario)
+		}
+		for i, scenario := range created {
+			if err := collection.AddOne(ctx, scenario); err != nil {
+				for _, stored := range created[:i] {
+					_ = collection.Delete(ctx, stored.Identity.Id)
+				}
+				return nil, err
+			}
+		}
+		return created, nil
+	}
+}
+
+// ByAutomationKey returns a function used to find the scenario of a project that has an automation key.
+// The filter contains the projectId and the key, both are mandatory.
+func ByAutomationKey(collection Getter) func(ctx context.Context, filter map[string][]string) (interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string) (interface{}, error) {
+		if len(filter["projectId"]) != 1 || len(filter["key"]) != 1 {
+			return nil, decoder.NewValidationError("projectId and key are mandatory")
+		}
+		scenario, err := findAutomated(ctx, collection, filter["projectId"][0], filter["key"][0])
+		if err != nil {
+			return nil, err
+		}
+		if scenario == nil {
+			return nil, nil
+		}
+		return scenario, nil
+	}
+}
+
+// Unreported returns a function used to list the automated scenarios of a project that did not produce a result in the last runs of the project, by name.
+// A run is a test plan of the project, the most recently created ones are used. The filter contains the projectId, which is mandatory,
+// and the number of runs, which defaults to DefaultRuns. Scenarios are automated when they are flagged as automated or have automation keys.
+// The scenarios are sorted by name and count limits their number, the other list parameters are ignored.
+func Unreported(collection Getter, listTestPlans itemLister, listExecutions itemLister) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		if len(filter["projectId"]) != 1 {
+			return nil, decoder.NewValidationError("projectId is mandatory")
+		}
+		projectID := filter["projectId"][0]
+		runs := DefaultRuns
+		if values := filter["runs"]; len(values) != 0 {
+			var err error
+			if runs, err = strconv.Atoi(values[0]); err != nil || runs < 1 {
+				return nil, decoder.NewValidationError("runs has to be a positive number")
+			}
+		}
+		items, err := listTestPlans(ctx, map[string][]string{ProjectFilterKey: {projectID}}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		testplans := make([]*testplanv1.TestPlan, 0, len(items))
+		for _, item := range items {
+			testplan, ok := item.(*testplanv1.TestPlan)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for test plan")
+			}
+			testplans = append(testplans, testplan)
+		}
+		sort.SliceStable(testplans, func(i, j int) bool {
+			return testplans[i].Identity.GetCreationTime() > testplans[j].Identity.GetCreationTime()
+		})
+		if len(testplans) > runs {
+			testplans = testplans[:runs]
+		}
+
+		reported := map[string]bool{}
+		if len(testplans) != 0 {
+			ids := make([]string, 0, len(testplans))
+			for _, testplan := range testplans {
+				ids = append(ids, testplan.Identity.GetId())
+			}
+			executions, err := listExecutions(ctx, map[string][]string{TestPlanFilterKey: ids}, "", false, 0, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range executions {
+				execution, ok := item.(*executionv1.Execution)
+				if !ok {
+					return nil, fmt.Errorf("invalid DB entry for execution")
+				}
+				if execution.Status != executionv1.Status_Pending {
+					reported[execution.ScenarioId] = true
+				}
+			}
+		}
+		scenarios, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}}, NameFilterKey, false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		unreported := []interface{}{}
+		for _, item := range scenarios {
+			scenario, ok := item.(*scenariov1.Scenario)
+			if !ok {
+				return nil, fmt.Errorf("invalid data structure in DB")
+			}
+			if (scenario.Automated || len(scenario.AutomationKeys) != 0) && !reported[scenario.Identity.GetId()] {
+				unreported = append(unreported, scenario)
+			}
+			if count > 0 && len(unreported) == count {
+				break
+			}
+		}
+		return unreported, nil
+	}
+}
+
+// findAutomated returns the scenario of the project that has the automation key, or nil when there is none
+func findAutomated(ctx context.Context, collection Getter, projectID string, key string) (*scenariov1.Scenario, error) {
+	found, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}, AutomationKeyFilterKey: {key}}, "", false, 1, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	scenario, ok := found[0].(*scenariov1.Scenario)
+	if !ok {
+		return nil, fmt.Errorf("invalid data structure in DB")
+	}
+	return scenario, nil
+}
+
+// usedAutomationKeys returns the automation keys that are used by other scenarios of the project than the one with the ID, with the names of these scenarios
+func usedAutomationKeys(ctx context.Context, collection Getter, projectID string, id string, keys []string) (map[string]string, error) {
+	used := map[string]string{}
+	if len(keys) == 0 {
+		return used, nil
+	}
+	found, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}, AutomationKeyFilterKey: keys}, "", false, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range found {
+		scenario, ok := item.(*scenariov1.Scenario)
+		if !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		if scenario.Identity.GetId() == id {
+			continue
+		}
+		for _, key := range scenario.AutomationKeys {
+			used[key] = scenario.Name
+		}
+	}
+	return used, nil
+}
+
+// checkAutomationKeys returns a validation error when one of the automation keys is used by another scenario of the project than the one with the ID
+func checkAutomationKeys(ctx context.Context, collection Getter, projectID string, id string, keys []string) error {
+	used, err := usedAutomationKeys(ctx, collection, projectID, id, keys)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if name, ok := used[key]; ok {
+			return decoder.NewValidationError(fmt.Sprintf("automation key '%s' is already used by scenario '%s'", key, name))
+		}
+	}
+	return nil
+}
+
+// freeAutomationKeys returns the automation keys that are neither used by another scenario of the project than the one with the ID, nor claimed
+// by the scenarios handled before. The returned keys are claimed for the scenario.
+func freeAutomationKeys(ctx context.Context, collection Getter, projectID string, id string, keys []string, claimed map[string]string) ([]string, error) {
+	used, err := usedAutomationKeys(ctx, collection, projectID, id, keys)
+	if err != nil {
+		return nil, err
+	}
+	free := []string{}
+	for _, key := range keys {
+		if _, ok := used[key]; ok {
+			continue
+		}
+		if _, ok := claimed[key]; ok {
+			continue
+		}
+		claimed[key] = id
+		free = append(free, key)
+	}
+	if len(free) == 0 {
+		return nil, nil
+	}
+	return free, nil
+}
+
+// findImported returns the scenario with the ID of the imported scenario, if it was exported from the same project, or the scenario with the same name
+func findImported(ctx context.Context, collection Getter, imported *scenariov1.Scenario) (*scenariov1.Scenario, bool, error) {
+	if imported.Identity != nil {
+		found, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {imported.ProjectId}, IDFilterKey: {imported.Identity.Id}}, "", false, 1, "")
+		if err != nil {
+			return nil, false, err
+		}
+		if len(found) != 0 {
+			existing, ok := found[0].(*scenariov1.Scenario)
+			if !ok {
+				return nil, false, fmt.Errorf("invalid data structure in DB")
+			}
+			return existing, true, nil
+		}
+	}
+	existing, err := findByName(ctx, collection, imported.ProjectId, imported.Name)
+	return existing, false, err
+}
+
+func findByName(ctx context.Context, collection Getter, projectID string, name string) (*scenariov1.Scenario, error) {
+	found, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}, NameFilterKey: {name}}, "", false, 1, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	scenario, ok := found[0].(*scenariov1.Scenario)
+	if !ok {
+		return nil, fmt.Errorf("invalid data structure in DB")
+	}
+	return scenario, nil
+}
+
+// freeName returns the first copy name that is not used in the project
+func freeName(ctx context.Context, collection Getter, projectID string, name string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := metadatav1.CopyName(name, n)
+		existing, err := findByName(ctx, collection, projectID, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+}
+
+func isDefined(definitions []*customfieldv1.Definition, name string) bool {
+	for _, d := range definitions {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyCustomFields validates the custom fields of the scenario against the ones defined by its project
+func applyCustomFields(ctx context.Context, getProject projectRetriever, scenario *scenariov1.Scenario) error {
+	raw, err := getProject(ctx, scenario.ProjectId)
+	if err != nil {
+		return err
+	}
+	project, ok := raw.(*projectv1.Project)
+	if !ok {
+		return fmt.Errorf("invalid DB entry for project %s", scenario.ProjectId)
+	}
+	scenario.CustomFields, err = customfieldv1.Apply(project.ScenarioFields, scenario.CustomFields)
+	return err
+}
+
 // func mangeFilters(filters map[string][]string) {
 
 // }
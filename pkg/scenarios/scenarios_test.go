@@ -1,20 +1,29 @@
 package scenarios_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/test/matchers"
 	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	customfield "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
 	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
 	"github.com/curious-kitten/scratch-post/pkg/scenarios"
 	mockScenarios "github.com/curious-kitten/scratch-post/pkg/scenarios/mocks"
 )
@@ -41,13 +50,21 @@ var (
 )
 
 func goodGetProject(ctx context.Context, id string) (interface{}, error) {
-	return nil, nil
+	return &project.Project{Name: "test project"}, nil
 }
 
 func errorGetProject(ctx context.Context, id string) (interface{}, error) {
 	return nil, fmt.Errorf("an error")
 }
 
+func goodGetStepBlocks(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+	return map[string][]*scenario.Step{}, nil
+}
+
+func goodFolder(ctx context.Context, projectID string, id string) error {
+	return nil
+}
+
 func noProject(ctx context.Context, id string) (interface{}, error) {
 	return nil, mongo.ErrNoDocuments
 }
@@ -67,6 +84,34 @@ func TestScenario_Validate(t *testing.T) {
 	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred when minimun requirements have been met")
 }
 
+func TestScenario_ValidateParameters(t *testing.T) {
+	g := NewWithT(t)
+	s := &scenario.Scenario{
+		Name:       "Test Name",
+		ProjectId:  "aabbccdd",
+		Parameters: &scenario.Parameters{Names: []string{"email", "password"}, Rows: []*scenario.ParameterRow{{Values: []string{"a@b.com"}}}},
+	}
+	err := s.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "row with missing values is not a validation error")
+	s.Parameters.Names = []string{"email", "email"}
+	err = s.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "duplicate parameter name is not a validation error")
+	s.Parameters.Rows[0].Values = []string{"a@b.com", "secret"}
+	s.Parameters.Names = []string{"email", "password"}
+	err = s.Validate()
+	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred for valid parameters")
+}
+
+func TestScenario_ValidateAutomationKeys(t *testing.T) {
+	g := NewWithT(t)
+	s := &scenario.Scenario{Name: "Test Name", ProjectId: "aabbccdd", AutomationKeys: []string{"shop.TestLogin", "shop.TestLogin"}}
+	err := s.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "duplicate automation key is not a validation error")
+	s.AutomationKeys = []string{""}
+	err = s.Validate()
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty automation key is not a validation error")
+}
+
 func TestNew_Create(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -78,13 +123,13 @@ func TestNew_Create(t *testing.T) {
 		NewMeta("tester", "scenario").
 		Return(&identity, nil)
 
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	mockAdder.
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
 		EXPECT().
 		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
 		Return(nil)
 
-	creator := scenarios.New(mockMetaHandler, mockAdder, goodGetProject)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
 	createdScenario, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 	expectedScenario := &scenario.Scenario{
@@ -92,7 +137,7 @@ func TestNew_Create(t *testing.T) {
 		Name:      testScenario.Name,
 		ProjectId: testScenario.ProjectId,
 	}
-	g.Expect(createdScenario).To(Equal(expectedScenario), "scenarios did not match")
+	g.Expect(createdScenario).To(matchers.EqualProto(expectedScenario), "scenarios did not match")
 }
 
 func TestNew_ProjectNotFound(t *testing.T) {
@@ -101,8 +146,8 @@ func TestNew_ProjectNotFound(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.Background()
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	creator := scenarios.New(mockMetaHandler, mockAdder, noProject)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, noProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 }
@@ -113,8 +158,8 @@ func TestNew_ProjectError(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.Background()
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	creator := scenarios.New(mockMetaHandler, mockAdder, errorGetProject)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, errorGetProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "error type was missing")
@@ -126,8 +171,8 @@ func TestNew_MarshallError(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.Background()
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	creator := scenarios.New(mockMetaHandler, mockAdder, errorGetProject)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, errorGetProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(struct{ SomeField string }{SomeField: "test"}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -139,13 +184,33 @@ func TestNew_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 	ctx := context.Background()
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	creator := scenarios.New(mockMetaHandler, mockAdder, errorGetProject)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, errorGetProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(&scenario.Scenario{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
 }
 
+func TestNew_StepBlockError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	noStepBlock := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return nil, mongo.ErrNoDocuments
+	}
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, goodGetProject, noStepBlock, goodFolder)
+	withBlock := &scenario.Scenario{
+		Name:      "test scenario",
+		ProjectId: "zzxxxccvv",
+		Steps:     []*scenario.Step{{Position: 1, StepBlockId: "missing"}},
+	}
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(withBlock))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+}
+
 func TestNew_NewMetaError(t *testing.T) {
 	g := NewWithT(t)
 	ctrl := gomock.NewController(t)
@@ -156,8 +221,8 @@ func TestNew_NewMetaError(t *testing.T) {
 		EXPECT().
 		NewMeta("tester", "scenario").
 		Return(nil, fmt.Errorf("identity error"))
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	creator := scenarios.New(mockMetaHandler, mockAdder, goodGetProject)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
@@ -172,13 +237,13 @@ func TestNew_AddToCollectionError(t *testing.T) {
 		EXPECT().
 		NewMeta("tester", "scenario").
 		Return(&identity, nil)
-	mockAdder := mockScenarios.NewMockAdder(ctrl)
-	mockAdder.
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
 		EXPECT().
 		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
 		Return(fmt.Errorf("expected error"))
 
-	creator := scenarios.New(mockMetaHandler, mockAdder, goodGetProject)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
@@ -293,7 +358,7 @@ func TestUpdate(t *testing.T) {
 		Update(ctx, identity.Id, matchers.OfType(&scenario.Scenario{}))
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodGetStepBlocks, goodFolder)
 	createdScenario, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testScenario))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 	expectedScenario := &scenario.Scenario{
@@ -301,7 +366,7 @@ func TestUpdate(t *testing.T) {
 		Name:      testScenario.Name,
 		ProjectId: testScenario.ProjectId,
 	}
-	g.Expect(createdScenario).To(Equal(expectedScenario), "scenarios did not match")
+	g.Expect(createdScenario).To(matchers.EqualProto(expectedScenario), "scenarios did not match")
 }
 
 func TestUpdate_ValidationError(t *testing.T) {
@@ -311,7 +376,7 @@ func TestUpdate_ValidationError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodGetStepBlocks, goodFolder)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(scenario.Scenario{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -324,7 +389,7 @@ func TestUpdate_InvalidProject(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, noProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, noProject, goodGetStepBlocks, goodFolder)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -336,7 +401,7 @@ func TestUpdate_ProjectError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, errorGetProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, errorGetProject, goodGetStepBlocks, goodFolder)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "project not found error is not a validation error")
@@ -353,7 +418,7 @@ func TestUpdate_GetError(t *testing.T) {
 		Get(ctx, identity.Id, matchers.OfType(&scenario.Scenario{})).
 		Return(fmt.Errorf("error during get"))
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodGetStepBlocks, goodFolder)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -376,7 +441,1299 @@ func TestUpdate_UpdateError(t *testing.T) {
 		Return(fmt.Errorf("update error"))
 	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodGetStepBlocks, goodFolder)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testScenario))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
+
+func TestMoveToFolder(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &identity
+			s.ProjectId = testScenario.ProjectId
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&scenario.Scenario{}))
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	mover := scenarios.MoveToFolder(mockMetaHandler, mockReaderUpdater)
+	moved, err := mover(ctx, "tester", testScenario.ProjectId, "folder", []string{identity.Id})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(moved).To(HaveLen(1), "moved scenarios were not returned")
+	g.Expect(moved[0].(*scenario.Scenario).FolderId).To(Equal("folder"), "folder was not set")
+}
+
+func TestMoveToFolder_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &identity
+			s.ProjectId = "other project"
+		})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mover := scenarios.MoveToFolder(mockMetaHandler, mockReaderUpdater)
+	_, err := mover(ctx, "tester", testScenario.ProjectId, "folder", []string{identity.Id})
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "moving a scenario from another project is not a validation error")
+}
+
+func TestNew_CustomFields(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getProject := func(ctx context.Context, id string) (interface{}, error) {
+		return &project.Project{
+			Name: "test project",
+			ScenarioFields: []*customfield.Definition{
+				{Name: "priority", Type: customfield.FieldType_Enum, Options: []string{"low", "high"}, DefaultValues: []string{"low"}},
+				{Name: "component", Required: true},
+			},
+		}, nil
+	}
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, getProject, goodGetStepBlocks, goodFolder)
+
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testScenario))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "missing required custom field is not a validation error")
+
+	withFields := &scenario.Scenario{
+		Name:         testScenario.Name,
+		ProjectId:    testScenario.ProjectId,
+		CustomFields: map[string]*customfield.Value{"component": {Values: []string{"login"}}},
+	}
+	created, err := creator(ctx, "tester", transformers.ToReadCloser(withFields))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created.(*scenario.Scenario).CustomFields["priority"].Values).To(Equal([]string{"low"}), "default value was not set")
+}
+
+// expectStoredScenario makes the collection return a copy of stored and expects it to be updated
+func expectStoredScenario(ctx context.Context, mockReaderUpdater *mockScenarios.MockReaderUpdater, stored *scenario.Scenario, updated bool) {
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &identity
+			s.Name = stored.Name
+			s.ProjectId = stored.ProjectId
+			s.State = stored.State
+			s.Reviewers = stored.Reviewers
+			s.Reviews = stored.Reviews
+			s.RequirementIds = stored.RequirementIds
+		})
+	if updated {
+		mockReaderUpdater.
+			EXPECT().
+			Update(ctx, identity.Id, matchers.OfType(&scenario.Scenario{}))
+	}
+}
+
+func TestUpdate_ApprovedReturnsToDraft(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	reviews := []*scenario.Review{{Reviewer: "reviewer", Decision: scenario.ReviewDecision_Approve}}
+	expectStoredScenario(ctx, mockReaderUpdater, &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, State: scenario.State_Approved, Reviews: reviews}, true)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := scenarios.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodGetStepBlocks, goodFolder)
+	edited := &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, State: scenario.State_Approved, Description: "changed"}
+	updated, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(edited))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*scenario.Scenario).State).To(Equal(scenario.State_Draft), "edited scenario did not return to draft")
+	g.Expect(updated.(*scenario.Scenario).Reviews).To(Equal(reviews), "reviews were not kept")
+}
+
+func TestTransition(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, testScenario, true)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	transition := scenarios.Transition(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updated, err := transition(ctx, "tester", identity.Id, transformers.ToReadCloser(&scenario.TransitionRequest{State: scenario.State_InReview}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*scenario.Scenario).State).To(Equal(scenario.State_InReview), "state was not changed")
+}
+
+func TestTransition_NotAllowed(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	getProject := func(ctx context.Context, id string) (interface{}, error) {
+		return &project.Project{
+			Name:                "test project",
+			ScenarioTransitions: []*scenario.Transition{{From: scenario.State_Draft, To: scenario.State_InReview}},
+		}, nil
+	}
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, testScenario, false)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	transition := scenarios.Transition(mockMetaHandler, mockReaderUpdater, getProject)
+	_, err := transition(ctx, "tester", identity.Id, transformers.ToReadCloser(&scenario.TransitionRequest{State: scenario.State_Deprecated}))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "transition that is not configured is not a validation error")
+}
+
+func TestTransition_ToApproved(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	transition := scenarios.Transition(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	_, err := transition(ctx, "tester", identity.Id, transformers.ToReadCloser(&scenario.TransitionRequest{State: scenario.State_Approved}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "approving without a review is not a validation error")
+}
+
+func TestReview_Approve(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, State: scenario.State_InReview, Reviewers: []string{"reviewer"}}, true)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("reviewer", matchers.OfType(&metadata.Identity{}))
+	review := scenarios.Review(mockMetaHandler, mockReaderUpdater)
+	updated, err := review(ctx, "reviewer", identity.Id, transformers.ToReadCloser(&scenario.ReviewRequest{Decision: scenario.ReviewDecision_Approve}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	reviewed := updated.(*scenario.Scenario)
+	g.Expect(reviewed.State).To(Equal(scenario.State_Approved), "scenario was not approved")
+	g.Expect(reviewed.Reviews).To(HaveLen(1), "review was not recorded")
+	g.Expect(reviewed.Reviews[0].Reviewer).To(Equal("reviewer"), "reviewer was not recorded")
+}
+
+func TestReview_RequestChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, State: scenario.State_InReview}, true)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("reviewer", matchers.OfType(&metadata.Identity{}))
+	review := scenarios.Review(mockMetaHandler, mockReaderUpdater)
+	updated, err := review(ctx, "reviewer", identity.Id, transformers.ToReadCloser(&scenario.ReviewRequest{Decision: scenario.ReviewDecision_RequestChanges, Comment: "missing steps"}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updated.(*scenario.Scenario).State).To(Equal(scenario.State_Draft), "scenario did not return to draft")
+}
+
+func TestReview_NotAReviewer(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, State: scenario.State_InReview, Reviewers: []string{"reviewer"}}, false)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	review := scenarios.Review(mockMetaHandler, mockReaderUpdater)
+	_, err := review(ctx, "tester", identity.Id, transformers.ToReadCloser(&scenario.ReviewRequest{Decision: scenario.ReviewDecision_Approve}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "review from a user that is not a reviewer is not a validation error")
+}
+
+func TestReview_NotInReview(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, testScenario, false)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	review := scenarios.Review(mockMetaHandler, mockReaderUpdater)
+	_, err := review(ctx, "reviewer", identity.Id, transformers.ToReadCloser(&scenario.ReviewRequest{Decision: scenario.ReviewDecision_Approve}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "reviewing a draft scenario is not a validation error")
+}
+
+// expectTakenNames makes the collection report the names as used in the project
+func TestLinkRequirement(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	stored := &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, RequirementIds: []string{"req1"}}
+	expectStoredScenario(ctx, mockReaderUpdater, stored, true)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	linked, err := scenarios.LinkRequirement(mockMetaHandler, mockReaderUpdater)(ctx, "tester", testScenario.ProjectId, "req2", []string{identity.Id}, true)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(linked[0].(*scenario.Scenario).RequirementIds).To(Equal([]string{"req1", "req2"}), "requirement was not linked")
+
+	expectStoredScenario(ctx, mockReaderUpdater, &scenario.Scenario{Name: testScenario.Name, ProjectId: testScenario.ProjectId, RequirementIds: []string{"req1", "req2"}}, true)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	unlinked, err := scenarios.LinkRequirement(mockMetaHandler, mockReaderUpdater)(ctx, "tester", testScenario.ProjectId, "req1", []string{identity.Id}, false)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(unlinked[0].(*scenario.Scenario).RequirementIds).To(Equal([]string{"req2"}), "requirement was not unlinked")
+}
+
+func TestLinkRequirement_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	expectStoredScenario(ctx, mockReaderUpdater, testScenario, false)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	_, err := scenarios.LinkRequirement(mockMetaHandler, mockReaderUpdater)(ctx, "tester", "another", "req1", []string{identity.Id}, true)
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "no validation error when linking a scenario of another project")
+}
+
+func expectTakenNames(ctx context.Context, mockReaderWriter *mockScenarios.MockReaderWriter, taken map[string]*metadata.Identity) {
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			if id, ok := taken[filter[scenarios.NameFilterKey][0]]; ok {
+				*items = append(*items, scenario.Scenario{Identity: id, Name: filter[scenarios.NameFilterKey][0], ProjectId: filter[scenarios.ProjectFilterKey][0]})
+			}
+		}).
+		AnyTimes()
+}
+
+func expectSourceScenario(ctx context.Context, mockReaderWriter *mockScenarios.MockReaderWriter) {
+	mockReaderWriter.
+		EXPECT().
+		Get(ctx, "source", matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &metadata.Identity{Id: "source"}
+			s.Name = "login"
+			s.ProjectId = "source project"
+			s.FolderId = "folder"
+			s.State = scenario.State_Approved
+			s.CustomFields = map[string]*customfield.Value{"priority": {Values: []string{"high"}}}
+			s.Steps = []*scenario.Step{
+				{Position: 1, StepBlockId: "block"},
+				{Position: 2, Name: "check"},
+			}
+		})
+}
+
+func getTargetProject(ctx context.Context, id string) (interface{}, error) {
+	return &project.Project{Name: "target"}, nil
+}
+
+func TestCopy_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectSourceScenario(ctx, mockReaderWriter)
+	expectTakenNames(ctx, mockReaderWriter, map[string]*metadata.Identity{"login": {Id: "existing"}})
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		g.Expect(projectID).To(Equal("source project"), "step blocks were not retrieved from the source project")
+		return map[string][]*scenario.Step{"block": {{Position: 1, Name: "open"}, {Position: 2, Name: "login"}}}, nil
+	}
+	copyScenarios := scenarios.Copy(mockMetaHandler, mockReaderWriter, getTargetProject, getStepBlocks)
+	result, err := copyScenarios(ctx, "tester", "target project", []string{"source"}, metadata.CollisionStrategy_SUFFIX)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Copied).To(HaveLen(1), "scenario was not copied")
+	copied := result.Copied[0]
+	g.Expect(copied.Name).To(Equal("login (copy)"), "name collision was not resolved with a suffix")
+	g.Expect(copied.ProjectId).To(Equal("target project"), "project was not changed")
+	g.Expect(copied.FolderId).To(BeEmpty(), "folder of the other project was kept")
+	g.Expect(copied.State).To(Equal(scenario.State_Draft), "copy is not a draft")
+	g.Expect(copied.CustomFields).To(BeEmpty(), "custom fields that are not defined by the project were kept")
+	g.Expect(copied.Steps).To(HaveLen(3), "step block was not inlined")
+	g.Expect(copied.Steps[2].Position).To(Equal(int32(3)), "steps were not renumbered")
+	g.Expect(copied.CopiedFrom.SourceId).To(Equal("source"), "provenance was not recorded")
+	g.Expect(copied.CopiedFrom.SourceProjectId).To(Equal("source project"), "provenance was not recorded")
+}
+
+func TestCopy_NameTakenInAnotherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectSourceScenario(ctx, mockReaderWriter)
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"target project"}, scenarios.NameFilterKey: {"login"}}, "", false, 1, "").
+		Return(nil)
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return map[string][]*scenario.Step{"block": {{Position: 1, Name: "open"}}}, nil
+	}
+	copyScenarios := scenarios.Copy(mockMetaHandler, mockReaderWriter, getTargetProject, getStepBlocks)
+	result, err := copyScenarios(ctx, "tester", "target project", []string{"source"}, metadata.CollisionStrategy_SUFFIX)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Copied).To(HaveLen(1), "scenario was not copied")
+	g.Expect(result.Copied[0].Name).To(Equal("login"), "name of the source project was seen as taken in the target project")
+}
+
+func TestScenario_UniqueKeys(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(&scenario.Scenario{ProjectId: "project", Name: "login"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the unique index of the names within a project is built on the filter keys
+	g.Expect(bson.Raw(stored).Lookup(scenarios.ProjectFilterKey).StringValue()).To(Equal("project"), "project filter key is not the stored field")
+	g.Expect(bson.Raw(stored).Lookup(scenarios.NameFilterKey).StringValue()).To(Equal("login"), "name filter key is not the stored field")
+}
+
+func TestScenario_StateKey(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(&scenario.Scenario{State: scenario.State_Approved})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the scenarios stored without a state are approved on startup through the state filter key
+	g.Expect(bson.Raw(stored).Lookup(scenarios.StateFilterKey).Int32()).To(Equal(int32(scenario.State_Approved)), "state filter key is not the stored field")
+	stored, err = bson.Marshal(&scenario.Scenario{})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	_, err = bson.Raw(stored).LookupErr(scenarios.StateFilterKey)
+	g.Expect(err).ShouldNot(HaveOccurred(), "draft scenarios are stored without a state")
+}
+
+func TestCopy_Skip(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectSourceScenario(ctx, mockReaderWriter)
+	expectTakenNames(ctx, mockReaderWriter, map[string]*metadata.Identity{"login": {Id: "existing"}})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return map[string][]*scenario.Step{"block": {{Position: 1, Name: "open"}}}, nil
+	}
+	copyScenarios := scenarios.Copy(mockMetaHandler, mockReaderWriter, getTargetProject, getStepBlocks)
+	result, err := copyScenarios(ctx, "tester", "target project", []string{"source"}, metadata.CollisionStrategy_SKIP)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Copied).To(BeEmpty(), "skipped scenario was copied")
+	g.Expect(result.Skipped).To(Equal([]string{"source"}), "skipped scenario was not reported")
+}
+
+func TestCopy_Overwrite(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	existing := &metadata.Identity{Id: "existing"}
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectSourceScenario(ctx, mockReaderWriter)
+	expectTakenNames(ctx, mockReaderWriter, map[string]*metadata.Identity{"login": existing})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "existing", matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", existing)
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return map[string][]*scenario.Step{"block": {{Position: 1, Name: "open"}}}, nil
+	}
+	copyScenarios := scenarios.Copy(mockMetaHandler, mockReaderWriter, getTargetProject, getStepBlocks)
+	result, err := copyScenarios(ctx, "tester", "target project", []string{"source"}, metadata.CollisionStrategy_OVERWRITE)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Copied).To(HaveLen(1), "scenario was not copied")
+	g.Expect(result.Copied[0].Identity.Id).To(Equal("existing"), "existing scenario was not overwritten")
+	g.Expect(result.Copied[0].Name).To(Equal("login"), "name of the overwritten scenario changed")
+}
+
+func TestCopySelection_NoScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	copyScenarios := func(ctx context.Context, user string, projectID string, ids []string, onCollision metadata.CollisionStrategy) (*scenario.CopyResult, error) {
+		return nil, fmt.Errorf("should not be called")
+	}
+	_, err := scenarios.CopySelection(copyScenarios)(ctx, "tester", transformers.ToReadCloser(&scenario.CopyRequest{ProjectId: "target project"}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "copying no scenarios is not a validation error")
+}
+
+func TestSetIssueStates(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockScenarios.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.IssueFilterKey: {"https://tracker/1"}}, "", false, 0, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, []scenario.Scenario{
+				{Identity: &metadata.Identity{Id: "changed"}, Issues: []*metadata.LinkedIssue{{Link: "https://tracker/1", State: "Open"}}},
+				{Identity: &metadata.Identity{Id: "up to date"}, Issues: []*metadata.LinkedIssue{{Link: "https://tracker/1", State: "Done"}}},
+			}...)
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, "changed", matchers.OfType(&scenario.Scenario{}))
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("issue-tracker", matchers.OfType(&metadata.Identity{}))
+
+	changed, err := scenarios.SetIssueStates(mockMetaHandler, mockReaderUpdater)(ctx, "issue-tracker", map[string]string{"https://tracker/1": "Done"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(changed).To(Equal(1), "wrong number of changed scenarios")
+}
+
+const loginFeature = `@web
+Feature: Login
+  Scenario: login
+    When the user logs in
+    Then the dashboard is shown
+`
+
+func expectFeatureScenarios(ctx context.Context, mockReaderWriter *mockScenarios.MockReaderWriter, existing map[string]*scenario.Scenario) {
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			if s, ok := existing[filter[scenarios.NameFilterKey][0]]; ok {
+				*items = append(*items, scenario.Scenario{
+					Identity:       s.Identity,
+					Name:           s.Name,
+					ProjectId:      s.ProjectId,
+					FeaturePath:    s.FeaturePath,
+					State:          s.State,
+					Labels:         s.Labels,
+					Steps:          s.Steps,
+					AutomationKeys: s.AutomationKeys,
+				})
+			}
+		}).
+		AnyTimes()
+}
+
+// expectFreeAutomationKeys reports that no other scenario uses the automation keys
+func expectFreeAutomationKeys(ctx context.Context, mockReaderWriter *mockScenarios.MockReaderWriter) {
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 0, "").
+		Return(nil).
+		AnyTimes()
+}
+
+func gherkinRequest(update bool) *scenario.GherkinImportRequest {
+	return &scenario.GherkinImportRequest{
+		ProjectId:   "project",
+		FeaturePath: "features/login.feature",
+		Content:     loginFeature,
+		Update:      update,
+	}
+}
+
+func TestImportGherkin_Create(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{})
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(false)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	created := result.(*scenario.ImportResult).Created
+	g.Expect(created).To(HaveLen(1), "scenario was not created")
+	g.Expect(created[0].ProjectId).To(Equal("project"), "project was not set")
+	g.Expect(created[0].FeaturePath).To(Equal("features/login.feature"), "feature path was not kept")
+	g.Expect(created[0].Labels).To(Equal([]string{"web"}), "tags were not imported as labels")
+	g.Expect(created[0].State).To(Equal(scenario.State_Draft), "imported scenario is not a draft")
+	g.Expect(created[0].AutomationKeys).To(Equal([]string{"features/login.feature:login"}), "automation key of the Cucumber results was not set")
+}
+
+func TestImportGherkin_SkipExisting(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {Identity: &metadata.Identity{Id: "existing"}, Name: "login", ProjectId: "project", FeaturePath: "features/login.feature"},
+	})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(false)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.(*scenario.ImportResult).Skipped).To(Equal([]string{"login"}), "existing scenario was not skipped")
+}
+
+func TestImportGherkin_Update(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	existing := &metadata.Identity{Id: "existing"}
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {
+			Identity:    existing,
+			Name:        "login",
+			ProjectId:   "project",
+			FeaturePath: "features/login.feature",
+			State:       scenario.State_Approved,
+			Steps:       []*scenario.Step{{Position: 1, Name: "the user logs in", Action: "the user logs in"}},
+		},
+	})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "existing", matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", gomock.Any())
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(true)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	updated := result.(*scenario.ImportResult).Updated
+	g.Expect(updated).To(HaveLen(1), "scenario was not updated")
+	g.Expect(updated[0].Identity.Id).To(Equal("existing"), "existing scenario was not updated")
+	g.Expect(updated[0].Steps[0].ExpectedOutcome).To(Equal("the dashboard is shown"), "steps were not updated")
+	g.Expect(updated[0].State).To(Equal(scenario.State_Draft), "updated scenario has to be reviewed again")
+	g.Expect(updated[0].AutomationKeys).To(Equal([]string{"features/login.feature:login"}), "automation key of the Cucumber results was not set")
+}
+
+func TestImportGherkin_AutomationKey(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	// the scenario was imported before the automation keys were set, under another name
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {
+			Identity:       &metadata.Identity{Id: "existing"},
+			Name:           "login",
+			ProjectId:      "project",
+			FeaturePath:    "features/login.feature",
+			State:          scenario.State_Approved,
+			Labels:         []string{"web"},
+			AutomationKeys: []string{"shop.TestLogin", "features/login.feature:old login"},
+			Steps:          []*scenario.Step{{Position: 1, Name: "the user logs in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"}},
+		},
+	})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "existing", matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", gomock.Any())
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(true)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	updated := result.(*scenario.ImportResult).Updated
+	g.Expect(updated).To(HaveLen(1), "automation key was not added")
+	g.Expect(updated[0].AutomationKeys).To(Equal([]string{"shop.TestLogin", "features/login.feature:old login", "features/login.feature:login"}), "automation keys were not merged")
+	g.Expect(updated[0].State).To(Equal(scenario.State_Approved), "adding the automation key requires a new review")
+}
+
+func TestImportGherkin_Unchanged(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {
+			Identity:       &metadata.Identity{Id: "existing"},
+			Name:           "login",
+			ProjectId:      "project",
+			FeaturePath:    "features/login.feature",
+			Labels:         []string{"web"},
+			AutomationKeys: []string{"features/login.feature:login"},
+			Steps:          []*scenario.Step{{Position: 1, Name: "the user logs in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"}},
+		},
+	})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(true)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.(*scenario.ImportResult).Skipped).To(Equal([]string{"login"}), "unchanged scenario was updated")
+}
+
+func TestImportGherkin_OtherFeature(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {Identity: &metadata.Identity{Id: "existing"}, Name: "login", ProjectId: "project", FeaturePath: "features/other.feature"},
+	})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(gherkinRequest(true)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.(*scenario.ImportResult).Skipped).To(Equal([]string{"login"}), "scenario of another feature file was overwritten")
+}
+
+func TestImportGherkin_InvalidFeature(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	request := gherkinRequest(false)
+	request.Content = "Feature: Login\n"
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	_, err := importGherkin(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "feature without scenarios is not a validation error")
+}
+
+func TestImportGherkin_ExportedID(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	exported := &metadata.Identity{Id: "exported"}
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"project"}, scenarios.IDFilterKey: {"exported"}}, "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, scenario.Scenario{Identity: exported, Name: "old name", ProjectId: "project"})
+		})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "exported", matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", gomock.Any())
+	request := gherkinRequest(true)
+	request.Content = "Feature: Login\n  @scratch-post-id:exported\n  Scenario: login\n    When the user logs in\n"
+	importGherkin := scenarios.ImportGherkin(mockMetaHandler, mockReaderWriter, goodGetProject, goodFolder)
+	result, err := importGherkin(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	updated := result.(*scenario.ImportResult).Updated
+	g.Expect(updated).To(HaveLen(1), "exported scenario was not updated")
+	g.Expect(updated[0].Name).To(Equal("login"), "name was not updated")
+	g.Expect(updated[0].FeaturePath).To(Equal("features/login.feature"), "feature path was not set")
+}
+
+func TestExportGherkin(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(
+			ctx,
+			matchers.OfType(&[]scenario.Scenario{}),
+			map[string][]string{scenarios.ProjectFilterKey: {"project"}, scenarios.LabelFilterKey: {"smoke"}, scenarios.FolderFilterKey: {"folder", "subfolder"}},
+			scenarios.NameFilterKey,
+			false,
+			0,
+			"",
+		).
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items,
+				scenario.Scenario{Identity: &metadata.Identity{Id: "imported"}, Name: "imported", FeaturePath: "features/login.feature"},
+				scenario.Scenario{Identity: &metadata.Identity{Id: "in folder"}, Name: "in folder", FolderId: "subfolder", Steps: []*scenario.Step{{Position: 1, StepBlockId: "block"}}},
+				scenario.Scenario{Identity: &metadata.Identity{Id: "at root"}, Name: "at root"},
+			)
+		})
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		g.Expect(ids).To(Equal([]string{"block"}), "step blocks were not retrieved")
+		return map[string][]*scenario.Step{"block": {{Position: 1, Action: "open the shop"}}}, nil
+	}
+	descendants := func(ctx context.Context, id string) ([]string, error) {
+		return []string{"folder", "subfolder"}, nil
+	}
+	paths := func(ctx context.Context, projectID string) (map[string]string, error) {
+		return map[string]string{"folder": "shop", "subfolder": "shop/cart"}, nil
+	}
+	export := scenarios.ExportGherkin(mockGetter, goodGetProject, getStepBlocks, descendants, paths)
+	name, content, err := export(ctx, map[string][]string{"projectId": {"project"}, "label": {"smoke"}, "folderId": {"folder"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(name).To(Equal("test project.zip"), "archive is not named after the project")
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	g.Expect(err).ShouldNot(HaveOccurred(), "archive could not be read")
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		g.Expect(err).ShouldNot(HaveOccurred(), "file could not be read")
+		text, err := ioutil.ReadAll(r)
+		g.Expect(err).ShouldNot(HaveOccurred(), "file could not be read")
+		files[f.Name] = string(text)
+	}
+	g.Expect(files).To(HaveLen(3), "scenarios were not split by feature file")
+	g.Expect(files["features/login.feature"]).To(ContainSubstring("@scratch-post-id:imported"), "imported scenario was not exported to its feature file")
+	g.Expect(files["shop/cart.feature"]).To(HavePrefix("Feature: cart\n"), "feature is not named after the folder")
+	g.Expect(files["shop/cart.feature"]).To(ContainSubstring("When open the shop"), "step block was not inlined")
+	g.Expect(files["test project.feature"]).To(ContainSubstring("Scenario: at root"), "scenario at the root was not exported to the project file")
+}
+
+func TestExportGherkin_NoProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	export := scenarios.ExportGherkin(mockGetter, goodGetProject, goodGetStepBlocks, nil, nil)
+	_, _, err := export(ctx, map[string][]string{"label": {"smoke"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "export without project is not a validation error")
+	_, _, err = export(ctx, map[string][]string{"projectId": {"project"}, "state": {"1"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "export by unknown filter is not a validation error")
+}
+
+func TestAutomated(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"project"}, scenarios.AutomationKeyFilterKey: {"shop.TestLogin"}}, "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, scenario.Scenario{Identity: &metadata.Identity{Id: "login"}, AutomationKeys: []string{"shop.TestLogin"}})
+		})
+	found, err := scenarios.Automated(mockGetter)(ctx, "project", "shop.TestLogin")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(found.Identity.Id).To(Equal("login"), "scenario with the automation key was not found")
+}
+
+func TestCreateAutomated(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockStore := mockScenarios.NewMockReaderWriterDeleter(ctrl)
+	// a scenario is already named after the logout test
+	mockStore.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			if filter[scenarios.NameFilterKey][0] == "shop.TestLogout" {
+				*items = append(*items, scenario.Scenario{Identity: &metadata.Identity{Id: "manual"}, Name: "shop.TestLogout"})
+			}
+		}).
+		Times(2)
+	mockStore.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil).
+		Times(2)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	ids := []string{"login", "logout"}
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		DoAndReturn(func(author string, objType string) (*metadata.Identity, error) {
+			id := ids[0]
+			ids = ids[1:]
+			return &metadata.Identity{Id: id}, nil
+		}).
+		Times(2)
+	created, err := scenarios.CreateAutomated(mockMetaHandler, mockStore, goodGetProject)(ctx, "tester", "project", []string{"shop.TestLogin", "shop.TestLogout"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(created).To(HaveLen(2), "scenarios were not created")
+	g.Expect(created[0].Name).To(Equal("shop.TestLogin"), "scenario is not named after the key")
+	g.Expect(created[0].Automated).To(BeTrue(), "scenario is not automated")
+	g.Expect(created[0].AutomationKeys).To(Equal([]string{"shop.TestLogin"}), "automation key was not set")
+	g.Expect(created[0].State).To(Equal(scenario.State_Draft), "scenario is not a draft")
+	g.Expect(created[1].Name).To(Equal("shop.TestLogout (logout)"), "name used by another scenario was not made unique")
+}
+
+func TestCreateAutomated_RemovedOnError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockStore := mockScenarios.NewMockReaderWriterDeleter(ctrl)
+	mockStore.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 1, "").
+		Return(nil).
+		Times(2)
+	gomock.InOrder(
+		mockStore.EXPECT().AddOne(ctx, matchers.OfType(&scenario.Scenario{})).Return(nil),
+		mockStore.EXPECT().AddOne(ctx, matchers.OfType(&scenario.Scenario{})).Return(fmt.Errorf("store is down")),
+	)
+	mockStore.
+		EXPECT().
+		Delete(ctx, identity.Id).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil).
+		Times(2)
+	_, err := scenarios.CreateAutomated(mockMetaHandler, mockStore, goodGetProject)(ctx, "tester", "project", []string{"shop.TestLogin", "shop.TestLogout"})
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+}
+
+const scenarioSheet = `Title,Steps,Tags,Ignored
+login,"1. the user logs in
+=> the dashboard is shown",web;smoke,x
+new scenario,1. open the shop,,
+,,,
+logout,,web,
+`
+
+func TestImportCSV(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{
+		"login": {
+			Identity:    &metadata.Identity{Id: "existing"},
+			Name:        "login",
+			ProjectId:   "project",
+			FeaturePath: "features/login.feature",
+			State:       scenario.State_Approved,
+		},
+		"logout": {
+			Identity:  &metadata.Identity{Id: "unchanged"},
+			Name:      "logout",
+			ProjectId: "project",
+			Labels:    []string{"web"},
+		},
+	})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "existing", matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", gomock.Any())
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	importCSV := scenarios.ImportCSV(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	request := &scenario.CSVImportRequest{
+		ProjectId: "project",
+		FolderId:  "folder",
+		Content:   scenarioSheet,
+		Mapping:   map[string]string{"Title": "name", "Steps": "steps", "Tags": "labels"},
+	}
+	raw, err := importCSV(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*scenario.ImportResult)
+	g.Expect(result.Rows).To(HaveLen(3), "every scenario was not reported")
+	g.Expect(result.Rows[0].Outcome).To(Equal(scenario.RowOutcome_Update))
+	g.Expect(result.Rows[1].Outcome).To(Equal(scenario.RowOutcome_Create))
+	g.Expect(result.Rows[2].Row).To(Equal(int32(5)), "row number does not count the header and blank rows")
+	g.Expect(result.Rows[2].Outcome).To(Equal(scenario.RowOutcome_Unchanged))
+
+	updated := result.Updated[0]
+	g.Expect(updated.Labels).To(Equal([]string{"web", "smoke"}), "labels were not updated")
+	g.Expect(updated.Steps[0].ExpectedOutcome).To(Equal("the dashboard is shown"), "steps were not updated")
+	g.Expect(updated.FeaturePath).To(Equal("features/login.feature"), "unmapped field was changed")
+	g.Expect(updated.State).To(Equal(scenario.State_Draft), "updated scenario has to be reviewed again")
+	g.Expect(result.Created[0].FolderId).To(Equal("folder"), "new scenario was not placed in the folder")
+	g.Expect(result.Skipped).To(Equal([]string{"logout"}))
+}
+
+func TestImportCSV_DryRun(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{})
+	expectAutomated(ctx, mockReaderWriter, "project", nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importCSV := scenarios.ImportCSV(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	content := "name,automationKeys,customFields.priority\nlogin,shop.TestLogin;shop.TestLogin,\nlogout,,high\n"
+	raw, err := importCSV(ctx, "tester", transformers.ToReadCloser(&scenario.CSVImportRequest{ProjectId: "project", Content: content, DryRun: true}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*scenario.ImportResult)
+	g.Expect(result.Rows[0].Outcome).To(Equal(scenario.RowOutcome_Invalid), "duplicate automation key was not reported")
+	g.Expect(result.Rows[0].Errors).To(ConsistOf("automation key 'shop.TestLogin' is used more than once"))
+	g.Expect(result.Rows[1].Outcome).To(Equal(scenario.RowOutcome_Invalid), "undefined custom field was not reported")
+	g.Expect(result.Created).To(BeEmpty())
+}
+
+func TestImportCSV_RoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	login := &scenario.Scenario{
+		Identity:       &metadata.Identity{Id: "login"},
+		Name:           "login",
+		ProjectId:      "project",
+		State:          scenario.State_Approved,
+		Labels:         []string{"web"},
+		AutomationKeys: []string{"shop.TestLogin"},
+		Steps: []*scenario.Step{
+			{Position: 1, StepBlockId: "block"},
+			{Position: 2, Name: "log in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"},
+			{Position: 3, Name: "log out"},
+		},
+	}
+	getStepBlocks := func(ctx context.Context, projectID string, ids []string) (map[string][]*scenario.Step, error) {
+		return map[string][]*scenario.Step{"block": {
+			{Position: 1, Name: "open", Action: "the shop is opened"},
+			{Position: 2, Name: "accept cookies", Action: "the user accepts the cookies\nof the shop", ExpectedOutcome: "the banner is closed"},
+		}}, nil
+	}
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"project"}}, scenarios.NameFilterKey, false, 0, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, scenario.Scenario{
+				Identity:       login.Identity,
+				Name:           login.Name,
+				ProjectId:      login.ProjectId,
+				State:          login.State,
+				Labels:         login.Labels,
+				AutomationKeys: login.AutomationKeys,
+				Steps:          login.Steps,
+			})
+		}).
+		AnyTimes()
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{"login": login})
+	expectFreeAutomationKeys(ctx, mockReaderWriter)
+	paths := func(ctx context.Context, projectID string) (map[string]string, error) {
+		return map[string]string{}, nil
+	}
+	export := scenarios.ExportCSV(mockReaderWriter, goodGetProject, getStepBlocks, nil, paths)
+	importCSV := scenarios.ImportCSV(mockScenarios.NewMockMetaHandler(ctrl), mockReaderWriter, goodGetProject, getStepBlocks, goodFolder)
+	for _, layout := range []string{"scenario", "step"} {
+		_, content, err := export(ctx, map[string][]string{"projectId": {"project"}, "layout": {layout}})
+		g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+		raw, err := importCSV(ctx, "tester", transformers.ToReadCloser(&scenario.CSVImportRequest{ProjectId: "project", Content: string(content)}))
+		g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+		result := raw.(*scenario.ImportResult)
+		g.Expect(result.Rows).To(HaveLen(1))
+		g.Expect(result.Rows[0].Outcome).To(Equal(scenario.RowOutcome_Unchanged), "unedited %s export was changed by the import", layout)
+	}
+}
+
+func TestImportCSV_DuplicateName(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{})
+	importCSV := scenarios.ImportCSV(mockScenarios.NewMockMetaHandler(ctrl), mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	content := "name,labels\nlogin,web\nlogout,web\nlogin,mobile\n"
+	raw, err := importCSV(ctx, "tester", transformers.ToReadCloser(&scenario.CSVImportRequest{ProjectId: "project", Content: content, DryRun: true}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*scenario.ImportResult)
+	g.Expect(result.Rows[0].Outcome).To(Equal(scenario.RowOutcome_Create))
+	g.Expect(result.Rows[2].Outcome).To(Equal(scenario.RowOutcome_Invalid), "second scenario with the name would be created")
+	g.Expect(result.Created).To(HaveLen(2))
+}
+
+func TestImportCSV_UnknownField(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importCSV := scenarios.ImportCSV(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	request := &scenario.CSVImportRequest{ProjectId: "project", Content: "Title\nlogin\n", Mapping: map[string]string{"Title": "title"}}
+	_, err := importCSV(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "unknown field is not a validation error")
+}
+
+func TestExportCSV(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"project"}}, scenarios.NameFilterKey, false, 0, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, scenario.Scenario{
+				Identity: &metadata.Identity{Id: "login"},
+				Name:     "login",
+				FolderId: "folder",
+				Steps: []*scenario.Step{
+					{Position: 1, Name: "log in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"},
+					{Position: 2, Name: "log out", Action: "the user logs out"},
+				},
+			})
+		}).
+		Times(2)
+	paths := func(ctx context.Context, projectID string) (map[string]string, error) {
+		return map[string]string{"folder": "shop/cart"}, nil
+	}
+	export := scenarios.ExportCSV(mockGetter, goodGetProject, goodGetStepBlocks, nil, paths)
+
+	name, content, err := export(ctx, map[string][]string{"projectId": {"project"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(name).To(Equal("test project.csv"), "file is not named after the project")
+	g.Expect(string(content)).To(ContainSubstring(",steps\n"), "steps are not flattened")
+	g.Expect(string(content)).To(ContainSubstring("login,,,shop/cart,,Draft,false,,,\"1. the user logs in\n=> the dashboard is shown\n2. the user logs out\"\n"))
+
+	_, content, err = export(ctx, map[string][]string{"projectId": {"project"}, "layout": {"step"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(strings.Count(string(content), "\n")).To(Equal(3), "there is not a row per step")
+
+	_, _, err = export(ctx, map[string][]string{"projectId": {"project"}, "layout": {"table"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "unknown layout is not a validation error")
+}
+
+// expectAutomated returns the scenarios that have one of the automation keys looked up in the project
+func expectAutomated(ctx context.Context, mockReaderWriter *mockScenarios.MockReaderWriter, projectID string, automated []*scenario.Scenario) {
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 0, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			if filter[scenarios.ProjectFilterKey][0] != projectID {
+				return
+			}
+			for _, s := range automated {
+				for _, key := range filter[scenarios.AutomationKeyFilterKey] {
+					if s.AutomationKeys[0] == key {
+						*items = append(*items, scenario.Scenario{Identity: s.Identity, Name: s.Name, ProjectId: projectID, AutomationKeys: s.AutomationKeys})
+						break
+					}
+				}
+			}
+		}).
+		AnyTimes()
+}
+
+func TestNew_AutomationKeyUsed(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectAutomated(ctx, mockReaderWriter, "project", []*scenario.Scenario{{Identity: &metadata.Identity{Id: "login"}, Name: "login", AutomationKeys: []string{"shop.TestLogin"}}})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	creator := scenarios.New(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(&scenario.Scenario{Name: "sign in", ProjectId: "project", AutomationKeys: []string{"shop.TestSignIn", "shop.TestLogin"}}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "automation key used by another scenario is not a validation error")
+	g.Expect(err.Error()).To(ContainSubstring("'shop.TestLogin' is already used by scenario 'login'"))
+}
+
+func TestUpdate_AutomationKeys(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
+		EXPECT().
+		Get(ctx, "login", matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &metadata.Identity{Id: "login"}
+		}).
+		Times(2)
+	expectAutomated(ctx, mockReaderWriter, "project", []*scenario.Scenario{
+		{Identity: &metadata.Identity{Id: "login"}, Name: "login", AutomationKeys: []string{"shop.TestLogin"}},
+		{Identity: &metadata.Identity{Id: "logout"}, Name: "logout", AutomationKeys: []string{"shop.TestLogout"}},
+	})
+	mockReaderWriter.
+		EXPECT().
+		Update(ctx, "login", matchers.OfType(&scenario.Scenario{}))
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := scenarios.Update(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+
+	_, err := updater(ctx, "tester", "login", transformers.ToReadCloser(&scenario.Scenario{Name: "login", ProjectId: "project", AutomationKeys: []string{"shop.TestLogin"}}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "automation key of the updated scenario was reported as used")
+
+	_, err = updater(ctx, "tester", "login", transformers.ToReadCloser(&scenario.Scenario{Name: "login", ProjectId: "project", AutomationKeys: []string{"shop.TestLogout"}}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "automation key used by another scenario is not a validation error")
+}
+
+func TestImportCSV_AutomationKeyUsed(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	expectFeatureScenarios(ctx, mockReaderWriter, map[string]*scenario.Scenario{})
+	expectAutomated(ctx, mockReaderWriter, "project", []*scenario.Scenario{{Identity: &metadata.Identity{Id: "logout"}, Name: "logout", AutomationKeys: []string{"shop.TestLogout"}}})
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	importCSV := scenarios.ImportCSV(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks, goodFolder)
+	content := "name,automationKeys\nlogin,shop.TestLogin\nsign in,shop.TestLogin\nsign out,shop.TestLogout\n"
+	raw, err := importCSV(ctx, "tester", transformers.ToReadCloser(&scenario.CSVImportRequest{ProjectId: "project", Content: content, DryRun: true}))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*scenario.ImportResult)
+	g.Expect(result.Rows[0].Outcome).To(Equal(scenario.RowOutcome_Create), "first scenario with the key was not imported")
+	g.Expect(result.Rows[1].Errors).To(ConsistOf("automation key 'shop.TestLogin' is already used by scenario 'login'"), "key used earlier in the file was not reported")
+	g.Expect(result.Rows[2].Errors).To(ConsistOf("automation key 'shop.TestLogout' is already used by scenario 'logout'"), "key used in the project was not reported")
+}
+
+func TestCopy_AutomationKeys(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mockScenarios.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
+		EXPECT().
+		Get(ctx, "source", matchers.OfType(&scenario.Scenario{})).
+		Do(func(ctx context.Context, id string, s *scenario.Scenario) {
+			s.Identity = &metadata.Identity{Id: "source"}
+			s.Name = "login"
+			s.ProjectId = "project"
+			s.AutomationKeys = []string{"shop.TestLogin"}
+		})
+	expectTakenNames(ctx, mockReaderWriter, map[string]*metadata.Identity{"login": {Id: "source"}})
+	expectAutomated(ctx, mockReaderWriter, "project", []*scenario.Scenario{{Identity: &metadata.Identity{Id: "source"}, Name: "login", AutomationKeys: []string{"shop.TestLogin"}}})
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&scenario.Scenario{})).
+		Return(nil)
+	mockMetaHandler := mockScenarios.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "scenario").
+		Return(&identity, nil)
+	result, err := scenarios.Copy(mockMetaHandler, mockReaderWriter, goodGetProject, goodGetStepBlocks)(ctx, "tester", "project", []string{"source"}, metadata.CollisionStrategy_SUFFIX)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(result.Copied[0].AutomationKeys).To(BeEmpty(), "automation keys of the source scenario were copied in the same project")
+}
+
+func TestByAutomationKey(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), gomock.Any(), "", false, 1, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			g.Expect(filter[scenarios.ProjectFilterKey]).To(Equal([]string{"project"}), "scenarios were not filtered by project")
+			if filter[scenarios.AutomationKeyFilterKey][0] == "shop.TestLogin" {
+				*items = append(*items, scenario.Scenario{Identity: &metadata.Identity{Id: "login"}, AutomationKeys: []string{"shop.TestLogin"}})
+			}
+		}).
+		Times(2)
+	byKey := scenarios.ByAutomationKey(mockGetter)
+
+	found, err := byKey(ctx, map[string][]string{"projectId": {"project"}, "key": {"shop.TestLogin"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(found.(*scenario.Scenario).Identity.Id).To(Equal("login"), "scenario with the automation key was not found")
+
+	found, err = byKey(ctx, map[string][]string{"projectId": {"project"}, "key": {"shop.TestLogout"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(found).To(BeNil(), "a scenario was found for an unknown automation key")
+
+	_, err = byKey(ctx, map[string][]string{"key": {"shop.TestLogin"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "missing project is not a validation error")
+}
+
+func TestUnreported(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]scenario.Scenario{}), map[string][]string{scenarios.ProjectFilterKey: {"project"}}, scenarios.NameFilterKey, false, 0, "").
+		Do(func(ctx context.Context, items *[]scenario.Scenario, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) {
+			*items = append(*items, []scenario.Scenario{
+				{Identity: &metadata.Identity{Id: "flagged"}, Name: "flagged", Automated: true},
+				{Identity: &metadata.Identity{Id: "manual"}, Name: "manual"},
+				{Identity: &metadata.Identity{Id: "old"}, Name: "old", AutomationKeys: []string{"shop.TestOld"}},
+				{Identity: &metadata.Identity{Id: "pending"}, Name: "pending", AutomationKeys: []string{"shop.TestPending"}},
+				{Identity: &metadata.Identity{Id: "reported"}, Name: "reported", AutomationKeys: []string{"shop.TestReported"}},
+			}...)
+		}).
+		Times(2)
+	listTestPlans := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{scenarios.ProjectFilterKey: {"project"}}), "test plans were not filtered by project")
+		return []interface{}{
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "first", CreationTime: 1}},
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "third", CreationTime: 3}},
+			&testplan.TestPlan{Identity: &metadata.Identity{Id: "second", CreationTime: 2}},
+		}, nil
+	}
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{scenarios.TestPlanFilterKey: {"third", "second"}}), "executions were not filtered by the last test plans")
+		return []interface{}{
+			&execution.Execution{ScenarioId: "reported", Status: execution.Status_Fail},
+			&execution.Execution{ScenarioId: "pending"},
+		}, nil
+	}
+	unreported := scenarios.Unreported(mockGetter, listTestPlans, listExecutions)
+	items, err := unreported(ctx, map[string][]string{"projectId": {"project"}, "runs": {"2"}}, "", false, 0, "")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	names := []string{}
+	for _, item := range items {
+		names = append(names, item.(*scenario.Scenario).Name)
+	}
+	g.Expect(names).To(Equal([]string{"flagged", "old", "pending"}), "unreported automated scenarios did not match")
+
+	items, err = unreported(ctx, map[string][]string{"projectId": {"project"}, "runs": {"2"}}, "", false, 2, "")
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(items).To(HaveLen(2), "count did not limit the scenarios")
+}
+
+func TestUnreported_InvalidRuns(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockScenarios.NewMockGetter(ctrl)
+	_, err := scenarios.Unreported(mockGetter, nil, nil)(ctx, map[string][]string{"projectId": {"project"}, "runs": {"0"}}, "", false, 0, "")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid number of runs is not a validation error")
+}
@@ -0,0 +1,556 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfieldv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+)
+
+// Names of the fields held by the columns of a CSV file. Exported files use them as headers
+const (
+	IDField                  = "identity.id"
+	NameField                = "name"
+	DescriptionField         = "description"
+	PrerequisitesField       = "prerequisites"
+	FolderField              = "folder"
+	LabelsField              = "labels"
+	StateField               = "state"
+	AutomatedField           = "automated"
+	AutomationKeysField      = "automationKeys"
+	FeaturePathField         = "featurePath"
+	StepsField               = "steps"
+	StepPositionField        = "step.position"
+	StepNameField            = "step.name"
+	StepActionField          = "step.action"
+	StepExpectedOutcomeField = "step.expectedOutcome"
+	// CustomFieldPrefix precedes the name of a custom field, ie. `customFields.priority`
+	CustomFieldPrefix = "customFields."
+)
+
+// separator separates the values of a cell that holds a list, ie. the labels
+const separator = ";"
+
+// outcomePrefix starts the lines of a flattened step that hold its expected outcome
+const outcomePrefix = "=> "
+
+// stepStart matches the first line of a flattened step, ie. `1. the user logs in`
+var stepStart = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+
+// importable are the fields that can be read from a CSV file, besides the custom fields
+var importable = map[string]bool{
+	NameField:                true,
+	DescriptionField:         true,
+	PrerequisitesField:       true,
+	LabelsField:              true,
+	AutomatedField:           true,
+	AutomationKeysField:      true,
+	FeaturePathField:         true,
+	StepsField:               true,
+	StepNameField:            true,
+	StepActionField:          true,
+	StepExpectedOutcomeField: true,
+}
+
+func isImportable(field string) bool {
+	return importable[field] || (strings.HasPrefix(field, CustomFieldPrefix) && len(field) > len(CustomFieldPrefix))
+}
+
+func isStepField(field string) bool {
+	return field == StepNameField || field == StepActionField || field == StepExpectedOutcomeField
+}
+
+// Record is a scenario read from the rows of a CSV file
+type Record struct {
+	// Row is the number of the first row of the scenario, the header being row 1
+	Row int
+	// Scenario holds the values of the mapped columns
+	Scenario *scenariov1.Scenario
+	// Errors are the problems found in the rows of the scenario
+	Errors []string
+}
+
+// Read returns the scenarios of a CSV file and the fields its columns are mapped to.
+// The mapping goes from the header of a column to a field. When it is empty, the columns named after a field are used and the other ones are ignored.
+//
+// Consecutive rows with the same name, or without a name, hold the steps of one scenario and the other values of the scenario are taken from its first row.
+// The steps are either flattened in the steps column or read from the step.* columns of every row.
+// Lists are separated by `;`, custom fields that are not MultiSelect according to the definitions hold the whole cell.
+func Read(content io.Reader, mapping map[string]string, definitions []*customfieldv1.Definition) ([]*Record, []string, error) {
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, decoder.NewValidationError("the CSV file has no header")
+	}
+	if err != nil {
+		return nil, nil, decoder.NewValidationError(fmt.Sprintf("invalid CSV file: %s", err))
+	}
+	columns, err := mapColumns(header, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	multiple := map[string]bool{}
+	for _, d := range definitions {
+		multiple[d.Name] = d.Type == customfieldv1.FieldType_MultiSelect
+	}
+
+	records := []*Record{}
+	firstRows := map[string]int{}
+	var current *Record
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, decoder.NewValidationError(fmt.Sprintf("invalid CSV file: %s", err))
+		}
+		if strings.TrimSpace(strings.Join(values, "")) == "" {
+			continue
+		}
+		cell := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(values[i])
+		}
+		name := cell(NameField)
+		if current == nil || (name != "" && name != current.Scenario.Name) {
+			current = &Record{Row: row, Scenario: &scenariov1.Scenario{Name: name}}
+			if first, ok := firstRows[name]; ok && name != "" {
+				current.Errors = append(current.Errors, fmt.Sprintf("scenario '%s' is already defined on row %d", name, first))
+			}
+			firstRows[name] = row
+			current.read(columns, cell, multiple)
+			records = append(records, current)
+		}
+		current.readStep(row, cell)
+	}
+
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return records, fields, nil
+}
+
+// mapColumns returns the index of the column of every mapped field
+func mapColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	if len(header) != 0 {
+		// spreadsheet applications start UTF-8 files with a byte order mark
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	indexes := map[string]int{}
+	for i, column := range header {
+		indexes[strings.TrimSpace(column)] = i
+	}
+	columns := map[string]int{}
+	if len(mapping) == 0 {
+		for i, column := range header {
+			column = strings.TrimSpace(column)
+			if !isImportable(column) {
+				continue
+			}
+			if _, ok := columns[column]; ok {
+				return nil, decoder.NewValidationError(fmt.Sprintf("column '%s' is used more than once", column))
+			}
+			columns[column] = i
+		}
+	}
+	for column, field := range mapping {
+		i, ok := indexes[column]
+		if !ok {
+			return nil, decoder.NewValidationError(fmt.Sprintf("column '%s' is not in the CSV file", column))
+		}
+		if !isImportable(field) {
+			return nil, decoder.NewValidationError(fmt.Sprintf("'%s' is not a field that can be imported", field))
+		}
+		if _, ok := columns[field]; ok {
+			return nil, decoder.NewValidationError(fmt.Sprintf("field '%s' is mapped more than once", field))
+		}
+		columns[field] = i
+	}
+	if _, ok := columns[NameField]; !ok {
+		return nil, decoder.NewValidationError("no column holds the name of the scenarios")
+	}
+	if _, ok := columns[StepsField]; ok {
+		for field := range columns {
+			if isStepField(field) {
+				return nil, decoder.NewValidationError(fmt.Sprintf("%s cannot be used together with %s", field, StepsField))
+			}
+		}
+	}
+	return columns, nil
+}
+
+// read sets the values of the scenario from its first row
+func (r *Record) read(columns map[string]int, cell func(field string) string, multiple map[string]bool) {
+	s := r.Scenario
+	for field := range columns {
+		value := cell(field)
+		switch field {
+		case DescriptionField:
+			s.Description = value
+		case PrerequisitesField:
+			s.Prerequisites = value
+		case LabelsField:
+			s.Labels = split(value)
+		case AutomationKeysField:
+			s.AutomationKeys = split(value)
+		case FeaturePathField:
+			s.FeaturePath = value
+		case AutomatedField:
+			if value == "" {
+				continue
+			}
+			automated, err := parseBool(value)
+			if err != nil {
+				r.Errors = append(r.Errors, fmt.Sprintf("row %d: '%s' is not a valid value for %s", r.Row, value, AutomatedField))
+			}
+			s.Automated = automated
+		case StepsField:
+			s.Steps = unflatten(value)
+		default:
+			if !strings.HasPrefix(field, CustomFieldPrefix) || value == "" {
+				continue
+			}
+			name := strings.TrimPrefix(field, CustomFieldPrefix)
+			if s.CustomFields == nil {
+				s.CustomFields = map[string]*customfieldv1.Value{}
+			}
+			s.CustomFields[name] = &customfieldv1.Value{Values: []string{value}}
+			if multiple[name] {
+				s.CustomFields[name].Values = split(value)
+			}
+		}
+	}
+}
+
+// readStep adds the step held by the step.* columns of the row, if any
+func (r *Record) readStep(row int, cell func(field string) string) {
+	step := &scenariov1.Step{
+		Name:            cell(StepNameField),
+		Action:          cell(StepActionField),
+		ExpectedOutcome: cell(StepExpectedOutcomeField),
+	}
+	if step.Name == "" && step.Action == "" && step.ExpectedOutcome == "" {
+		return
+	}
+	if step.Name == "" {
+		step.Name = firstLine(step.Action)
+	}
+	if step.Name == "" {
+		r.Errors = append(r.Errors, fmt.Sprintf("row %d: the step has neither a name nor an action", row))
+		return
+	}
+	step.Position = int32(len(r.Scenario.Steps) + 1)
+	r.Scenario.Steps = append(r.Scenario.Steps, step)
+}
+
+// Merge copies the values of the fields read from a CSV file to an existing scenario. The other values of the scenario are kept.
+// The blocks hold the steps of the step blocks referenced by the existing scenario, by ID, as they are inlined in the exported files; see MergeSteps
+func Merge(fields []string, from *scenariov1.Scenario, to *scenariov1.Scenario, blocks map[string][]*scenariov1.Step) {
+	stepFields := map[string]bool{}
+	for _, field := range fields {
+		switch field {
+		case NameField:
+			// the name identifies the scenario
+		case DescriptionField:
+			to.Description = from.Description
+		case PrerequisitesField:
+			to.Prerequisites = from.Prerequisites
+		case LabelsField:
+			to.Labels = from.Labels
+		case AutomatedField:
+			to.Automated = from.Automated
+		case AutomationKeysField:
+			to.AutomationKeys = from.AutomationKeys
+		case FeaturePathField:
+			to.FeaturePath = from.FeaturePath
+		case StepsField, StepNameField, StepActionField, StepExpectedOutcomeField:
+			stepFields[field] = true
+		default:
+			name := strings.TrimPrefix(field, CustomFieldPrefix)
+			value, ok := from.CustomFields[name]
+			if !ok {
+				delete(to.CustomFields, name)
+				continue
+			}
+			if to.CustomFields == nil {
+				to.CustomFields = map[string]*customfieldv1.Value{}
+			}
+			to.CustomFields[name] = value
+		}
+	}
+	if len(stepFields) != 0 {
+		to.Steps = mergeSteps(stepFields, from.Steps, to.Steps, blocks)
+	}
+}
+
+// mergeSteps returns the existing steps with the values of the step fields read from a CSV file, in which the step blocks are inlined.
+// The steps are matched by position. A step block is kept when the steps read at its position are its inlined steps, otherwise its steps are inlined with the edits.
+// Steps read beyond the existing ones are added and the existing steps that were not read are removed.
+func mergeSteps(fields map[string]bool, from []*scenariov1.Step, to []*scenariov1.Step, blocks map[string][]*scenariov1.Step) []*scenariov1.Step {
+	merged := []*scenariov1.Step{}
+	i := 0
+	for _, step := range to {
+		if step.StepBlockId == "" {
+			if i < len(from) {
+				merged = append(merged, mergeStep(fields, from[i], step))
+				i++
+			}
+			continue
+		}
+		inlined, ok := blocks[step.StepBlockId]
+		if !ok || (i+len(inlined) <= len(from) && unchanged(fields, from[i:i+len(inlined)], inlined)) {
+			merged = append(merged, proto.Clone(step).(*scenariov1.Step))
+			i += len(inlined)
+			continue
+		}
+		for _, blockStep := range inlined {
+			if i < len(from) {
+				merged = append(merged, mergeStep(fields, from[i], blockStep))
+				i++
+			}
+		}
+	}
+	for ; i < len(from); i++ {
+		merged = append(merged, proto.Clone(from[i]).(*scenariov1.Step))
+	}
+	for position, step := range merged {
+		step.Position = int32(position + 1)
+	}
+	return merged
+}
+
+// unchanged tells if the steps read from a CSV file hold the same values as the existing steps
+func unchanged(fields map[string]bool, from []*scenariov1.Step, to []*scenariov1.Step) bool {
+	for i, step := range to {
+		merged := mergeStep(fields, from[i], step)
+		merged.Position = step.Position
+		if !proto.Equal(merged, step) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeStep returns a copy of the existing step with the values of the step fields read from a CSV file
+func mergeStep(fields map[string]bool, from *scenariov1.Step, to *scenariov1.Step) *scenariov1.Step {
+	merged := proto.Clone(to).(*scenariov1.Step)
+	if fields[StepsField] {
+		// flattened steps hold the action, or the name of the steps without an action, and the steps read from them are named after their action
+		if action := flattenedAction(to); from.Action != action {
+			if to.Name == "" || to.Name == firstLine(action) {
+				merged.Name = from.Name
+			}
+			merged.Action = from.Action
+		}
+		merged.ExpectedOutcome = from.ExpectedOutcome
+	}
+	if fields[StepNameField] {
+		merged.Name = from.Name
+	}
+	if fields[StepActionField] {
+		merged.Action = from.Action
+	}
+	if fields[StepExpectedOutcomeField] {
+		merged.ExpectedOutcome = from.ExpectedOutcome
+	}
+	return merged
+}
+
+// WriteScenarios writes the scenarios as CSV, with one row per scenario and the steps flattened in the steps column,
+// or with one row per step when stepRows is set. The folders map the folder IDs to their paths and the fields are the names
+// of the custom fields that get a column.
+func WriteScenarios(w io.Writer, scenarios []*scenariov1.Scenario, folders map[string]string, fields []string, stepRows bool) error {
+	header := []string{IDField, NameField, DescriptionField, PrerequisitesField, FolderField, LabelsField, StateField, AutomatedField, AutomationKeysField, FeaturePathField}
+	for _, field := range fields {
+		header = append(header, CustomFieldPrefix+field)
+	}
+	if stepRows {
+		header = append(header, StepPositionField, StepNameField, StepActionField, StepExpectedOutcomeField)
+	} else {
+		header = append(header, StepsField)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, s := range scenarios {
+		row := []string{
+			s.GetIdentity().GetId(),
+			s.Name,
+			s.Description,
+			s.Prerequisites,
+			folders[s.FolderId],
+			strings.Join(s.Labels, separator),
+			s.State.String(),
+			strconv.FormatBool(s.Automated),
+			strings.Join(s.AutomationKeys, separator),
+			s.FeaturePath,
+		}
+		for _, field := range fields {
+			row = append(row, strings.Join(s.CustomFields[field].GetValues(), separator))
+		}
+		if !stepRows {
+			if err := writer.Write(append(row, flatten(s.Steps))); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(s.Steps) == 0 {
+			if err := writer.Write(append(row, "", "", "", "")); err != nil {
+				return err
+			}
+		}
+		for _, step := range s.Steps {
+			stepRow := append(append([]string{}, row...), strconv.Itoa(int(step.Position)), step.Name, step.Action, step.ExpectedOutcome)
+			if err := writer.Write(stepRow); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteExecutions writes the executions as CSV, one row per execution. The fields are the names of the custom fields that get a column
+func WriteExecutions(w io.Writer, executions []*executionv1.Execution, fields []string) error {
+	header := []string{IDField, NameField, "scenarioId", "testPlanId", "releaseId", "configuration", "status", "duration", "actualResult", LabelsField, "updatedBy", "updateTime"}
+	for _, field := range fields {
+		header = append(header, CustomFieldPrefix+field)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, e := range executions {
+		dimensions := make([]string, 0, len(e.Configuration))
+		for dimension, value := range e.Configuration {
+			dimensions = append(dimensions, dimension+"="+value)
+		}
+		sort.Strings(dimensions)
+		updateTime := ""
+		if e.GetIdentity().GetUpdateTime() != 0 {
+			updateTime = time.Unix(e.Identity.UpdateTime, 0).UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			e.GetIdentity().GetId(),
+			e.Name,
+			e.ScenarioId,
+			e.TestPlanId,
+			e.ReleaseId,
+			strings.Join(dimensions, separator),
+			e.Status.String(),
+			strconv.FormatFloat(e.Duration, 'f', -1, 64),
+			e.ActualResult,
+			strings.Join(e.Labels, separator),
+			e.GetIdentity().GetUpdatedBy(),
+			updateTime,
+		}
+		for _, field := range fields {
+			row = append(row, strings.Join(e.CustomFields[field].GetValues(), separator))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// flatten writes each step as a `<position>. <action>` line followed by its expected outcome on lines starting with `=> `
+func flatten(steps []*scenariov1.Step) string {
+	lines := []string{}
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, flattenedAction(step)))
+		if step.ExpectedOutcome == "" {
+			continue
+		}
+		for _, outcome := range strings.Split(step.ExpectedOutcome, "\n") {
+			lines = append(lines, outcomePrefix+outcome)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// flattenedAction returns the action written by flatten for the step: its action, or its name when it has no action
+func flattenedAction(step *scenariov1.Step) string {
+	if step.Action == "" {
+		return step.Name
+	}
+	return step.Action
+}
+
+// firstLine returns the first line of a text, which names the steps read from a CSV file without a name
+func firstLine(text string) string {
+	return strings.SplitN(text, "\n", 2)[0]
+}
+
+// unflatten reads the steps written by flatten. Lines that do not start a step nor hold an expected outcome continue the action of the step
+func unflatten(cell string) []*scenariov1.Step {
+	steps := []*scenariov1.Step{}
+	var current *scenariov1.Step
+	for _, line := range strings.Split(cell, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if match := stepStart.FindStringSubmatch(line); match != nil || current == nil {
+			text := strings.TrimSpace(line)
+			if match != nil {
+				text = strings.TrimSpace(match[1])
+			}
+			current = &scenariov1.Step{Position: int32(len(steps) + 1), Name: text, Action: text}
+			steps = append(steps, current)
+			continue
+		}
+		if strings.HasPrefix(line, strings.TrimSpace(outcomePrefix)) {
+			outcome := strings.TrimSpace(strings.TrimPrefix(line, strings.TrimSpace(outcomePrefix)))
+			current.ExpectedOutcome = strings.TrimPrefix(current.ExpectedOutcome+"\n"+outcome, "\n")
+			continue
+		}
+		current.Action += "\n" + line
+	}
+	return steps
+}
+
+// parseBool reads a boolean cell, which spreadsheets often hold as yes or no
+func parseBool(cell string) (bool, error) {
+	switch strings.ToLower(cell) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(cell)
+}
+
+// split returns the values of a cell holding a list
+func split(cell string) []string {
+	values := []string{}
+	for _, value := range strings.Split(cell, separator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,169 @@
+package spreadsheet_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	customfield "github.com/curious-kitten/scratch-post/pkg/api/v1/customfield"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/spreadsheet"
+)
+
+const stepSheet = "\ufeffname,description,automated,step.name,step.action,step.expectedOutcome,customFields.platforms,customFields.owner\n" +
+	"login,Log into the shop,yes,,the user logs in,the dashboard is shown,web;mobile,john;jane\n" +
+	",,,,the user logs out,the login page is shown,,\n" +
+	"login,,,,,,,\n" +
+	"logout,,maybe,,,,,\n" +
+	"login,,,,,,,\n"
+
+func TestRead(t *testing.T) {
+	g := NewWithT(t)
+	definitions := []*customfield.Definition{{Name: "platforms", Type: customfield.FieldType_MultiSelect}, {Name: "owner"}}
+	records, fields, err := spreadsheet.Read(strings.NewReader(stepSheet), nil, definitions)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(fields).To(ConsistOf("name", "description", "automated", "step.name", "step.action", "step.expectedOutcome", "customFields.platforms", "customFields.owner"))
+	g.Expect(records).To(HaveLen(3), "rows were not grouped by scenario")
+
+	login := records[0]
+	g.Expect(login.Row).To(Equal(2))
+	g.Expect(login.Errors).To(BeEmpty())
+	g.Expect(login.Scenario.Name).To(Equal("login"), "byte order mark was not removed from the header")
+	g.Expect(login.Scenario.Description).To(Equal("Log into the shop"))
+	g.Expect(login.Scenario.Steps).To(HaveLen(2), "rows without a name did not continue the scenario")
+	g.Expect(login.Scenario.Steps[1].Position).To(Equal(int32(2)))
+	g.Expect(login.Scenario.Steps[1].Name).To(Equal("the user logs out"), "step is not named after its action")
+	g.Expect(login.Scenario.CustomFields["platforms"].Values).To(Equal([]string{"web", "mobile"}), "multi select field was not split")
+	g.Expect(login.Scenario.CustomFields["owner"].Values).To(Equal([]string{"john;jane"}), "single value field was split")
+
+	g.Expect(records[1].Errors).To(ConsistOf("row 5: 'maybe' is not a valid value for automated"))
+	g.Expect(records[2].Errors).To(ConsistOf("scenario 'login' is already defined on row 2"))
+}
+
+func TestRead_Mapping(t *testing.T) {
+	g := NewWithT(t)
+	content := "Title,Steps\nlogin,\"1. the user logs in\n   with a password\n=> the dashboard is shown\n=> a welcome message is shown\n2. the user logs out\"\n"
+	records, fields, err := spreadsheet.Read(strings.NewReader(content), map[string]string{"Title": "name", "Steps": "steps"}, nil)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(fields).To(Equal([]string{"name", "steps"}))
+	steps := records[0].Scenario.Steps
+	g.Expect(steps).To(HaveLen(2), "flattened steps were not read")
+	g.Expect(steps[0].Action).To(Equal("the user logs in\n   with a password"))
+	g.Expect(steps[0].ExpectedOutcome).To(Equal("the dashboard is shown\na welcome message is shown"))
+	g.Expect(steps[1].Name).To(Equal("the user logs out"))
+}
+
+func TestRead_InvalidMapping(t *testing.T) {
+	g := NewWithT(t)
+	for _, mapping := range []map[string]string{
+		{"Title": "title"},
+		{"Name": "name"},
+		{"Title": "name", "Steps": "steps", "Action": "step.action"},
+		{"Steps": "steps"},
+	} {
+		_, _, err := spreadsheet.Read(strings.NewReader("Title,Steps,Action\n"), mapping, nil)
+		g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid mapping %v is not a validation error", mapping)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	g := NewWithT(t)
+	from := &scenario.Scenario{Name: "login", Description: "new", CustomFields: map[string]*customfield.Value{"owner": {Values: []string{"jane"}}}}
+	to := &scenario.Scenario{
+		Name:          "login",
+		Description:   "old",
+		Prerequisites: "kept",
+		Steps:         []*scenario.Step{{Position: 1, Name: "replaced"}},
+		CustomFields:  map[string]*customfield.Value{"owner": {Values: []string{"john"}}, "priority": {Values: []string{"high"}}},
+	}
+	spreadsheet.Merge([]string{"name", "description", "step.action", "customFields.owner", "customFields.priority"}, from, to, nil)
+	g.Expect(to.Description).To(Equal("new"))
+	g.Expect(to.Prerequisites).To(Equal("kept"), "unmapped field was changed")
+	g.Expect(to.Steps).To(BeEmpty(), "steps were not replaced")
+	g.Expect(to.CustomFields).To(HaveLen(1), "empty custom field was not removed")
+	g.Expect(to.CustomFields["owner"].Values).To(Equal([]string{"jane"}))
+}
+
+func TestMerge_Steps(t *testing.T) {
+	g := NewWithT(t)
+	blocks := map[string][]*scenario.Step{"block": {{Position: 1, Name: "open", Action: "the shop is opened"}}}
+	to := &scenario.Scenario{Steps: []*scenario.Step{
+		{Position: 1, StepBlockId: "block"},
+		{Position: 2, Name: "log in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"},
+		{Position: 3, Name: "log out"},
+	}}
+	from := &scenario.Scenario{Steps: []*scenario.Step{
+		{Position: 1, Name: "the shop is opened", Action: "the shop is opened"},
+		{Position: 2, Name: "the user logs in", Action: "the user logs in", ExpectedOutcome: "the cart is shown"},
+		{Position: 3, Name: "log out", Action: "log out"},
+		{Position: 4, Name: "the user closes the shop", Action: "the user closes the shop"},
+	}}
+	spreadsheet.Merge([]string{"steps"}, from, to, blocks)
+	g.Expect(to.Steps).To(HaveLen(4))
+	g.Expect(to.Steps[0].StepBlockId).To(Equal("block"), "unchanged step block was inlined")
+	g.Expect(to.Steps[1].Name).To(Equal("log in"), "name of the step was overwritten")
+	g.Expect(to.Steps[1].ExpectedOutcome).To(Equal("the cart is shown"), "expected outcome was not updated")
+	g.Expect(to.Steps[2].Action).To(BeEmpty(), "name of a step without an action became its action")
+	g.Expect(to.Steps[3].Position).To(Equal(int32(4)), "new step was not added")
+
+	from.Steps[0].Action = "the shop is opened on mobile"
+	spreadsheet.Merge([]string{"steps"}, from, to, blocks)
+	g.Expect(to.Steps[0].StepBlockId).To(BeEmpty(), "edited step block was not inlined")
+	g.Expect(to.Steps[0].Name).To(Equal("open"), "name of the inlined step was overwritten")
+	g.Expect(to.Steps[0].Action).To(Equal("the shop is opened on mobile"))
+}
+
+func TestWriteScenarios_RoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	scenarios := []*scenario.Scenario{{
+		Identity:       &metadata.Identity{Id: "login"},
+		Name:           "login",
+		Labels:         []string{"web", "smoke"},
+		AutomationKeys: []string{"shop.TestLogin"},
+		CustomFields:   map[string]*customfield.Value{"owner": {Values: []string{"john"}}},
+		Steps: []*scenario.Step{
+			{Position: 1, Name: "log in", Action: "the user logs in", ExpectedOutcome: "the dashboard is shown"},
+			{Position: 2, Name: "log out", Action: "the user logs out"},
+		},
+	}}
+	for _, stepRows := range []bool{false, true} {
+		file := &bytes.Buffer{}
+		g.Expect(spreadsheet.WriteScenarios(file, scenarios, nil, []string{"owner"}, stepRows)).To(Succeed())
+		records, _, err := spreadsheet.Read(file, nil, nil)
+		g.Expect(err).ShouldNot(HaveOccurred(), "exported file could not be read")
+		g.Expect(records).To(HaveLen(1))
+		read := records[0].Scenario
+		g.Expect(read.Labels).To(Equal(scenarios[0].Labels))
+		g.Expect(read.AutomationKeys).To(Equal(scenarios[0].AutomationKeys))
+		g.Expect(read.CustomFields["owner"].Values).To(Equal([]string{"john"}))
+		g.Expect(read.Steps).To(HaveLen(2), "steps were not exported")
+		g.Expect(read.Steps[0].Action).To(Equal("the user logs in"))
+		g.Expect(read.Steps[0].ExpectedOutcome).To(Equal("the dashboard is shown"))
+	}
+}
+
+func TestWriteExecutions(t *testing.T) {
+	g := NewWithT(t)
+	executions := []*execution.Execution{{
+		Identity:      &metadata.Identity{Id: "execution", UpdatedBy: "tester", UpdateTime: 1614610085},
+		Name:          "login",
+		ScenarioId:    "scenario",
+		TestPlanId:    "plan",
+		Status:        execution.Status_Fail,
+		Configuration: map[string]string{"os": "linux", "browser": "chrome"},
+		Duration:      1.5,
+		ActualResult:  "dashboard not found",
+		CustomFields:  map[string]*customfield.Value{"owner": {Values: []string{"john"}}},
+	}}
+	file := &bytes.Buffer{}
+	g.Expect(spreadsheet.WriteExecutions(file, executions, []string{"owner"})).To(Succeed())
+	g.Expect(file.String()).To(Equal(
+		"identity.id,name,scenarioId,testPlanId,releaseId,configuration,status,duration,actualResult,labels,updatedBy,updateTime,customFields.owner\n" +
+			"execution,login,scenario,plan,,browser=chrome;os=linux,Fail,1.5,dashboard not found,,tester,2021-03-01T14:48:05Z,john\n",
+	))
+}
@@ -0,0 +1,195 @@
+package stepblocks
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	stepblockv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/stepblock"
+)
+
+//go:generate mockgen -source ./stepblocks.go -destination mocks/stepblocks.go
+
+const (
+	// ScenarioFilterKey is the filter used to find the scenarios that reference a step block
+	ScenarioFilterKey = "steps.stepblockid"
+	// ProjectFilterKey is the filter used to find the step blocks of a project
+	ProjectFilterKey = "projectid"
+	// NameFilterKey is the filter used to find step blocks by name
+	NameFilterKey = "name"
+)
+
+type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type scenarioLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+
+// MetaHandler handles metadata information
+type MetaHandler interface {
+	NewMeta(author string, objType string) (*metadatav1.Identity, error)
+	UpdateMeta(author string, identity *metadatav1.Identity)
+}
+
+// Adder is used to add items to the store
+type Adder interface {
+	AddOne(ctx context.Context, item interface{}) error
+}
+
+// Getter is used to retrieve items from the store
+type Getter interface {
+	Get(ctx context.Context, id string, item interface{}) error
+	GetAll(ctx context.Context, items interface{}, filterMap map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) error
+}
+
+// Deleter deletes an entry from the collection
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+// Updater is used to replace information into the Data Base
+type Updater interface {
+	Update(ctx context.Context, id string, item interface{}) error
+}
+
+// ReaderUpdater is used to read and update objects in the Data Base
+type ReaderUpdater interface {
+	Getter
+	Updater
+}
+
+// New returns a function used to create a step block
+func New(meta MetaHandler, collection Adder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+		block := &stepblockv1.StepBlock{}
+		if err := decoder.Decode(block, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, block.ProjectId); err != nil {
+			return nil, err
+		}
+		identity, err := meta.NewMeta(author, "stepblock")
+		if err != nil {
+			return nil, err
+		}
+		block.Identity = identity
+		if err := collection.AddOne(ctx, block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+}
+
+// List returns a function used to return the step blocks
+func List(collection Getter) func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		blocks := []stepblockv1.StepBlock{}
+		err := collection.GetAll(ctx, &blocks, filter, sortBy, reverse, count, previousLastValue)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(blocks))
+		for i := range blocks {
+			items[i] = proto.Clone(&blocks[i]).(*stepblockv1.StepBlock)
+		}
+		return items, nil
+	}
+}
+
+// Get returns a function to retrieve a step block based on the passed ID
+func Get(collection Getter) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		block := &stepblockv1.StepBlock{}
+		if err := collection.Get(ctx, id, block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+}
+
+// Delete returns a function to delete a step block based on the passed ID.
+// A step block that is still referenced by scenarios cannot be deleted.
+func Delete(collection Deleter, listScenarios scenarioLister) func(ctx context.Context, id string) error {
+	return func(ctx context.Context, id string) error {
+		scenarios, err := listScenarios(ctx, map[string][]string{ScenarioFilterKey: {id}}, "", false, 1, "")
+		if err != nil {
+			return err
+		}
+		if len(scenarios) != 0 {
+			return decoder.NewValidationError(fmt.Sprintf("step block '%s' is used by scenarios", id))
+		}
+		if err := collection.Delete(ctx, id); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// Update is used to replace a step block with the provided step block.
+// Executions created after the update will use the new steps.
+// The step block cannot be moved to another project, as the scenarios of its project reference it.
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		block := &stepblockv1.StepBlock{}
+		if err := decoder.Decode(block, data); err != nil {
+			return nil, err
+		}
+		if _, err := getProject(ctx, block.ProjectId); err != nil {
+			return nil, err
+		}
+		foundBlock, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		var b *stepblockv1.StepBlock
+		var ok bool
+		if b, ok = foundBlock.(*stepblockv1.StepBlock); !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		if b.ProjectId != block.ProjectId {
+			return nil, decoder.NewValidationError("a step block cannot be moved to another project")
+		}
+		block.Identity = b.Identity
+		meta.UpdateMeta(user, block.Identity)
+		if err := collection.Update(ctx, id, block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+}
+
+// Scenarios returns a function used to retrieve the scenarios that reference a step block
+func Scenarios(collection Getter, listScenarios scenarioLister) func(ctx context.Context, id string) ([]interface{}, error) {
+	return func(ctx context.Context, id string) ([]interface{}, error) {
+		if _, err := Get(collection)(ctx, id); err != nil {
+			return nil, err
+		}
+		return listScenarios(ctx, map[string][]string{ScenarioFilterKey: {id}}, "", false, 0, "")
+	}
+}
+
+// Steps returns a function used to retrieve the steps of the referenced step blocks.
+// The steps are returned in a map where the key is the ID of the step block.
+// All step blocks need to belong to the provided project.
+func Steps(getBlock func(ctx context.Context, id string) (interface{}, error)) func(ctx context.Context, projectID string, ids []string) (map[string][]*scenariov1.Step, error) {
+	return func(ctx context.Context, projectID string, ids []string) (map[string][]*scenariov1.Step, error) {
+		steps := map[string][]*scenariov1.Step{}
+		for _, id := range ids {
+			raw, err := getBlock(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			block, ok := raw.(*stepblockv1.StepBlock)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for step block %s", id)
+			}
+			if block.ProjectId != projectID {
+				return nil, decoder.NewValidationError(fmt.Sprintf("step block '%s' is not part of project '%s'", id, projectID))
+			}
+			steps[id] = block.Steps
+		}
+		return steps, nil
+	}
+}
@@ -0,0 +1,322 @@
+package stepblocks_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/internal/test/matchers"
+	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	stepblock "github.com/curious-kitten/scratch-post/pkg/api/v1/stepblock"
+	"github.com/curious-kitten/scratch-post/pkg/stepblocks"
+	mockStepBlocks "github.com/curious-kitten/scratch-post/pkg/stepblocks/mocks"
+)
+
+var (
+	sortBy            = ""
+	reverse           = false
+	count             = 1000
+	previousLastValue = ""
+	identity          = metadata.Identity{
+		Id:           "aabbccddee",
+		Type:         "stepblock",
+		Version:      1,
+		CreatedBy:    "author",
+		UpdatedBy:    "author",
+		CreationTime: time.Now().Unix(),
+		UpdateTime:   time.Now().Unix(),
+	}
+
+	testStepBlock = &stepblock.StepBlock{
+		Name:      "login as admin",
+		ProjectId: "zzxxxccvv",
+		Steps: []*scenario.Step{
+			{
+				Position: 1,
+				Name:     "login",
+			},
+		},
+	}
+)
+
+func goodGetProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, nil
+}
+
+func errorGetProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, fmt.Errorf("an error")
+}
+
+func noProject(ctx context.Context, id string) (interface{}, error) {
+	return nil, mongo.ErrNoDocuments
+}
+
+func noScenarios(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return []interface{}{}, nil
+}
+
+func usedByScenario(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+	return []interface{}{&scenario.Scenario{Name: "test scenario"}}, nil
+}
+
+func TestStepBlock_Validate(t *testing.T) {
+	g := NewWithT(t)
+	s := &stepblock.StepBlock{}
+	err := s.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with empty step block")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "empty step block error is not a validation error")
+	s.Name = "Test Name"
+	s.ProjectId = "aabbccdd"
+	err = s.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with step block without steps")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "step block without steps error is not a validation error")
+	s.Steps = []*scenario.Step{{Position: 1, StepBlockId: "other"}}
+	err = s.Validate()
+	g.Expect(err).Should(HaveOccurred(), "No error with step block that references another step block")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "nested step block error is not a validation error")
+	s.Steps = []*scenario.Step{{Position: 1, Name: "login"}}
+	err = s.Validate()
+	g.Expect(err).ShouldNot(HaveOccurred(), "error occurred when minimun requirements have been met")
+}
+
+func TestNew_Create(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "stepblock").
+		Return(&identity, nil)
+	mockAdder := mockStepBlocks.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&stepblock.StepBlock{})).
+		Return(nil)
+
+	creator := stepblocks.New(mockMetaHandler, mockAdder, goodGetProject)
+	createdBlock, err := creator(ctx, "tester", transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(createdBlock.(*stepblock.StepBlock).Identity).To(Equal(&identity), "identity was not set")
+}
+
+func TestNew_ProjectNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	mockAdder := mockStepBlocks.NewMockAdder(ctrl)
+	creator := stepblocks.New(mockMetaHandler, mockAdder, noProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+}
+
+func TestNew_ValidationError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	mockAdder := mockStepBlocks.NewMockAdder(ctrl)
+	creator := stepblocks.New(mockMetaHandler, mockAdder, errorGetProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(&stepblock.StepBlock{}))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
+}
+
+func TestNew_AddToCollectionError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "stepblock").
+		Return(&identity, nil)
+	mockAdder := mockStepBlocks.NewMockAdder(ctrl)
+	mockAdder.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&stepblock.StepBlock{})).
+		Return(fmt.Errorf("expected error"))
+
+	creator := stepblocks.New(mockMetaHandler, mockAdder, goodGetProject)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+}
+
+func TestList(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockStepBlocks.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]stepblock.StepBlock{}), map[string][]string{}, sortBy, reverse, count, previousLastValue).
+		Return(nil)
+
+	lister := stepblocks.List(mockGetter)
+	_, err := lister(ctx, map[string][]string{}, sortBy, reverse, count, previousLastValue)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+}
+
+func TestGet_Error(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockStepBlocks.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&stepblock.StepBlock{})).
+		Return(fmt.Errorf("expected error"))
+
+	getter := stepblocks.Get(mockGetter)
+	_, err := getter(ctx, identity.Id)
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+}
+
+func TestDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockDeleter := mockStepBlocks.NewMockDeleter(ctrl)
+	mockDeleter.
+		EXPECT().
+		Delete(ctx, identity.Id).
+		Return(nil)
+	deleter := stepblocks.Delete(mockDeleter, noScenarios)
+	err := deleter(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+}
+
+func TestDelete_InUse(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockDeleter := mockStepBlocks.NewMockDeleter(ctrl)
+	deleter := stepblocks.Delete(mockDeleter, usedByScenario)
+	err := deleter(ctx, identity.Id)
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "deleting a used step block is not a validation error")
+}
+
+func TestUpdate(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockStepBlocks.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&stepblock.StepBlock{})).
+		Do(func(ctx context.Context, id string, b *stepblock.StepBlock) {
+			b.Identity = &identity
+			b.ProjectId = testStepBlock.ProjectId
+		})
+	mockReaderUpdater.
+		EXPECT().
+		Update(ctx, identity.Id, matchers.OfType(&stepblock.StepBlock{}))
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
+	updater := stepblocks.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updatedBlock, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(updatedBlock.(*stepblock.StepBlock).Identity).To(Equal(&identity), "identity was not kept")
+}
+
+func TestUpdate_InvalidProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockStepBlocks.NewMockReaderUpdater(ctrl)
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	updater := stepblocks.Update(mockMetaHandler, mockReaderUpdater, noProject)
+	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+}
+
+func TestUpdate_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderUpdater := mockStepBlocks.NewMockReaderUpdater(ctrl)
+	mockReaderUpdater.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&stepblock.StepBlock{})).
+		Do(func(ctx context.Context, id string, b *stepblock.StepBlock) {
+			b.Identity = &identity
+			b.ProjectId = "other"
+		})
+	mockMetaHandler := mockStepBlocks.NewMockMetaHandler(ctrl)
+	updater := stepblocks.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testStepBlock))
+	g.Expect(err).Should(HaveOccurred(), "step block was moved to another project")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "moving a step block is not a validation error")
+}
+
+func TestStepBlock_UniqueKeys(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(testStepBlock)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the unique index of the names within a project is built on the filter keys
+	g.Expect(bson.Raw(stored).Lookup(stepblocks.ProjectFilterKey).StringValue()).To(Equal(testStepBlock.ProjectId), "project filter key is not the stored field")
+	g.Expect(bson.Raw(stored).Lookup(stepblocks.NameFilterKey).StringValue()).To(Equal(testStepBlock.Name), "name filter key is not the stored field")
+}
+
+func TestScenarios(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mockStepBlocks.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&stepblock.StepBlock{})).
+		Return(nil)
+	var usedFilter map[string][]string
+	listScenarios := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		usedFilter = filter
+		return usedByScenario(ctx, filter, sortBy, reverse, count, previousLastValue)
+	}
+	items, err := stepblocks.Scenarios(mockGetter, listScenarios)(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(items).To(HaveLen(1), "scenarios using the step block were not returned")
+	g.Expect(usedFilter).To(Equal(map[string][]string{stepblocks.ScenarioFilterKey: {identity.Id}}), "scenarios were not filtered by step block")
+}
+
+func TestSteps(t *testing.T) {
+	g := NewWithT(t)
+	getBlock := func(ctx context.Context, id string) (interface{}, error) {
+		return testStepBlock, nil
+	}
+	steps, err := stepblocks.Steps(getBlock)(context.Background(), testStepBlock.ProjectId, []string{identity.Id})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(steps).To(HaveKeyWithValue(identity.Id, testStepBlock.Steps), "steps were not returned")
+}
+
+func TestSteps_OtherProject(t *testing.T) {
+	g := NewWithT(t)
+	getBlock := func(ctx context.Context, id string) (interface{}, error) {
+		return testStepBlock, nil
+	}
+	_, err := stepblocks.Steps(getBlock)(context.Background(), "other project", []string{identity.Id})
+	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "step block from another project is not a validation error")
+}
@@ -0,0 +1,108 @@
+package tap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+)
+
+// testLine matches a test point, ie. `not ok 2 - add to cart # TODO not implemented`
+var testLine = regexp.MustCompile(`^(not ok|ok)\b\s*(\d*)\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(.*))?$`)
+
+// Parse reads the test points of a TAP (Test Anything Protocol) stream. Every test point is a result.
+//
+// The automation key of a test is its description, or `test <number>` when it has none.
+// Tests marked with a SKIP directive and failed tests marked with a TODO directive are skipped.
+// The YAML block or the comments that follow a failed test are its actual result.
+// Indented lines, used by subtests, are ignored and the stream ends when the tests bail out.
+func Parse(content io.Reader) ([]*executionv1.TestResult, error) {
+	results := []*executionv1.TestResult{}
+	var last *executionv1.TestResult
+	var diagnostics []string
+	inYAML := false
+	flush := func() {
+		if last != nil && last.Status == executionv1.Status_Fail && len(diagnostics) != 0 {
+			last.ActualResult = strings.Join(diagnostics, "\n")
+		}
+		diagnostics = nil
+	}
+
+	reader := bufio.NewScanner(content)
+	reader.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for reader.Scan() {
+		lineNumber++
+		raw := reader.Text()
+		line := strings.TrimSpace(raw)
+		switch {
+		case inYAML:
+			if line == "..." {
+				inYAML = false
+				continue
+			}
+			diagnostics = append(diagnostics, line)
+			continue
+		case line == "---" && last != nil && raw != line:
+			inYAML = true
+			continue
+		case raw != strings.TrimLeft(raw, " \t"):
+			// subtests are indented
+			continue
+		case strings.HasPrefix(line, "Bail out!"):
+			flush()
+			return results, nil
+		case strings.HasPrefix(line, "# Subtest"):
+			continue
+		case strings.HasPrefix(line, "#"):
+			diagnostics = append(diagnostics, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			continue
+		}
+		match := testLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		flush()
+		last = &executionv1.TestResult{Name: match[3], Status: executionv1.Status_Pass}
+		if match[1] == "not ok" {
+			last.Status = executionv1.Status_Fail
+		}
+		directive, reason := splitDirective(match[4])
+		switch {
+		case strings.HasPrefix(directive, "SKIP"):
+			last.Status = executionv1.Status_Skipped
+			last.ActualResult = reason
+		case directive == "TODO" && last.Status == executionv1.Status_Fail:
+			last.Status = executionv1.Status_Skipped
+			last.ActualResult = reason
+		}
+		last.Key = last.Name
+		if last.Key == "" {
+			if match[2] == "" {
+				return nil, decoder.NewValidationError(fmt.Sprintf("invalid TAP stream: test on line %d has neither a number nor a description", lineNumber))
+			}
+			last.Key = "test " + match[2]
+			last.Name = last.Key
+		}
+		results = append(results, last)
+	}
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return results, nil
+}
+
+// splitDirective separates the directive of a test, in upper case, from its reason
+func splitDirective(comment string) (string, string) {
+	fields := strings.SplitN(strings.TrimSpace(comment), " ", 2)
+	directive := strings.ToUpper(fields[0])
+	if len(fields) == 1 {
+		return directive, ""
+	}
+	return directive, strings.TrimSpace(fields[1])
+}
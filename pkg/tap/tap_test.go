@@ -0,0 +1,58 @@
+package tap_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	"github.com/curious-kitten/scratch-post/pkg/tap"
+)
+
+const stream = `TAP version 13
+1..6
+ok 1 - cart is empty
+not ok 2 - add to cart
+  ---
+  message: expected 1 product, got 0
+  severity: fail
+  ...
+ok 3 - checkout # SKIP no payment provider
+not ok 4 - discounts # TODO not implemented
+# Subtest: remove
+    ok 1 - remove one
+    not ok 2 - remove all
+ok 5 - remove
+not ok 6
+# timeout after 30s
+Bail out! database is down
+ok 7 - never reported
+`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	results, err := tap.Parse(strings.NewReader(stream))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(results).To(HaveLen(6), "every test point before bailing out is not a result")
+
+	g.Expect(results[0].Key).To(Equal("cart is empty"), "key is not the description")
+	g.Expect(results[0].Status).To(Equal(execution.Status_Pass))
+	g.Expect(results[1].Status).To(Equal(execution.Status_Fail))
+	g.Expect(results[1].ActualResult).To(Equal("message: expected 1 product, got 0\nseverity: fail"), "YAML block is not the actual result")
+	g.Expect(results[2].Key).To(Equal("checkout"), "directive is part of the key")
+	g.Expect(results[2].Status).To(Equal(execution.Status_Skipped))
+	g.Expect(results[2].ActualResult).To(Equal("no payment provider"))
+	g.Expect(results[3].Status).To(Equal(execution.Status_Skipped), "failed TODO test was not skipped")
+	g.Expect(results[4].Key).To(Equal("remove"), "subtests were not ignored")
+	g.Expect(results[4].Status).To(Equal(execution.Status_Pass))
+	g.Expect(results[5].Key).To(Equal("test 6"), "test without description is not named after its number")
+	g.Expect(results[5].ActualResult).To(Equal("timeout after 30s"), "diagnostics are not the actual result")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := tap.Parse(strings.NewReader("ok\n"))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "test without number or description is not a validation error")
+}
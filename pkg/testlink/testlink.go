@@ -0,0 +1,121 @@
+package testlink
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/migration"
+)
+
+// automatedExecution is the execution type of automated test cases
+const automatedExecution = 2
+
+type suite struct {
+	XMLName xml.Name
+	Name    string     `xml:"name,attr"`
+	Details string     `xml:"details"`
+	Suites  []suite    `xml:"testsuite"`
+	Cases   []testCase `xml:"testcase"`
+}
+
+type testCase struct {
+	InternalID    string    `xml:"internalid,attr"`
+	Name          string    `xml:"name,attr"`
+	ExternalID    string    `xml:"externalid"`
+	Summary       string    `xml:"summary"`
+	Preconditions string    `xml:"preconditions"`
+	ExecutionType int       `xml:"execution_type"`
+	Steps         []step    `xml:"steps>step"`
+	Keywords      []keyword `xml:"keywords>keyword"`
+}
+
+type step struct {
+	Number          int    `xml:"step_number"`
+	Actions         string `xml:"actions"`
+	ExpectedResults string `xml:"expectedresults"`
+}
+
+type keyword struct {
+	Name string `xml:"name,attr"`
+}
+
+var (
+	// lineBreaks matches the HTML elements that end a line of text
+	lineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</div>|</tr>`)
+	tags       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Parse reads a TestLink XML export of a test suite or of a list of test cases.
+// The test suites become folders and the test cases scenarios, identified by their external ID, or their internal ID when it is not exported.
+// The summary becomes the description, the keywords become labels and automated test cases are marked as automated.
+// The HTML of the texts is converted to plain text.
+func Parse(content io.Reader) (*migration.Folder, error) {
+	root := suite{}
+	if err := xml.NewDecoder(content).Decode(&root); err != nil {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid TestLink export: %s", err))
+	}
+	if root.XMLName.Local != "testsuite" && root.XMLName.Local != "testcases" {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid TestLink export: unexpected element <%s>", root.XMLName.Local))
+	}
+	return convert(root), nil
+}
+
+func convert(s suite) *migration.Folder {
+	folder := &migration.Folder{Name: strings.TrimSpace(s.Name), Description: text(s.Details)}
+	for _, sub := range s.Suites {
+		folder.Folders = append(folder.Folders, convert(sub))
+	}
+	for _, c := range s.Cases {
+		id := strings.TrimSpace(c.ExternalID)
+		if id == "" {
+			id = c.InternalID
+		}
+		scenario := &scenariov1.Scenario{
+			Name:          strings.TrimSpace(c.Name),
+			Description:   text(c.Summary),
+			Prerequisites: text(c.Preconditions),
+			Automated:     c.ExecutionType == automatedExecution,
+		}
+		for _, k := range c.Keywords {
+			scenario.Labels = append(scenario.Labels, strings.TrimSpace(k.Name))
+		}
+		sort.SliceStable(c.Steps, func(i, j int) bool { return c.Steps[i].Number < c.Steps[j].Number })
+		for i, s := range c.Steps {
+			action := text(s.Actions)
+			name := strings.SplitN(action, "\n", 2)[0]
+			if name == "" {
+				name = fmt.Sprintf("Step %d", i+1)
+			}
+			scenario.Steps = append(scenario.Steps, &scenariov1.Step{
+				Position:        int32(i + 1),
+				Name:            name,
+				Action:          action,
+				ExpectedOutcome: text(s.ExpectedResults),
+			})
+		}
+		folder.Cases = append(folder.Cases, &migration.Case{ID: id, Scenario: scenario})
+	}
+	return folder
+}
+
+// text converts the HTML used by TestLink to plain text
+func text(content string) string {
+	content = lineBreaks.ReplaceAllString(content, "\n")
+	content = html.UnescapeString(tags.ReplaceAllString(content, ""))
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\u00a0", " "))
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,83 @@
+package testlink_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/pkg/testlink"
+)
+
+const export = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite id="1" name="Shop">
+  <details><![CDATA[<p>Web shop</p>]]></details>
+  <testsuite id="2" name="Cart">
+    <testcase internalid="10" name="Add a product">
+      <externalid>12</externalid>
+      <summary><![CDATA[<p>Adds a product&nbsp;to the cart</p><p>Second line</p>]]></summary>
+      <preconditions><![CDATA[The user is logged in]]></preconditions>
+      <execution_type><![CDATA[2]]></execution_type>
+      <steps>
+        <step>
+          <step_number><![CDATA[2]]></step_number>
+          <actions><![CDATA[<p>Open the cart</p>]]></actions>
+          <expectedresults><![CDATA[<p>The product is in the cart</p>]]></expectedresults>
+        </step>
+        <step>
+          <step_number><![CDATA[1]]></step_number>
+          <actions><![CDATA[<p>Click &quot;Add&quot;<br/>on the product page</p>]]></actions>
+          <expectedresults></expectedresults>
+        </step>
+      </steps>
+      <keywords>
+        <keyword name="smoke"><notes></notes></keyword>
+      </keywords>
+    </testcase>
+  </testsuite>
+  <testcase internalid="11" name="Search">
+    <execution_type>1</execution_type>
+  </testcase>
+</testsuite>`
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	root, err := testlink.Parse(strings.NewReader(export))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(root.Name).To(Equal("Shop"))
+	g.Expect(root.Description).To(Equal("Web shop"), "HTML was not converted to text")
+	g.Expect(root.Cases).To(HaveLen(1))
+	g.Expect(root.Cases[0].ID).To(Equal("11"), "internal ID is not used when there is no external ID")
+	g.Expect(root.Cases[0].Scenario.Automated).To(BeFalse())
+	g.Expect(root.Folders).To(HaveLen(1), "test suite did not become a folder")
+
+	c := root.Folders[0].Cases[0]
+	g.Expect(c.ID).To(Equal("12"), "external ID was not used")
+	scenario := c.Scenario
+	g.Expect(scenario.Name).To(Equal("Add a product"))
+	g.Expect(scenario.Description).To(Equal("Adds a product to the cart\nSecond line"))
+	g.Expect(scenario.Prerequisites).To(Equal("The user is logged in"))
+	g.Expect(scenario.Automated).To(BeTrue(), "automated test case is not automated")
+	g.Expect(scenario.Labels).To(Equal([]string{"smoke"}), "keywords did not become labels")
+	g.Expect(scenario.Steps).To(HaveLen(2))
+	g.Expect(scenario.Steps[0].Position).To(Equal(int32(1)))
+	g.Expect(scenario.Steps[0].Name).To(Equal(`Click "Add"`), "steps were not ordered or named after their first line")
+	g.Expect(scenario.Steps[0].Action).To(Equal("Click \"Add\"\non the product page"))
+	g.Expect(scenario.Steps[1].ExpectedOutcome).To(Equal("The product is in the cart"))
+}
+
+func TestParse_TestCases(t *testing.T) {
+	g := NewWithT(t)
+	root, err := testlink.Parse(strings.NewReader(`<testcases><testcase internalid="1" name="Login"/></testcases>`))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(root.Cases).To(HaveLen(1), "test cases export was not read")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := testlink.Parse(strings.NewReader(`<testsuite>`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid XML is not a validation error")
+	_, err = testlink.Parse(strings.NewReader(`<requirements/>`))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "other export is not a validation error")
+}
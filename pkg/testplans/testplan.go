@@ -1,20 +1,43 @@
 package testplans
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadatav1 "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	projectv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
 	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/testreport"
 )
 
 //go:generate mockgen -source ./testplan.go -destination mocks/testplan.go
 
+const (
+	// ProjectFilterKey is the filter used to find the test plans of a project
+	ProjectFilterKey = "projectid"
+	// NameFilterKey is the filter used to find test plans by name
+	NameFilterKey = "name"
+	// ExecutionFilterKey is the filter used to find the executions of a test plan
+	ExecutionFilterKey = "testplanid"
+	// ReleaseFilterKey is the filter used to find the executions of a release
+	ReleaseFilterKey = "releaseid"
+)
+
 type projectRetriever func(ctx context.Context, id string) (interface{}, error)
+type executionLister func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error)
+type scenarioCopier func(ctx context.Context, user string, projectID string, ids []string, onCollision metadatav1.CollisionStrategy) (*scenariov1.CopyResult, error)
+type releaseChecker func(ctx context.Context, projectID string, id string) error
 
 // MetaHandler handles metadata information
 type MetaHandler interface {
@@ -49,14 +72,24 @@ type ReaderUpdater interface {
 	Updater
 }
 
+// ReaderWriter is used to read, add and update objects in the Data Base
+type ReaderWriter interface {
+	Getter
+	Adder
+	Updater
+}
+
 // New returns a function used to create a testplan
-func New(meta MetaHandler, collection Adder, getProject projectRetriever) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
+func New(meta MetaHandler, collection Adder, getProject projectRetriever, inProjectRelease releaseChecker) func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 	return func(ctx context.Context, author string, data io.Reader) (interface{}, error) {
 		testplan := &testplanv1.TestPlan{}
 		if err := decoder.Decode(testplan, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, testplan.ProjectId); err != nil {
+		if err := validateMatrix(ctx, getProject, testplan); err != nil {
+			return nil, err
+		}
+		if err := inProjectRelease(ctx, testplan.ProjectId, testplan.ReleaseId); err != nil {
 			return nil, err
 		}
 		identity, err := meta.NewMeta(author, "testplan")
@@ -109,13 +142,16 @@ func Delete(collection Deleter) func(ctx context.Context, id string) error {
 }
 
 // Update is used to replace a testplan with the provided testplan
-func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetriever, inProjectRelease releaseChecker) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
 	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
 		testplan := &testplanv1.TestPlan{}
 		if err := decoder.Decode(testplan, data); err != nil {
 			return nil, err
 		}
-		if _, err := getProject(ctx, testplan.ProjectId); err != nil {
+		if err := validateMatrix(ctx, getProject, testplan); err != nil {
+			return nil, err
+		}
+		if err := inProjectRelease(ctx, testplan.ProjectId, testplan.ReleaseId); err != nil {
 			return nil, err
 		}
 		foundTestplan, err := Get(collection)(ctx, id)
@@ -135,3 +171,292 @@ func Update(meta MetaHandler, collection ReaderUpdater, getProject projectRetrie
 		return testplan, nil
 	}
 }
+
+// Copy returns a function used to copy a test plan to a project together with the scenarios that were executed as part of it.
+// A copy to another project is not attached to a release and only keeps the part of the matrix defined by that project.
+// The collision strategy is used for the test plan and the scenarios. When the test plan is skipped, the existing test plan is returned.
+func Copy(meta MetaHandler, collection ReaderWriter, getProject projectRetriever, listExecutions executionLister, copyScenarios scenarioCopier) func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+	return func(ctx context.Context, user string, id string, data io.Reader) (interface{}, error) {
+		request := &testplanv1.CopyRequest{}
+		if err := decoder.Decode(request, data); err != nil {
+			return nil, err
+		}
+		rawProject, err := getProject(ctx, request.ProjectId)
+		if err != nil {
+			return nil, err
+		}
+		project, ok := rawProject.(*projectv1.Project)
+		if !ok {
+			return nil, fmt.Errorf("invalid DB entry for project %s", request.ProjectId)
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		source, ok := raw.(*testplanv1.TestPlan)
+		if !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		executions, err := listExecutions(ctx, map[string][]string{ExecutionFilterKey: {id}}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		scenarioIDs := []string{}
+		seen := map[string]bool{}
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			if !seen[execution.ScenarioId] {
+				seen[execution.ScenarioId] = true
+				scenarioIDs = append(scenarioIDs, execution.ScenarioId)
+			}
+		}
+
+		clone := proto.Clone(source).(*testplanv1.TestPlan)
+		if clone.ProjectId != request.ProjectId {
+			clone.ReleaseId = ""
+			clone.Matrix = project.FilterMatrix(clone.Matrix)
+		}
+		clone.ProjectId = request.ProjectId
+		clone.CopiedFrom = &metadatav1.Provenance{
+			SourceId:        source.Identity.Id,
+			SourceProjectId: source.ProjectId,
+			CopiedBy:        user,
+			Time:            time.Now().Unix(),
+		}
+		existing, err := findByName(ctx, collection, clone.ProjectId, clone.Name)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case existing == nil:
+			if clone.Identity, err = meta.NewMeta(user, "testplan"); err != nil {
+				return nil, err
+			}
+			if err := collection.AddOne(ctx, clone); err != nil {
+				return nil, err
+			}
+		case request.OnCollision == metadatav1.CollisionStrategy_SKIP:
+			clone = existing
+		case request.OnCollision == metadatav1.CollisionStrategy_OVERWRITE:
+			if existing.Identity.Id == id {
+				return nil, decoder.NewValidationError(fmt.Sprintf("test plan '%s' cannot overwrite itself", id))
+			}
+			clone.Identity = existing.Identity
+			meta.UpdateMeta(user, clone.Identity)
+			if err := collection.Update(ctx, clone.Identity.Id, clone); err != nil {
+				return nil, err
+			}
+		default:
+			if clone.Name, err = freeName(ctx, collection, clone.ProjectId, clone.Name); err != nil {
+				return nil, err
+			}
+			if clone.Identity, err = meta.NewMeta(user, "testplan"); err != nil {
+				return nil, err
+			}
+			if err := collection.AddOne(ctx, clone); err != nil {
+				return nil, err
+			}
+		}
+
+		result := &testplanv1.CopyResult{TestPlan: clone, Scenarios: &scenariov1.CopyResult{}}
+		if len(scenarioIDs) != 0 {
+			if result.Scenarios, err = copyScenarios(ctx, user, request.ProjectId, scenarioIDs, request.OnCollision); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// Summary returns a function used to count the results of the executions of a test plan for each configuration.
+// Every configuration of the matrix is reported, followed by the other configurations the executions were run on
+func Summary(collection Getter, listExecutions executionLister) func(ctx context.Context, id string) (interface{}, error) {
+	return func(ctx context.Context, id string) (interface{}, error) {
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		testplan, ok := raw.(*testplanv1.TestPlan)
+		if !ok {
+			return nil, fmt.Errorf("invalid data structure in DB")
+		}
+		executions, err := listExecutions(ctx, map[string][]string{ExecutionFilterKey: {id}}, "", false, 0, "")
+		if err != nil {
+			return nil, err
+		}
+
+		summary := &testplanv1.Summary{TestPlanId: id, Configurations: []*testplanv1.ConfigurationSummary{}}
+		byKey := map[string]*testplanv1.ConfigurationSummary{}
+		for _, configuration := range testplan.Configurations() {
+			configurationSummary := &testplanv1.ConfigurationSummary{Configuration: configuration}
+			byKey[configurationKey(configuration)] = configurationSummary
+			summary.Configurations = append(summary.Configurations, configurationSummary)
+		}
+		others := []*testplanv1.ConfigurationSummary{}
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			key := configurationKey(execution.Configuration)
+			configurationSummary, ok := byKey[key]
+			if !ok {
+				configurationSummary = &testplanv1.ConfigurationSummary{Configuration: execution.Configuration}
+				byKey[key] = configurationSummary
+				others = append(others, configurationSummary)
+			}
+			configurationSummary.Total++
+			switch execution.Status {
+			case executionv1.Status_Pending:
+				configurationSummary.Pending++
+			case executionv1.Status_Fail:
+				configurationSummary.Failed++
+			case executionv1.Status_Pass:
+				configurationSummary.Passed++
+			case executionv1.Status_Skipped:
+				configurationSummary.Skipped++
+			}
+		}
+		sort.SliceStable(others, func(i, j int) bool {
+			return configurationKey(others[i].Configuration) < configurationKey(others[j].Configuration)
+		})
+		summary.Configurations = append(summary.Configurations, others...)
+		return summary, nil
+	}
+}
+
+// Report returns a function used to render the executions of a test plan, ie. with testreport.HTML or testreport.Markdown.
+// The filter can contain a releaseId and configuration values as `dimension:value`, an execution has to match all the configuration values.
+// The name of the file is the name of the test plan followed by the extension.
+func Report(collection Getter, listExecutions executionLister, extension string, render func(w io.Writer, report *testreport.Report) error) func(ctx context.Context, id string, filter map[string][]string) (string, []byte, error) {
+	return func(ctx context.Context, id string, filter map[string][]string) (string, []byte, error) {
+		report := &testreport.Report{Generated: time.Now(), Executions: []*executionv1.Execution{}}
+		query := map[string][]string{ExecutionFilterKey: {id}}
+		for key, values := range filter {
+			switch key {
+			case "releaseId":
+				query[ReleaseFilterKey] = values
+				report.ReleaseID = strings.Join(values, ", ")
+			case "configuration":
+				report.Configuration = map[string]string{}
+				for _, value := range values {
+					parts := strings.SplitN(value, ":", 2)
+					if len(parts) != 2 || parts[0] == "" {
+						return "", nil, decoder.NewValidationError(fmt.Sprintf("configuration '%s' has to be written as dimension:value", value))
+					}
+					report.Configuration[parts[0]] = parts[1]
+				}
+			default:
+				return "", nil, decoder.NewValidationError(fmt.Sprintf("test plan reports cannot be filtered by '%s'", key))
+			}
+		}
+		raw, err := Get(collection)(ctx, id)
+		if err != nil {
+			return "", nil, err
+		}
+		testplan, ok := raw.(*testplanv1.TestPlan)
+		if !ok {
+			return "", nil, fmt.Errorf("invalid data structure in DB")
+		}
+		report.TestPlan = testplan
+		executions, err := listExecutions(ctx, query, "", false, 0, "")
+		if err != nil {
+			return "", nil, err
+		}
+		for _, item := range executions {
+			execution, ok := item.(*executionv1.Execution)
+			if !ok {
+				return "", nil, fmt.Errorf("invalid DB entry for execution")
+			}
+			if matchesConfiguration(execution.Configuration, report.Configuration) {
+				report.Executions = append(report.Executions, execution)
+			}
+		}
+		report.Sort()
+		file := &bytes.Buffer{}
+		if err := render(file, report); err != nil {
+			return "", nil, err
+		}
+		return fileName(testplan.Name) + extension, file.Bytes(), nil
+	}
+}
+
+// matchesConfiguration checks that the configuration has all the values of the filter
+func matchesConfiguration(configuration map[string]string, filter map[string]string) bool {
+	for dimension, value := range filter {
+		if configuration[dimension] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// fileName turns the name of a test plan into a file name, ie. `Release 1.2 / smoke` becomes `release-1.2-smoke`
+func fileName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '_' && r != '-'
+	})
+	if len(words) == 0 {
+		return "testplan"
+	}
+	return strings.Join(words, "-")
+}
+
+// configurationKey identifies a configuration independently of the order of its dimensions
+func configurationKey(configuration map[string]string) string {
+	names := make([]string, 0, len(configuration))
+	for name := range configuration {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("%s=%s;", name, configuration[name]))
+	}
+	return b.String()
+}
+
+// validateMatrix checks that the project of the test plan exists and defines the dimensions and values of its matrix
+func validateMatrix(ctx context.Context, getProject projectRetriever, testplan *testplanv1.TestPlan) error {
+	raw, err := getProject(ctx, testplan.ProjectId)
+	if err != nil {
+		return err
+	}
+	project, ok := raw.(*projectv1.Project)
+	if !ok {
+		return fmt.Errorf("invalid DB entry for project %s", testplan.ProjectId)
+	}
+	return project.ValidateMatrix(testplan.Matrix)
+}
+
+func findByName(ctx context.Context, collection Getter, projectID string, name string) (*testplanv1.TestPlan, error) {
+	found, err := List(collection)(ctx, map[string][]string{ProjectFilterKey: {projectID}, NameFilterKey: {name}}, "", false, 1, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	testplan, ok := found[0].(*testplanv1.TestPlan)
+	if !ok {
+		return nil, fmt.Errorf("invalid data structure in DB")
+	}
+	return testplan, nil
+}
+
+// freeName returns the first copy name that is not used in the project
+func freeName(ctx context.Context, collection Getter, projectID string, name string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := metadatav1.CopyName(name, n)
+		existing, err := findByName(ctx, collection, projectID, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+}
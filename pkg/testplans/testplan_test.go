@@ -3,20 +3,26 @@ package testplans_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/curious-kitten/scratch-post/internal/decoder"
 	"github.com/curious-kitten/scratch-post/internal/test/matchers"
 	"github.com/curious-kitten/scratch-post/internal/test/transformers"
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
 	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	project "github.com/curious-kitten/scratch-post/pkg/api/v1/project"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
 	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
 	"github.com/curious-kitten/scratch-post/pkg/testplans"
 	mocktestplans "github.com/curious-kitten/scratch-post/pkg/testplans/mocks"
+	"github.com/curious-kitten/scratch-post/pkg/testreport"
 )
 
 var (
@@ -41,7 +47,13 @@ var (
 )
 
 func goodGetProject(ctx context.Context, id string) (interface{}, error) {
-	return nil, nil
+	return &project.Project{
+		Name: "test project",
+		ConfigurationDimensions: []*project.Dimension{
+			{Name: "browser", Values: []string{"chrome", "firefox"}},
+			{Name: "os", Values: []string{"linux", "windows"}},
+		},
+	}, nil
 }
 
 func errorGetProject(ctx context.Context, id string) (interface{}, error) {
@@ -52,6 +64,10 @@ func noProject(ctx context.Context, id string) (interface{}, error) {
 	return nil, mongo.ErrNoDocuments
 }
 
+func goodReleaseCheck(ctx context.Context, projectID string, id string) error {
+	return nil
+}
+
 func TestTestPlan_Validate(t *testing.T) {
 	g := NewWithT(t)
 	s := &testplan.TestPlan{}
@@ -83,7 +99,7 @@ func TestNew_Create(t *testing.T) {
 		AddOne(ctx, matchers.OfType(&testplan.TestPlan{})).
 		Return(nil)
 
-	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject, goodReleaseCheck)
 	createdTestplan, err := creator(ctx, "tester", transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 	expectedTestPlan := &testplan.TestPlan{
@@ -91,7 +107,7 @@ func TestNew_Create(t *testing.T) {
 		Name:      testTestPlan.Name,
 		ProjectId: testTestPlan.ProjectId,
 	}
-	g.Expect(createdTestplan).To(Equal(expectedTestPlan), "testplans did not match")
+	g.Expect(createdTestplan).To(matchers.EqualProto(expectedTestPlan), "testplans did not match")
 }
 
 func TestNew_ProjectNotFound(t *testing.T) {
@@ -101,7 +117,7 @@ func TestNew_ProjectNotFound(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockAdder := mocktestplans.NewMockAdder(ctrl)
-	creator := testplans.New(mockMetaHandler, mockAdder, noProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, noProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 }
@@ -113,7 +129,7 @@ func TestNew_ProjectError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockAdder := mocktestplans.NewMockAdder(ctrl)
-	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "error type was missing")
@@ -126,7 +142,7 @@ func TestNew_MarshallError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockAdder := mocktestplans.NewMockAdder(ctrl)
-	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(struct{ SomeField string }{SomeField: "test"}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -139,7 +155,7 @@ func TestNew_ValidationError(t *testing.T) {
 	ctx := context.Background()
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockAdder := mocktestplans.NewMockAdder(ctrl)
-	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, errorGetProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(&testplan.TestPlan{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -156,7 +172,7 @@ func TestNew_AddMetaError(t *testing.T) {
 		NewMeta("tester", "testplan").
 		Return(nil, fmt.Errorf("identity error"))
 	mockAdder := mocktestplans.NewMockAdder(ctrl)
-	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
@@ -177,7 +193,7 @@ func TestNew_AddToCollectionError(t *testing.T) {
 		AddOne(ctx, matchers.OfType(&testplan.TestPlan{})).
 		Return(fmt.Errorf("expected error"))
 
-	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject)
+	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject, goodReleaseCheck)
 	_, err := creator(ctx, "tester", transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "expected error did not occur")
 }
@@ -292,7 +308,7 @@ func TestUpdate(t *testing.T) {
 		Update(ctx, identity.Id, matchers.OfType(&testplan.TestPlan{}))
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodReleaseCheck)
 	createdTestplan, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
 	expectedTestPlan := &testplan.TestPlan{
@@ -300,7 +316,74 @@ func TestUpdate(t *testing.T) {
 		Name:      testTestPlan.Name,
 		ProjectId: testTestPlan.ProjectId,
 	}
-	g.Expect(createdTestplan).To(Equal(expectedTestPlan), "testplans did not match")
+	g.Expect(createdTestplan).To(matchers.EqualProto(expectedTestPlan), "testplans did not match")
+}
+
+func TestNew_ReleaseNotInProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
+	mockAdder := mocktestplans.NewMockAdder(ctrl)
+	releaseCheck := func(ctx context.Context, projectID string, id string) error {
+		g.Expect(projectID).To(Equal(testTestPlan.ProjectId))
+		g.Expect(id).To(Equal("release"))
+		return decoder.NewValidationError("not in project")
+	}
+	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject, releaseCheck)
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(&testplan.TestPlan{Name: "test testplan", ProjectId: testTestPlan.ProjectId, ReleaseId: "release"}))
+	g.Expect(err).Should(HaveOccurred(), "error did not occur")
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "release of another project does not return a validation error")
+}
+
+func TestNew_MatrixNotInProject(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
+	mockAdder := mocktestplans.NewMockAdder(ctrl)
+	creator := testplans.New(mockMetaHandler, mockAdder, goodGetProject, goodReleaseCheck)
+	request := &testplan.TestPlan{
+		Name:      "test testplan",
+		ProjectId: testTestPlan.ProjectId,
+		Matrix:    []*project.Dimension{{Name: "browser", Values: []string{"safari"}}},
+	}
+	_, err := creator(ctx, "tester", transformers.ToReadCloser(request))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "matrix value outside of the project does not return a validation error")
+}
+
+func TestSummary(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mocktestplans.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&testplan.TestPlan{})).
+		Do(func(ctx context.Context, id string, tp *testplan.TestPlan) {
+			tp.Identity = &identity
+			tp.Matrix = []*project.Dimension{{Name: "browser", Values: []string{"chrome", "firefox"}}}
+		})
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{testplans.ExecutionFilterKey: {identity.Id}}), "executions were not filtered by test plan")
+		return []interface{}{
+			&execution.Execution{Configuration: map[string]string{"browser": "firefox"}, Status: execution.Status_Pass},
+			&execution.Execution{Configuration: map[string]string{"browser": "firefox"}, Status: execution.Status_Fail},
+			&execution.Execution{Status: execution.Status_Pending},
+		}, nil
+	}
+	raw, err := testplans.Summary(mockGetter, listExecutions)(ctx, identity.Id)
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	summary := raw.(*testplan.Summary)
+	g.Expect(summary.TestPlanId).To(Equal(identity.Id), "test plan was not reported")
+	g.Expect(summary.Configurations).To(Equal([]*testplan.ConfigurationSummary{
+		{Configuration: map[string]string{"browser": "chrome"}},
+		{Configuration: map[string]string{"browser": "firefox"}, Total: 2, Failed: 1, Passed: 1},
+		{Total: 1, Pending: 1},
+	}), "configurations did not match")
 }
 
 func TestUpdate_ValidationError(t *testing.T) {
@@ -310,7 +393,7 @@ func TestUpdate_ValidationError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mocktestplans.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodReleaseCheck)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testplan.TestPlan{}))
 	g.Expect(err).Should(HaveOccurred(), "error did not occur")
 	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid item passed does not return a validation error")
@@ -323,7 +406,7 @@ func TestUpdate_InvalidProject(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mocktestplans.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, noProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, noProject, goodReleaseCheck)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -335,7 +418,7 @@ func TestUpdate_ProjectError(t *testing.T) {
 	ctx := context.Background()
 	mockReaderUpdater := mocktestplans.NewMockReaderUpdater(ctrl)
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, errorGetProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, errorGetProject, goodReleaseCheck)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 	g.Expect(decoder.IsValidationError(err)).To(BeFalse(), "project not found error is not a validation error")
@@ -352,7 +435,7 @@ func TestUpdate_GetError(t *testing.T) {
 		Get(ctx, identity.Id, matchers.OfType(&testplan.TestPlan{})).
 		Return(fmt.Errorf("error during get"))
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodReleaseCheck)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
@@ -375,7 +458,137 @@ func TestUpdate_UpdateError(t *testing.T) {
 		Return(fmt.Errorf("update error"))
 	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
 	mockMetaHandler.EXPECT().UpdateMeta("tester", matchers.OfType(&metadata.Identity{}))
-	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject)
+	updater := testplans.Update(mockMetaHandler, mockReaderUpdater, goodGetProject, goodReleaseCheck)
 	_, err := updater(ctx, "tester", identity.Id, transformers.ToReadCloser(testTestPlan))
 	g.Expect(err).Should(HaveOccurred(), "unexpected error occurred")
 }
+
+func TestCopy(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mocktestplans.NewMockReaderWriter(ctrl)
+	mockReaderWriter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&testplan.TestPlan{})).
+		Do(func(ctx context.Context, id string, tp *testplan.TestPlan) {
+			tp.Identity = &identity
+			tp.Name = testTestPlan.Name
+			tp.ProjectId = testTestPlan.ProjectId
+			tp.ReleaseId = "release"
+			tp.Matrix = []*project.Dimension{
+				{Name: "browser", Values: []string{"safari", "chrome"}},
+				{Name: "device", Values: []string{"phone"}},
+			}
+		})
+	mockReaderWriter.
+		EXPECT().
+		GetAll(ctx, matchers.OfType(&[]testplan.TestPlan{}), map[string][]string{testplans.ProjectFilterKey: {"target project"}, testplans.NameFilterKey: {testTestPlan.Name}}, "", false, 1, "").
+		Return(nil)
+	mockReaderWriter.
+		EXPECT().
+		AddOne(ctx, matchers.OfType(&testplan.TestPlan{})).
+		Return(nil)
+	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
+	mockMetaHandler.
+		EXPECT().
+		NewMeta("tester", "testplan").
+		Return(&metadata.Identity{Id: "copy"}, nil)
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{testplans.ExecutionFilterKey: {identity.Id}}), "executions were not filtered by test plan")
+		return []interface{}{
+			&execution.Execution{ScenarioId: "first"},
+			&execution.Execution{ScenarioId: "second"},
+			&execution.Execution{ScenarioId: "first"},
+		}, nil
+	}
+	copyScenarios := func(ctx context.Context, user string, projectID string, ids []string, onCollision metadata.CollisionStrategy) (*scenario.CopyResult, error) {
+		g.Expect(ids).To(Equal([]string{"first", "second"}), "scenarios of the test plan did not match")
+		g.Expect(onCollision).To(Equal(metadata.CollisionStrategy_SKIP), "collision strategy was not passed")
+		return &scenario.CopyResult{Skipped: ids}, nil
+	}
+	copier := testplans.Copy(mockMetaHandler, mockReaderWriter, goodGetProject, listExecutions, copyScenarios)
+	request := &testplan.CopyRequest{ProjectId: "target project", OnCollision: metadata.CollisionStrategy_SKIP}
+	raw, err := copier(ctx, "tester", identity.Id, transformers.ToReadCloser(request))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	result := raw.(*testplan.CopyResult)
+	g.Expect(result.TestPlan.Identity.Id).To(Equal("copy"), "test plan was not copied")
+	g.Expect(result.TestPlan.ProjectId).To(Equal("target project"), "project was not changed")
+	g.Expect(result.TestPlan.CopiedFrom.SourceId).To(Equal(identity.Id), "provenance was not recorded")
+	g.Expect(result.TestPlan.ReleaseId).To(BeEmpty(), "release of the source project was kept")
+	g.Expect(result.TestPlan.Matrix).To(Equal([]*project.Dimension{{Name: "browser", Values: []string{"chrome"}}}), "matrix was not filtered by the target project")
+	g.Expect(result.Scenarios.Skipped).To(HaveLen(2), "scenario copy result was not returned")
+}
+
+func TestTestPlan_UniqueKeys(t *testing.T) {
+	g := NewWithT(t)
+	stored, err := bson.Marshal(&testplan.TestPlan{ProjectId: "project", Name: "smoke"})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	// the unique index of the names within a project is built on the filter keys
+	g.Expect(bson.Raw(stored).Lookup(testplans.ProjectFilterKey).StringValue()).To(Equal("project"), "project filter key is not the stored field")
+	g.Expect(bson.Raw(stored).Lookup(testplans.NameFilterKey).StringValue()).To(Equal("smoke"), "name filter key is not the stored field")
+}
+
+func TestCopy_ValidationError(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockReaderWriter := mocktestplans.NewMockReaderWriter(ctrl)
+	mockMetaHandler := mocktestplans.NewMockMetaHandler(ctrl)
+	copier := testplans.Copy(mockMetaHandler, mockReaderWriter, goodGetProject, nil, nil)
+	_, err := copier(ctx, "tester", identity.Id, transformers.ToReadCloser(&testplan.CopyRequest{OnCollision: 5}))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "invalid copy request is not a validation error")
+}
+
+func TestReport(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mocktestplans.NewMockGetter(ctrl)
+	mockGetter.
+		EXPECT().
+		Get(ctx, identity.Id, matchers.OfType(&testplan.TestPlan{})).
+		Do(func(ctx context.Context, id string, tp *testplan.TestPlan) {
+			tp.Identity = &identity
+			tp.Name = "Release 1.2 / smoke"
+		})
+	listExecutions := func(ctx context.Context, filter map[string][]string, sortBy string, reverse bool, count int, previousLastValue string) ([]interface{}, error) {
+		g.Expect(filter).To(Equal(map[string][]string{testplans.ExecutionFilterKey: {identity.Id}, testplans.ReleaseFilterKey: {"1.2"}}), "executions were not filtered by test plan and release")
+		return []interface{}{
+			&execution.Execution{Name: "Search", Configuration: map[string]string{"browser": "chrome", "os": "linux"}},
+			&execution.Execution{Name: "Login", Configuration: map[string]string{"browser": "firefox", "os": "linux"}},
+			&execution.Execution{Name: "Checkout", Configuration: map[string]string{"browser": "chrome", "os": "linux"}},
+		}, nil
+	}
+	var report *testreport.Report
+	render := func(w io.Writer, r *testreport.Report) error {
+		report = r
+		_, err := w.Write([]byte("rendered"))
+		return err
+	}
+	name, content, err := testplans.Report(mockGetter, listExecutions, ".md", render)(ctx, identity.Id, map[string][]string{"releaseId": {"1.2"}, "configuration": {"browser:chrome"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(name).To(Equal("release-1.2-smoke.md"), "file is not named after the test plan")
+	g.Expect(string(content)).To(Equal("rendered"), "report was not rendered")
+	g.Expect(report.TestPlan.Name).To(Equal("Release 1.2 / smoke"), "test plan was not reported")
+	g.Expect(report.ReleaseID).To(Equal("1.2"), "release was not reported")
+	g.Expect(report.Configuration).To(Equal(map[string]string{"browser": "chrome"}), "configuration was not reported")
+	g.Expect(report.Executions).To(HaveLen(2), "executions were not filtered by configuration")
+	g.Expect(report.Executions[0].Name).To(Equal("Checkout"), "executions were not sorted")
+}
+
+func TestReport_InvalidFilter(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockGetter := mocktestplans.NewMockGetter(ctrl)
+	report := testplans.Report(mockGetter, nil, ".html", testreport.HTML)
+	_, _, err := report(ctx, identity.Id, map[string][]string{"status": {"Fail"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "unknown filter does not return a validation error")
+	_, _, err = report(ctx, identity.Id, map[string][]string{"configuration": {"chrome"}})
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "configuration without dimension does not return a validation error")
+}
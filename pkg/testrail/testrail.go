@@ -0,0 +1,139 @@
+package testrail
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	scenariov1 "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	"github.com/curious-kitten/scratch-post/pkg/migration"
+)
+
+// Columns of a TestRail CSV export
+const (
+	idColumn                = "id"
+	titleColumn             = "title"
+	sectionColumn           = "section"
+	sectionHierarchyColumn  = "section hierarchy"
+	typeColumn              = "type"
+	priorityColumn          = "priority"
+	referencesColumn        = "references"
+	preconditionsColumn     = "preconditions"
+	stepsColumn             = "steps"
+	expectedResultColumn    = "expected result"
+	separatedStepColumn     = "steps (step)"
+	separatedExpectedColumn = "steps (expected result)"
+	automationTypeColumn    = "automation type"
+	sectionSeparator        = ">"
+)
+
+// Parse reads a TestRail CSV export of test cases. The header names the columns, in any order and case.
+//
+// The sections become folders, using the `Section Hierarchy` column, ie. `Shop > Cart`, or the `Section` column.
+// The test cases become scenarios identified by their `ID`, with the `Preconditions` as prerequisites and the `Type`, `Priority`
+// and `References` as labels, ie. `priority:High`. Test cases with an `Automation Type` other than None are automated.
+// The steps are read from the `Steps (Step)` and `Steps (Expected Result)` columns, the following rows without ID nor title
+// holding the next steps of the test case, or from the `Steps` and `Expected Result` columns, which become a single step.
+func Parse(content io.Reader) (*migration.Folder, error) {
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, decoder.NewValidationError("invalid TestRail export: the file has no header")
+	}
+	if err != nil {
+		return nil, decoder.NewValidationError(fmt.Sprintf("invalid TestRail export: %s", err))
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns[titleColumn]; !ok {
+		return nil, decoder.NewValidationError("invalid TestRail export: there is no Title column")
+	}
+
+	root := &migration.Folder{}
+	var current *scenariov1.Scenario
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, decoder.NewValidationError(fmt.Sprintf("invalid TestRail export: %s", err))
+		}
+		cell := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(values[i])
+		}
+		if strings.TrimSpace(strings.Join(values, "")) == "" {
+			continue
+		}
+		id, title := cell(idColumn), cell(titleColumn)
+		if id == "" && title == "" {
+			if current == nil {
+				return nil, decoder.NewValidationError(fmt.Sprintf("invalid TestRail export: row %d does not belong to a test case", row))
+			}
+			addStep(current, cell(separatedStepColumn), cell(separatedExpectedColumn))
+			continue
+		}
+		current = &scenariov1.Scenario{
+			Name:          title,
+			Prerequisites: cell(preconditionsColumn),
+		}
+		if title == "" {
+			current.Name = id
+		}
+		if value := cell(typeColumn); value != "" {
+			current.Labels = append(current.Labels, "type:"+value)
+		}
+		if value := cell(priorityColumn); value != "" {
+			current.Labels = append(current.Labels, "priority:"+value)
+		}
+		for _, reference := range strings.Split(cell(referencesColumn), ",") {
+			if reference = strings.TrimSpace(reference); reference != "" {
+				current.Labels = append(current.Labels, reference)
+			}
+		}
+		if automation := cell(automationTypeColumn); automation != "" && !strings.EqualFold(automation, "none") {
+			current.Automated = true
+		}
+		addStep(current, cell(stepsColumn), cell(expectedResultColumn))
+		addStep(current, cell(separatedStepColumn), cell(separatedExpectedColumn))
+
+		section := cell(sectionHierarchyColumn)
+		if section == "" {
+			section = cell(sectionColumn)
+		}
+		path := []string{}
+		for _, name := range strings.Split(section, sectionSeparator) {
+			if name = strings.TrimSpace(name); name != "" {
+				path = append(path, name)
+			}
+		}
+		folder := root.Subfolder(path)
+		folder.Cases = append(folder.Cases, &migration.Case{ID: id, Scenario: current})
+	}
+	return root, nil
+}
+
+// addStep adds a step to the scenario when the action or the expected outcome is set
+func addStep(scenario *scenariov1.Scenario, action string, expected string) {
+	if action == "" && expected == "" {
+		return
+	}
+	position := int32(len(scenario.Steps) + 1)
+	name := strings.TrimSpace(strings.SplitN(action, "\n", 2)[0])
+	if name == "" {
+		name = fmt.Sprintf("Step %d", position)
+	}
+	scenario.Steps = append(scenario.Steps, &scenariov1.Step{Position: position, Name: name, Action: action, ExpectedOutcome: expected})
+}
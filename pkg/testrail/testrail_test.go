@@ -0,0 +1,58 @@
+package testrail_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/curious-kitten/scratch-post/internal/decoder"
+	"github.com/curious-kitten/scratch-post/pkg/testrail"
+)
+
+const export = "\ufeffID,Title,Section,Section Hierarchy,Type,Priority,References,Automation Type,Preconditions,Steps (Step),Steps (Expected Result)\n" +
+	"C1,Add a product,Cart,Shop > Cart,Functional,High,\"JIRA-1, JIRA-2\",Automated,The user is logged in,Open a product,The product page is shown\n" +
+	",,,,,,,,,Click Add,The product is in the cart\n" +
+	",,,,,,,,,,\n" +
+	"C2,Search,Shop,,Functional,Low,,None,,,\n"
+
+func TestParse(t *testing.T) {
+	g := NewWithT(t)
+	root, err := testrail.Parse(strings.NewReader(export))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(root.Folders).To(HaveLen(1), "sections were not merged")
+	shop := root.Folders[0]
+	g.Expect(shop.Name).To(Equal("Shop"))
+	g.Expect(shop.Cases).To(HaveLen(1), "section was not used without a hierarchy")
+	g.Expect(shop.Cases[0].Scenario.Automated).To(BeFalse())
+	g.Expect(shop.Folders).To(HaveLen(1))
+
+	c := shop.Folders[0].Cases[0]
+	g.Expect(c.ID).To(Equal("C1"))
+	g.Expect(c.Scenario.Name).To(Equal("Add a product"))
+	g.Expect(c.Scenario.Prerequisites).To(Equal("The user is logged in"))
+	g.Expect(c.Scenario.Labels).To(Equal([]string{"type:Functional", "priority:High", "JIRA-1", "JIRA-2"}))
+	g.Expect(c.Scenario.Automated).To(BeTrue())
+	g.Expect(c.Scenario.Steps).To(HaveLen(2), "steps of the following rows were not added")
+	g.Expect(c.Scenario.Steps[1].Position).To(Equal(int32(2)))
+	g.Expect(c.Scenario.Steps[1].Action).To(Equal("Click Add"))
+	g.Expect(c.Scenario.Steps[1].ExpectedOutcome).To(Equal("The product is in the cart"))
+}
+
+func TestParse_TextSteps(t *testing.T) {
+	g := NewWithT(t)
+	root, err := testrail.Parse(strings.NewReader("Title,Steps,Expected Result\nLogin,\"Open the page\nLog in\",The dashboard is shown\n"))
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	steps := root.Cases[0].Scenario.Steps
+	g.Expect(steps).To(HaveLen(1), "text steps did not become a single step")
+	g.Expect(steps[0].Name).To(Equal("Open the page"))
+	g.Expect(steps[0].ExpectedOutcome).To(Equal("The dashboard is shown"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	g := NewWithT(t)
+	_, err := testrail.Parse(strings.NewReader("ID,Name\nC1,Login\n"))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "missing title is not a validation error")
+	_, err = testrail.Parse(strings.NewReader("Title,Steps (Step)\n,Click\n"))
+	g.Expect(decoder.IsValidationError(err)).To(BeTrue(), "step without test case is not a validation error")
+}
@@ -0,0 +1,244 @@
+package testreport
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	executionv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	testplanv1 "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+)
+
+// Report holds the results of the executions of a test plan
+type Report struct {
+	TestPlan *testplanv1.TestPlan
+	// ReleaseID is set when the report only holds the executions of a release
+	ReleaseID string
+	// Configuration is set when the report only holds the executions run on a configuration
+	Configuration map[string]string
+	Generated     time.Time
+	Executions    []*executionv1.Execution
+}
+
+// Counts are the number of executions with each status
+type Counts struct {
+	Configuration map[string]string
+	Total         int
+	Passed        int
+	Failed        int
+	Skipped       int
+	Pending       int
+}
+
+// PassRate is the percentage of passed executions out of the ones that were run
+func (c Counts) PassRate() string {
+	run := c.Total - c.Pending
+	if run == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(c.Passed)*100/float64(run))
+}
+
+func (c *Counts) add(status executionv1.Status) {
+	c.Total++
+	switch status {
+	case executionv1.Status_Pass:
+		c.Passed++
+	case executionv1.Status_Fail:
+		c.Failed++
+	case executionv1.Status_Skipped:
+		c.Skipped++
+	default:
+		c.Pending++
+	}
+}
+
+// Totals counts the results of all the executions of the report
+func (r *Report) Totals() Counts {
+	counts := Counts{}
+	for _, e := range r.Executions {
+		counts.add(e.Status)
+	}
+	return counts
+}
+
+// Configurations counts the results of the executions of each configuration, in the order the configurations were first run
+func (r *Report) Configurations() []Counts {
+	byKey := map[string]*Counts{}
+	keys := []string{}
+	for _, e := range r.Executions {
+		key := Configuration(e.Configuration)
+		counts, ok := byKey[key]
+		if !ok {
+			counts = &Counts{Configuration: e.Configuration}
+			byKey[key] = counts
+			keys = append(keys, key)
+		}
+		counts.add(e.Status)
+	}
+	configurations := make([]Counts, 0, len(keys))
+	for _, key := range keys {
+		configurations = append(configurations, *byKey[key])
+	}
+	return configurations
+}
+
+// Configuration describes a configuration, ie. `browser=chrome, os=linux`
+func Configuration(configuration map[string]string) string {
+	dimensions := make([]string, 0, len(configuration))
+	for dimension, value := range configuration {
+		dimensions = append(dimensions, dimension+"="+value)
+	}
+	sort.Strings(dimensions)
+	return strings.Join(dimensions, ", ")
+}
+
+// Sort orders the executions by name and configuration
+func (r *Report) Sort() {
+	sort.SliceStable(r.Executions, func(i, j int) bool {
+		if r.Executions[i].Name != r.Executions[j].Name {
+			return r.Executions[i].Name < r.Executions[j].Name
+		}
+		return Configuration(r.Executions[i].Configuration) < Configuration(r.Executions[j].Configuration)
+	})
+}
+
+var functions = map[string]interface{}{
+	"configuration": Configuration,
+	"status":        func(status executionv1.Status) string { return status.String() },
+	"failed":        func(status executionv1.Status) bool { return status == executionv1.Status_Fail },
+	"lower":         strings.ToLower,
+	"time":          func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+	"seconds":       func(d float64) string { return fmt.Sprintf("%.2fs", d) },
+	"cell":          cell,
+}
+
+var (
+	htmlReport     = htmltemplate.Must(htmltemplate.New("html").Funcs(functions).Parse(htmlTemplate))
+	markdownReport = texttemplate.Must(texttemplate.New("markdown").Funcs(functions).Parse(markdownTemplate))
+)
+
+// HTML writes the report as a self-contained HTML page
+func HTML(w io.Writer, report *Report) error {
+	return htmlReport.Execute(w, report)
+}
+
+// Markdown writes the report as a Markdown document
+func Markdown(w io.Writer, report *Report) error {
+	return markdownReport.Execute(w, report)
+}
+
+// cell escapes a text so that it fits in a cell of a Markdown table
+func cell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "|", `\|`)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.TestPlan.Name}} - test report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+pre { white-space: pre-wrap; margin: 0; }
+.status { font-weight: bold; padding: 2px 6px; border-radius: 3px; color: #fff; }
+.pass { background: #2e7d32; }
+.fail { background: #c62828; }
+.skipped { background: #757575; }
+.pending { background: #f9a825; }
+tr.failed td { background: #ffebee; }
+.execution { border-top: 1px solid #ccc; padding-top: 0.5em; }
+</style>
+</head>
+<body>
+<h1>{{.TestPlan.Name}}</h1>
+{{with .TestPlan.Description}}<p>{{.}}</p>{{end}}
+<p>Generated on {{time .Generated}}{{with .ReleaseID}} for release {{.}}{{end}}{{with .Configuration}} on {{configuration .}}{{end}}</p>
+<h2>Summary</h2>
+<table>
+<tr>{{if gt (len .Configurations) 1}}<th>Configuration</th>{{end}}<th>Total</th><th>Passed</th><th>Failed</th><th>Skipped</th><th>Pending</th><th>Pass rate</th></tr>
+{{- if gt (len .Configurations) 1}}{{range .Configurations}}
+<tr><td>{{configuration .Configuration}}</td><td>{{.Total}}</td><td>{{.Passed}}</td><td>{{.Failed}}</td><td>{{.Skipped}}</td><td>{{.Pending}}</td><td>{{.PassRate}}</td></tr>
+{{- end}}{{end}}
+{{with .Totals}}<tr>{{if gt (len $.Configurations) 1}}<th>All</th>{{end}}<td>{{.Total}}</td><td>{{.Passed}}</td><td>{{.Failed}}</td><td>{{.Skipped}}</td><td>{{.Pending}}</td><td>{{.PassRate}}</td></tr>{{end}}
+</table>
+<h2>Results</h2>
+{{range .Executions}}
+<div class="execution">
+<h3>{{.Name}} <span class="status {{lower (status .Status)}}">{{status .Status}}</span></h3>
+{{with .Configuration}}<p>Configuration: {{configuration .}}</p>{{end}}
+{{if .Duration}}<p>Duration: {{seconds .Duration}}</p>{{end}}
+{{with .ActualResult}}<p>Actual result:</p><pre>{{.}}</pre>{{end}}
+{{with .Issues}}<p>Issues:</p><ul>{{range .}}<li><a href="{{.Link}}">{{.Link}}</a>{{with .State}} ({{.}}){{end}}</li>{{end}}</ul>{{end}}
+{{with .Steps}}
+<table>
+<tr><th>#</th><th>Step</th><th>Expected outcome</th><th>Status</th><th>Actual result</th><th>Attachments and issues</th></tr>
+{{- range .}}
+<tr{{if failed .Status}} class="failed"{{end}}><td>{{.Definition.GetPosition}}</td><td><pre>{{with .Definition.GetAction}}{{.}}{{else}}{{.Definition.GetName}}{{end}}</pre></td><td><pre>{{.Definition.GetExpectedOutcome}}</pre></td><td><span class="status {{lower (status .Status)}}">{{status .Status}}</span></td><td><pre>{{.ActualResult}}</pre></td><td>
+{{- range .Attachments}}<a href="{{.Link}}">{{.Name}}</a><br>{{end}}
+{{- range .Issues}}<a href="{{.Link}}">{{.Link}}</a>{{with .State}} ({{.}}){{end}}<br>{{end -}}
+</td></tr>
+{{- end}}
+</table>
+{{end}}
+</div>
+{{else}}
+<p>The test plan has no executions.</p>
+{{end}}
+</body>
+</html>
+`
+
+const markdownTemplate = `# {{.TestPlan.Name}}
+{{with .TestPlan.Description}}
+{{.}}
+{{end}}
+Generated on {{time .Generated}}{{with .ReleaseID}} for release {{.}}{{end}}{{with .Configuration}} on {{configuration .}}{{end}}
+
+## Summary
+
+{{if gt (len .Configurations) 1 -}}
+| Configuration | Total | Passed | Failed | Skipped | Pending | Pass rate |
+|---|---|---|---|---|---|---|
+{{range .Configurations}}| {{configuration .Configuration}} | {{.Total}} | {{.Passed}} | {{.Failed}} | {{.Skipped}} | {{.Pending}} | {{.PassRate}} |
+{{end}}{{with .Totals}}| **All** | {{.Total}} | {{.Passed}} | {{.Failed}} | {{.Skipped}} | {{.Pending}} | {{.PassRate}} |
+{{end}}
+{{- else -}}
+| Total | Passed | Failed | Skipped | Pending | Pass rate |
+|---|---|---|---|---|---|
+{{with .Totals}}| {{.Total}} | {{.Passed}} | {{.Failed}} | {{.Skipped}} | {{.Pending}} | {{.PassRate}} |
+{{end}}
+{{- end}}
+## Results
+{{range .Executions}}
+### {{.Name}}: {{if failed .Status}}**{{status .Status}}**{{else}}{{status .Status}}{{end}}
+{{with .Configuration}}
+Configuration: {{configuration .}}
+{{end}}{{if .Duration}}
+Duration: {{seconds .Duration}}
+{{end}}{{with .ActualResult}}
+Actual result:
+
+` + "```" + `
+{{.}}
+` + "```" + `
+{{end}}{{with .Issues}}
+Issues:
+{{range .}}
+- {{.Link}}{{with .State}} ({{.}}){{end}}
+{{- end}}
+{{end}}{{with .Steps}}
+| # | Step | Expected outcome | Status | Actual result | Attachments and issues |
+|---|---|---|---|---|---|
+{{range .}}| {{.Definition.GetPosition}} | {{with .Definition.GetAction}}{{cell .}}{{else}}{{cell .Definition.GetName}}{{end}} | {{cell .Definition.GetExpectedOutcome}} | {{if failed .Status}}**{{status .Status}}**{{else}}{{status .Status}}{{end}} | {{cell .ActualResult}} | {{range .Attachments}}[{{cell .Name}}]({{.Link}}) {{end}}{{range .Issues}}{{.Link}}{{with .State}} ({{.}}){{end}} {{end}}|
+{{end}}{{end}}{{else}}
+The test plan has no executions.
+{{end}}`
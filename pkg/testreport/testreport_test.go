@@ -0,0 +1,114 @@
+package testreport_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	execution "github.com/curious-kitten/scratch-post/pkg/api/v1/execution"
+	metadata "github.com/curious-kitten/scratch-post/pkg/api/v1/metadata"
+	scenario "github.com/curious-kitten/scratch-post/pkg/api/v1/scenario"
+	testplan "github.com/curious-kitten/scratch-post/pkg/api/v1/testplan"
+	"github.com/curious-kitten/scratch-post/pkg/testreport"
+)
+
+func report() *testreport.Report {
+	return &testreport.Report{
+		TestPlan:  &testplan.TestPlan{Name: "Smoke", Description: "Checks <the> shop"},
+		ReleaseID: "1.2",
+		Generated: time.Date(2021, 3, 1, 14, 48, 0, 0, time.UTC),
+		Executions: []*execution.Execution{
+			{
+				Name:          "Login",
+				Configuration: map[string]string{"os": "linux", "browser": "firefox"},
+				Status:        execution.Status_Fail,
+				ActualResult:  "dashboard not found",
+				Issues:        []*metadata.LinkedIssue{{Link: "https://issues/SHOP-1", State: "Open"}},
+				Steps: []*execution.StepExecution{
+					{Definition: &scenario.Step{Position: 1, Name: "Open", Action: "open the login page"}, Status: execution.Status_Pass},
+					{
+						Definition:   &scenario.Step{Position: 2, Name: "Submit", Action: "submit | log in", ExpectedOutcome: "dashboard\nis shown"},
+						Status:       execution.Status_Fail,
+						ActualResult: "error 500",
+						Attachments:  []*execution.Attachment{{Name: "screenshot.png", Link: "https://files/screenshot.png"}},
+					},
+				},
+			},
+			{Name: "Checkout", Configuration: map[string]string{"browser": "chrome", "os": "linux"}, Status: execution.Status_Pass, Duration: 1.5},
+			{Name: "Login", Configuration: map[string]string{"browser": "chrome", "os": "linux"}, Status: execution.Status_Skipped},
+			{Name: "Search", Configuration: map[string]string{"browser": "chrome", "os": "linux"}},
+		},
+	}
+}
+
+func TestCounts(t *testing.T) {
+	g := NewWithT(t)
+	r := report()
+	g.Expect(r.Totals()).To(Equal(testreport.Counts{Total: 4, Passed: 1, Failed: 1, Skipped: 1, Pending: 1}), "totals did not match")
+	g.Expect(r.Totals().PassRate()).To(Equal("33.3%"), "pending executions are not ignored by the pass rate")
+	g.Expect(testreport.Counts{Total: 2, Pending: 2}.PassRate()).To(Equal("-"), "pass rate is reported without results")
+
+	configurations := r.Configurations()
+	g.Expect(configurations).To(HaveLen(2), "executions were not grouped by configuration")
+	g.Expect(testreport.Configuration(configurations[0].Configuration)).To(Equal("browser=firefox, os=linux"), "configurations are not in the order they were run")
+	g.Expect(configurations[1].Total).To(Equal(3), "executions of a configuration were not counted")
+	g.Expect(configurations[1].Passed).To(Equal(1), "passed executions of a configuration were not counted")
+}
+
+func TestSort(t *testing.T) {
+	g := NewWithT(t)
+	r := report()
+	r.Sort()
+	names := []string{}
+	for _, e := range r.Executions {
+		names = append(names, e.Name+" "+testreport.Configuration(e.Configuration))
+	}
+	g.Expect(names).To(Equal([]string{
+		"Checkout browser=chrome, os=linux",
+		"Login browser=chrome, os=linux",
+		"Login browser=firefox, os=linux",
+		"Search browser=chrome, os=linux",
+	}), "executions are not ordered by name and configuration")
+}
+
+func TestHTML(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	err := testreport.HTML(out, report())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	html := out.String()
+	g.Expect(html).To(HavePrefix("<!DOCTYPE html>"), "report is not an HTML page")
+	g.Expect(html).To(ContainSubstring("<style>"), "styles are not inlined")
+	g.Expect(html).To(ContainSubstring("Checks &lt;the&gt; shop"), "description was not escaped")
+	g.Expect(html).To(ContainSubstring("for release 1.2"), "release is missing")
+	g.Expect(html).To(ContainSubstring("<td>browser=firefox, os=linux</td><td>1</td><td>0</td><td>1</td>"), "configuration summary is missing")
+	g.Expect(html).To(ContainSubstring(`<tr class="failed"><td>2</td>`), "failed step is not highlighted")
+	g.Expect(html).To(ContainSubstring(`<a href="https://files/screenshot.png">screenshot.png</a>`), "attachment is missing")
+	g.Expect(html).To(ContainSubstring(`<a href="https://issues/SHOP-1">https://issues/SHOP-1</a> (Open)`), "issue is missing")
+	g.Expect(html).To(ContainSubstring("Duration: 1.50s"), "duration is missing")
+}
+
+func TestMarkdown(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	err := testreport.Markdown(out, report())
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	markdown := out.String()
+	g.Expect(markdown).To(HavePrefix("# Smoke\n"), "title is missing")
+	g.Expect(markdown).To(ContainSubstring("| **All** | 4 | 1 | 1 | 1 | 1 | 33.3% |"), "totals are missing")
+	g.Expect(markdown).To(ContainSubstring("### Login: **Fail**"), "failed execution is not highlighted")
+	g.Expect(markdown).To(ContainSubstring(`| 2 | submit \| log in | dashboard<br>is shown | **Fail** | error 500 | [screenshot.png](https://files/screenshot.png) |`), "failed step is not highlighted and escaped")
+	g.Expect(markdown).To(ContainSubstring("- https://issues/SHOP-1 (Open)"), "issue is missing")
+}
+
+func TestMarkdown_SingleConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	err := testreport.Markdown(out, &testreport.Report{TestPlan: &testplan.TestPlan{Name: "Empty"}})
+	g.Expect(err).ShouldNot(HaveOccurred(), "unexpected error occurred")
+	g.Expect(out.String()).To(ContainSubstring("| 0 | 0 | 0 | 0 | 0 | - |"), "totals are missing")
+	g.Expect(out.String()).NotTo(ContainSubstring("Configuration |"), "configurations are reported without executions")
+	g.Expect(out.String()).To(ContainSubstring("The test plan has no executions."), "empty test plan is not reported")
+}